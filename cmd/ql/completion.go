@@ -0,0 +1,36 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/lvim-tech/ql/pkg/commands"
+)
+
+// handleCompletions prints one candidate per line for shell completion:
+// module names without arguments, or the subcommands (including aliases)
+// of a module that declares them
+func handleCompletions(args []string) error {
+	all := commands.GetAll()
+
+	if len(args) == 0 {
+		for _, cmd := range all {
+			fmt.Println(cmd.Name)
+		}
+		return nil
+	}
+
+	for _, cmd := range all {
+		if cmd.Name != args[0] {
+			continue
+		}
+		for _, sub := range cmd.Subcommands {
+			fmt.Println(sub.Name)
+			for _, alias := range sub.Aliases {
+				fmt.Println(alias)
+			}
+		}
+		return nil
+	}
+
+	return fmt.Errorf("unknown module: %s", args[0])
+}
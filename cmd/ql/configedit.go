@@ -0,0 +1,146 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/lvim-tech/ql/pkg/commands"
+	"github.com/lvim-tech/ql/pkg/config"
+	"github.com/lvim-tech/ql/pkg/utils"
+)
+
+// terminalEditors need a terminal; any other editor is assumed to open its own window
+var terminalEditors = []string{"vi", "vim", "nvim", "nano", "micro", "hx", "helix", "kak", "emacs", "ne", "joe", "mg"}
+
+// handleConfigEdit opens the user config in the configured editor, creating
+// it first when missing, and validates it after every save. In a terminal a
+// broken config can be re-opened right away; otherwise problems are reported
+// with a notification.
+func handleConfigEdit() error {
+	configPath := config.GetUserConfigPath()
+	if _, err := os.Stat(configPath); os.IsNotExist(err) {
+		if err := handleInit(); err != nil {
+			return err
+		}
+	}
+
+	editor := configEditor()
+	inTerminal := utils.IsTerminal()
+
+	for {
+		if err := openEditor(editor, configPath, inTerminal); err != nil {
+			return err
+		}
+
+		problems, err := validateConfig()
+		if err == nil && len(problems) == 0 {
+			if inTerminal {
+				fmt.Println("Config is valid")
+			}
+			return nil
+		}
+
+		if err != nil {
+			problems = append([]string{err.Error()}, problems...)
+		}
+
+		if !inTerminal {
+			showErrorNotification("ql: Config Problems", strings.Join(problems, "\n"))
+			return fmt.Errorf("%d problem(s) found", len(problems))
+		}
+
+		fmt.Println("Config problems:")
+		for _, problem := range problems {
+			fmt.Printf("  %s\n", problem)
+		}
+
+		if !askYesNo("Edit again? [Y/n] ") {
+			return fmt.Errorf("%d problem(s) found", len(problems))
+		}
+	}
+}
+
+// configEditor returns the editor from the config, or $VISUAL/$EDITOR when
+// the config itself does not load
+func configEditor() string {
+	if cfg, err := config.Load(); err == nil {
+		return cfg.GetEditor()
+	}
+	for _, env := range []string{"VISUAL", "EDITOR"} {
+		if editor := os.Getenv(env); editor != "" {
+			return editor
+		}
+	}
+	return "vim"
+}
+
+// openEditor runs the editor on path and waits for it to exit. Terminal
+// editors run inline when ql has a terminal, or in a new terminal otherwise.
+func openEditor(editor, path string, inTerminal bool) error {
+	fields := strings.Fields(editor)
+	if len(fields) == 0 {
+		return fmt.Errorf("no editor configured")
+	}
+	args := append(fields[1:], path)
+
+	var cmd *exec.Cmd
+	switch {
+	case inTerminal:
+		cmd = exec.Command(fields[0], args...)
+		cmd.Stdin = os.Stdin
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+	case slices.Contains(terminalEditors, filepath.Base(fields[0])):
+		terminal := utils.DetectTerminal()
+		if terminal == "" {
+			return fmt.Errorf("no terminal emulator found to run %s", fields[0])
+		}
+		cmd = exec.Command(terminal, append([]string{"-e", fields[0]}, args...)...)
+	default:
+		// GUI editors need to block until closed (e.g. editor = "code --wait")
+		cmd = exec.Command(fields[0], args...)
+	}
+	cmd.Env = os.Environ()
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("editor exited with error: %w", err)
+	}
+
+	return nil
+}
+
+// validateConfig reloads the config and returns non-fatal problems;
+// the error is set when the config does not load at all
+func validateConfig() ([]string, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	problems := slices.Clone(cfg.Warnings())
+
+	_, unknown := resolveModuleOrder(cfg, commands.GetAll())
+	for _, name := range unknown {
+		problems = append(problems, fmt.Sprintf("module_order: %s is not a registered module", name))
+	}
+
+	return problems, nil
+}
+
+// askYesNo reads an answer from stdin; an empty answer means yes
+func askYesNo(prompt string) bool {
+	fmt.Print(prompt)
+
+	answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil {
+		return false
+	}
+
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "" || answer == "y" || answer == "yes"
+}
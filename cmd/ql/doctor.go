@@ -0,0 +1,85 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/lvim-tech/ql/pkg/commands"
+	"github.com/lvim-tech/ql/pkg/config"
+)
+
+// handleDoctor reports, per module, whether its tools are installed, whether
+// its Init succeeds and whether its own Check passes (config decodes, server reachable, device or
+// backend present). 'ql doctor <module>' checks a single module.
+func handleDoctor(args []string) error {
+	if len(args) > 1 {
+		return fmt.Errorf("usage: ql doctor [module]")
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	for _, warning := range cfg.Warnings() {
+		fmt.Printf("Warning: %s\n", warning)
+	}
+
+	modules := commands.GetAll()
+	if len(args) == 1 {
+		modules = nil
+		for _, cmd := range commands.GetAll() {
+			if cmd.Name == args[0] {
+				modules = append(modules, cmd)
+			}
+		}
+		if len(modules) == 0 {
+			return fmt.Errorf("unknown module: %s", args[0])
+		}
+	}
+
+	problems := 0
+	for _, cmd := range modules {
+		status := doctorStatus(cfg, cmd)
+		if status != "ok" && status != "disabled" {
+			problems++
+		}
+		fmt.Printf("  %-14s %s\n", cmd.Name, status)
+	}
+
+	if problems > 0 {
+		return fmt.Errorf("%d problem(s) found", problems)
+	}
+
+	return nil
+}
+
+// doctorStatus checks one module. Disabled modules are not checked: a missing
+// tool or unreachable server does not matter for them.
+func doctorStatus(cfg *config.Config, cmd commands.Command) string {
+	if !isCommandEnabled(cfg, cmd.Name) {
+		return "disabled"
+	}
+
+	if missing := cmd.MissingRequirements(); len(missing) > 0 {
+		return "missing " + strings.Join(missing, ", ")
+	}
+
+	if cmd.Config != nil {
+		if err := commands.DecodeConfig(cfg.Commands[cmd.Name], cmd.Config()); err != nil {
+			return err.Error()
+		}
+	}
+
+	if err := cmd.Initialize(cfg); err != nil {
+		return err.Error()
+	}
+
+	if cmd.Check != nil {
+		if err := cmd.Check(cfg); err != nil {
+			return err.Error()
+		}
+	}
+
+	return "ok"
+}
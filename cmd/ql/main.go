@@ -1,20 +1,31 @@
 package main
 
 import (
+	"encoding/json"
 	"errors"
 	"flag"
 	"fmt"
+	"io"
+	"maps"
 	"os"
 	"os/exec"
+	"os/signal"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"time"
 
 	"github.com/lvim-tech/ql/pkg/commands"
 	_ "github.com/lvim-tech/ql/pkg/commands/audiorecord"
 	_ "github.com/lvim-tech/ql/pkg/commands/bookman"
 	_ "github.com/lvim-tech/ql/pkg/commands/clipboard"
+	_ "github.com/lvim-tech/ql/pkg/commands/hub"
 	_ "github.com/lvim-tech/ql/pkg/commands/kill"
 	_ "github.com/lvim-tech/ql/pkg/commands/man"
 	_ "github.com/lvim-tech/ql/pkg/commands/mpc"
 	_ "github.com/lvim-tech/ql/pkg/commands/netstat"
+	_ "github.com/lvim-tech/ql/pkg/commands/notifications"
 	_ "github.com/lvim-tech/ql/pkg/commands/power"
 	_ "github.com/lvim-tech/ql/pkg/commands/radio"
 	_ "github.com/lvim-tech/ql/pkg/commands/screenshot"
@@ -23,26 +34,90 @@ import (
 	_ "github.com/lvim-tech/ql/pkg/commands/wifi"
 	"github.com/lvim-tech/ql/pkg/config"
 	"github.com/lvim-tech/ql/pkg/launcher"
+	"github.com/lvim-tech/ql/pkg/utils"
 )
 
 func main() {
-	if err := run(); err != nil {
+	interrupted := handleInterrupts()
+	commands.SetRunner(runCommand)
+
+	err := run()
+
+	// An interrupted module returns once it has stopped; the callbacks of
+	// those that could not clean up after themselves still run
+	if sig, ok := interrupted(); ok {
+		utils.RunCleanup()
+		os.Exit(signalExitCode(sig))
+	}
+
+	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
 }
 
+// interruptGrace is how long an interrupted module gets to return on its own
+// before the cleanup callbacks run and ql exits regardless
+const interruptGrace = 2 * time.Second
+
+// handleInterrupts cancels utils.InterruptContext when ql is interrupted, so
+// modules stop and run their own deferred cleanup (PID files, detached
+// helpers). A module that has not returned within interruptGrace, or a
+// second signal, ends ql after the callbacks registered with utils.OnCleanup
+// (temp files, persistent notifications) ran. The returned function reports
+// the signal received, if any.
+func handleInterrupts() (interrupted func() (os.Signal, bool)) {
+	sigCh := make(chan os.Signal, 2)
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP)
+
+	var received atomic.Value
+
+	go func() {
+		sig := <-sigCh
+		received.Store(sig)
+		utils.LogDebug("main", "signal", sig.String())
+		utils.Interrupt()
+
+		select {
+		case <-sigCh:
+		case <-time.After(interruptGrace):
+		}
+
+		utils.RunCleanup()
+		os.Exit(signalExitCode(sig))
+	}()
+
+	return func() (os.Signal, bool) {
+		sig, ok := received.Load().(os.Signal)
+		return sig, ok
+	}
+}
+
+// signalExitCode is the conventional 128+signal exit status
+func signalExitCode(sig os.Signal) int {
+	if s, ok := sig.(syscall.Signal); ok {
+		return 128 + int(s)
+	}
+	return 130
+}
+
 func run() error {
 	initFlag := flag.Bool("init", false, "Initialize user config")
 	versionFlag := flag.Bool("version", false, "Show version")
 	helpFlag := flag.Bool("help", false, "Show help")
 	flatFlag := flag.Bool("flat", false, "Use flat menu style")
 	groupedFlag := flag.Bool("grouped", false, "Use grouped menu style")
-	launcherFlag := flag.String("launcher", "", "Override launcher (rofi, dmenu, fzf, bemenu, fuzzel)")
+	launcherFlag := flag.String("launcher", "", "Override launcher (rofi, dmenu, fzf, bemenu, fuzzel, tui)")
+	tuiFlag := flag.Bool("tui", false, "Draw menus in the terminal (same as --launcher tui)")
 	groupFlag := flag.String("group", "", "Show only commands from specific group")
+	jsonFlag := flag.Bool("json", false, "Print direct module results as JSON")
+	recentFlag := flag.Bool("recent", false, "Show all modules, most used first")
+	debugFlag := flag.Bool("debug", false, "Log debug output to stderr and the cache log file")
 
 	flag.Parse()
 
+	utils.SetupLogging(*debugFlag || utils.DebugEnabled())
+
 	if *initFlag {
 		return handleInit()
 	}
@@ -57,8 +132,10 @@ func run() error {
 		return nil
 	}
 
-	if len(os.Args) > 1 && !flag.Parsed() {
-		switch os.Args[1] {
+	args := flag.Args()
+
+	if len(args) > 0 {
+		switch args[0] {
 		case "init":
 			return handleInit()
 		case "version":
@@ -67,42 +144,90 @@ func run() error {
 		case "help":
 			printHelp()
 			return nil
+		case "config":
+			return handleConfig(args[1:])
+		case "doctor":
+			return handleDoctor(args[1:])
+		case "completions":
+			return handleCompletions(args[1:])
 		}
 	}
 
+	if *tuiFlag && *launcherFlag != "" && *launcherFlag != "tui" {
+		return fmt.Errorf("--tui and --launcher %s cannot be combined", *launcherFlag)
+	}
+
+	menuFlags := setMenuFlags()
+	if len(menuFlags) > 1 {
+		return fmt.Errorf("--%s and --%s cannot be combined", menuFlags[0], menuFlags[1])
+	}
+
 	cfg, err := config.Load()
 	if err != nil {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
 
-	launcherName := cfg.GetDefaultLauncher()
+	for _, warning := range cfg.Warnings() {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+	}
 
-	if *launcherFlag != "" {
-		launcherName = *launcherFlag
+	// Launcher precedence: --tui, --launcher or the legacy positional
+	// launcher (which must agree), then QL_LAUNCHER, then default_launcher
+	launcherName := *launcherFlag
+	if *tuiFlag {
+		launcherName = "tui"
 	}
 
-	args := flag.Args()
 	if len(args) > 0 {
 		firstArg := args[0]
 
 		if isRegisteredModule(firstArg) {
-			return runDirectModule(cfg, launcherName, firstArg, args[1:])
+			if len(menuFlags) > 0 {
+				return fmt.Errorf("--%s selects a menu and cannot be used with module '%s'", menuFlags[0], firstArg)
+			}
+			if launcherName == "" {
+				if launcherName, err = defaultLauncherName(cfg); err != nil {
+					return err
+				}
+			}
+			return runDirectModule(cfg, launcherName, firstArg, args[1:], *jsonFlag)
 		}
 
-		if firstArg != "init" && firstArg != "version" && firstArg != "help" {
-			launcherName = firstArg
+		if !launcher.IsKnown(firstArg) && firstArg != "auto" {
+			return fmt.Errorf("unknown module or launcher: %s", firstArg)
 		}
+		if len(args) > 1 {
+			return fmt.Errorf("unexpected arguments after launcher %s: %s", firstArg, strings.Join(args[1:], " "))
+		}
+		if launcherName != "" && launcherName != firstArg {
+			return fmt.Errorf("launcher %s conflicts with --launcher %s", firstArg, launcherName)
+		}
+		launcherName = firstArg
 	}
 
-	ctx, err := launcher.New(launcherName, cfg)
+	if *jsonFlag {
+		return fmt.Errorf("--json only applies to modules run directly (ql --json MODULE ...)")
+	}
+
+	if launcherName == "" {
+		if launcherName, err = defaultLauncherName(cfg); err != nil {
+			return err
+		}
+	}
+
+	ctx, err := newLauncher(launcherName, cfg)
 	if err != nil {
-		return fmt.Errorf("failed to create launcher: %w", err)
+		return err
 	}
 
 	if *groupFlag != "" {
 		return runSpecificGroup(ctx, cfg, *groupFlag)
 	}
 
+	if *recentFlag {
+		return runRecentMenu(ctx, cfg)
+	}
+
 	menuStyle := cfg.GetMenuStyle()
 
 	if *flatFlag {
@@ -118,6 +243,56 @@ func run() error {
 	return runFlatMenu(ctx, cfg)
 }
 
+// setMenuFlags returns the menu-selecting flags given on the command line.
+// Each picks a different root menu, so more than one (or one together with a
+// direct module) is an error instead of one of them being silently ignored.
+func setMenuFlags() []string {
+	var set []string
+	flag.Visit(func(f *flag.Flag) {
+		switch value := f.Value.String(); f.Name {
+		case "flat", "grouped", "recent", "group":
+			if value != "false" && value != "" {
+				set = append(set, f.Name)
+			}
+		}
+	})
+	return set
+}
+
+// launcherEnv overrides default_launcher for a session; --launcher and
+// --tui still take precedence
+const launcherEnv = "QL_LAUNCHER"
+
+// defaultLauncherName is the launcher used when none is given on the command
+// line: QL_LAUNCHER when set, else default_launcher
+func defaultLauncherName(cfg *config.Config) (string, error) {
+	name := strings.TrimSpace(os.Getenv(launcherEnv))
+	if name == "" {
+		return cfg.GetDefaultLauncher(), nil
+	}
+	if !launcher.IsKnown(name) && name != "auto" {
+		return "", fmt.Errorf("%s: unknown launcher %s", launcherEnv, name)
+	}
+	return name, nil
+}
+
+// newLauncher creates the launcher and reports a missing or replaced launcher
+// with a notification, since ql usually runs from a keybind without a terminal
+func newLauncher(name string, cfg *config.Config) (launcher.Launcher, error) {
+	ctx, err := launcher.New(name, cfg)
+	if err != nil {
+		showErrorNotification("ql: Launcher Error", err.Error())
+		return nil, fmt.Errorf("failed to create launcher: %w", err)
+	}
+
+	if name != "auto" && name != ctx.Name() && launcher.IsKnown(name) {
+		showErrorNotification("ql: Launcher Fallback",
+			fmt.Sprintf("launcher '%s' is not installed, using %s", name, ctx.Name()))
+	}
+
+	return ctx, nil
+}
+
 func isRegisteredModule(name string) bool {
 	registeredCommands := commands.GetAll()
 	for _, cmd := range registeredCommands {
@@ -128,7 +303,7 @@ func isRegisteredModule(name string) bool {
 	return false
 }
 
-func runDirectModule(cfg *config.Config, launcherName string, moduleName string, moduleArgs []string) error {
+func runDirectModule(cfg *config.Config, launcherName string, moduleName string, moduleArgs []string, jsonOutput bool) error {
 	registeredCommands := commands.GetAll()
 
 	var targetCmd *commands.Command
@@ -147,15 +322,115 @@ func runDirectModule(cfg *config.Config, launcherName string, moduleName string,
 		return fmt.Errorf("module '%s' is disabled in config", moduleName)
 	}
 
+	// Subcommands that never open a menu must work without a launcher
 	ctx, err := launcher.New(launcherName, cfg)
 	if err != nil {
-		return fmt.Errorf("failed to create launcher: %w", err)
+		ctx = launcher.NewUnavailable(launcherName, cfg, err, func(err error) {
+			showErrorNotification("ql: Launcher Error", err.Error())
+		})
+	}
+
+	// --json may also follow the module arguments (ql wifi status --json)
+	var filteredArgs []string
+	for _, arg := range moduleArgs {
+		if arg == "--json" || arg == "-json" {
+			jsonOutput = true
+			continue
+		}
+		filteredArgs = append(filteredArgs, arg)
+	}
+
+	filteredArgs, err = expandStdinArg(filteredArgs, os.Stdin)
+	if err != nil {
+		return err
 	}
 
 	ctx.SetDirectLaunch(true)
-	ctx.SetArgs(moduleArgs)
+	ctx.SetJSONOutput(jsonOutput)
+	ctx.SetArgs(filteredArgs)
+
+	result := runCommand(ctx, *targetCmd)
 
-	result := targetCmd.Run(ctx)
+	if jsonOutput {
+		return printJSONResult(result)
+	}
+
+	if !result.Success && result.Error != nil && !errors.Is(result.Error, commands.ErrBack) {
+		return result.Error
+	}
+
+	return nil
+}
+
+// expandStdinArg replaces a "-" module argument with the lines read from stdin,
+// each non-empty line becoming a separate argument (echo Sofia | ql weather -).
+// stdin can only be read once, so a second "-" is an error.
+func expandStdinArg(args []string, stdin *os.File) ([]string, error) {
+	if !slices.Contains(args, "-") {
+		return args, nil
+	}
+
+	if info, err := stdin.Stat(); err == nil && info.Mode()&os.ModeCharDevice != 0 {
+		return nil, fmt.Errorf("argument '-' reads from stdin, but nothing is piped in")
+	}
+
+	data, err := io.ReadAll(stdin)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read stdin: %w", err)
+	}
+
+	var lines []string
+	for _, line := range strings.Split(string(data), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	if len(lines) == 0 {
+		return nil, fmt.Errorf("argument '-' got empty stdin")
+	}
+
+	var result []string
+	expanded := false
+	for _, arg := range args {
+		if arg != "-" {
+			result = append(result, arg)
+			continue
+		}
+		if expanded {
+			return nil, fmt.Errorf("argument '-' can only be used once")
+		}
+		result = append(result, lines...)
+		expanded = true
+	}
+
+	return result, nil
+}
+
+// jsonResult is the structured form of a CommandResult printed in JSON mode
+type jsonResult struct {
+	Success bool   `json:"success"`
+	Message string `json:"message,omitempty"`
+	Data    any    `json:"data,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+func printJSONResult(result commands.CommandResult) error {
+	out := jsonResult{
+		Success: result.Success,
+		Message: result.Message,
+		Data:    result.Data,
+	}
+	if result.Error != nil {
+		out.Error = result.Error.Error()
+	}
+
+	data, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode result: %w", err)
+	}
+
+	fmt.Println(string(data))
 
 	if !result.Success && result.Error != nil && !errors.Is(result.Error, commands.ErrBack) {
 		return result.Error
@@ -165,7 +440,7 @@ func runDirectModule(cfg *config.Config, launcherName string, moduleName string,
 }
 
 func runSpecificGroup(ctx launcher.Launcher, cfg *config.Config, groupName string) error {
-	groups := cfg.GetModuleGroups()
+	groups := resolveModuleGroups(cfg, commands.GetAll())
 
 	var selectedGroup *config.ModuleGroup
 
@@ -198,42 +473,108 @@ func runSpecificGroup(ctx launcher.Launcher, cfg *config.Config, groupName strin
 
 	result := runModuleMenuDirect(ctx, cfg, *selectedGroup, commandMap)
 
-	if !result.Success && result.Error != nil {
+	if !result.Success && result.Error != nil && !errors.Is(result.Error, commands.ErrCancelled) {
 		return result.Error
 	}
 
 	return nil
 }
 
+// resolveModuleOrder returns module_order restricted to registered commands,
+// followed by any registered command it doesn't list, so a typo or a new
+// module never makes a module disappear. Unknown entries are returned separately.
+func resolveModuleOrder(cfg *config.Config, registeredCommands []commands.Command) ([]string, []string) {
+	var order, unknown []string
+
+	for _, name := range cfg.GetModuleOrder() {
+		if !isRegisteredModule(name) {
+			unknown = append(unknown, name)
+			continue
+		}
+		if !slices.Contains(order, name) {
+			order = append(order, name)
+		}
+	}
+
+	for _, cmd := range registeredCommands {
+		if !slices.Contains(order, cmd.Name) {
+			order = append(order, cmd.Name)
+		}
+	}
+
+	return order, unknown
+}
+
 func runFlatMenu(ctx launcher.Launcher, cfg *config.Config) error {
 	registeredCommands := commands.GetAll()
 	if len(registeredCommands) == 0 {
 		return fmt.Errorf("no commands registered")
 	}
 
-	commandMap := make(map[string]commands.Command)
-	for _, cmd := range registeredCommands {
-		commandMap[cmd.Name] = cmd
+	moduleOrder, _ := resolveModuleOrder(cfg, registeredCommands)
+
+	var frequent []string
+	if cfg.GetShowFrequent() {
+		frequent = frequentModules(cfg.GetFrequentCount())
+	}
+
+	return runModuleList(ctx, cfg, registeredCommands, moduleOrder, frequent)
+}
+
+// runRecentMenu is the flat menu ordered by usage, most used modules first (ql --recent)
+func runRecentMenu(ctx launcher.Launcher, cfg *config.Config) error {
+	registeredCommands := commands.GetAll()
+	if len(registeredCommands) == 0 {
+		return fmt.Errorf("no commands registered")
 	}
 
-	moduleOrder := cfg.GetModuleOrder()
-	if len(moduleOrder) == 0 {
-		for _, cmd := range registeredCommands {
-			moduleOrder = append(moduleOrder, cmd.Name)
+	moduleOrder, _ := resolveModuleOrder(cfg, registeredCommands)
+
+	var order []string
+	for _, name := range frequentModules(0) {
+		if slices.Contains(moduleOrder, name) {
+			order = append(order, name)
 		}
 	}
+	for _, name := range moduleOrder {
+		if !slices.Contains(order, name) {
+			order = append(order, name)
+		}
+	}
+
+	return runModuleList(ctx, cfg, registeredCommands, order, nil)
+}
+
+// runModuleList shows modules in the given order as a flat menu, with the
+// frequent modules repeated in a "★" section on top
+func runModuleList(ctx launcher.Launcher, cfg *config.Config, registeredCommands []commands.Command, moduleOrder, frequent []string) error {
+	commandMap := make(map[string]commands.Command)
+	for _, cmd := range registeredCommands {
+		commandMap[cmd.Name] = cmd
+	}
 
 	for {
 		var options []string
 		optionToCommand := make(map[string]commands.Command)
 
+		for _, moduleName := range frequent {
+			cmd, exists := commandMap[moduleName]
+			if !exists || !isCommandAvailable(cfg, cmd) {
+				continue
+			}
+
+			option := "★ " + cmd.Description
+			options = append(options, option)
+			optionToCommand[option] = cmd
+		}
+
 		for _, moduleName := range moduleOrder {
 			cmd, exists := commandMap[moduleName]
 			if !exists {
 				continue
 			}
 
-			if !isCommandEnabled(cfg, cmd.Name) {
+			if !isCommandAvailable(cfg, cmd) {
 				continue
 			}
 
@@ -245,7 +586,7 @@ func runFlatMenu(ctx launcher.Launcher, cfg *config.Config) error {
 			return fmt.Errorf("no enabled commands")
 		}
 
-		choice, err := ctx.Show(options, "ql")
+		choice, err := ctx.ShowOrdered(options, "ql")
 		if err != nil {
 			return nil
 		}
@@ -256,7 +597,7 @@ func runFlatMenu(ctx launcher.Launcher, cfg *config.Config) error {
 			continue
 		}
 
-		result := cmd.Run(ctx)
+		result := runCommand(ctx, cmd)
 		if errors.Is(result.Error, commands.ErrBack) {
 			continue
 		}
@@ -276,13 +617,18 @@ func runGroupedMenu(ctx launcher.Launcher, cfg *config.Config) error {
 		commandMap[cmd.Name] = cmd
 	}
 
-	groups := cfg.GetModuleGroups()
+	groups := resolveModuleGroups(cfg, registeredCommands)
 	if len(groups) == 0 {
 		return runFlatMenu(ctx, cfg)
 	}
 
 	groupOrder := cfg.GetModuleGroupsOrder()
 
+	var ungrouped config.ModuleGroup
+	if cfg.GetShowUngrouped() {
+		ungrouped = ungroupedModules(cfg, groups, groupOrder, registeredCommands)
+	}
+
 	for {
 		var groupOptions []string
 		groupMap := make(map[string]config.ModuleGroup)
@@ -295,7 +641,7 @@ func runGroupedMenu(ctx launcher.Launcher, cfg *config.Config) error {
 
 			hasEnabled := false
 			for _, moduleName := range group.Modules {
-				if isCommandEnabled(cfg, moduleName) {
+				if cmd, exists := commandMap[moduleName]; exists && isCommandAvailable(cfg, cmd) {
 					hasEnabled = true
 					break
 				}
@@ -307,6 +653,11 @@ func runGroupedMenu(ctx launcher.Launcher, cfg *config.Config) error {
 			}
 		}
 
+		if len(ungrouped.Modules) > 0 {
+			groupOptions = append(groupOptions, ungrouped.Name)
+			groupMap[ungrouped.Name] = ungrouped
+		}
+
 		if len(groupOptions) == 0 {
 			return fmt.Errorf("no enabled command groups")
 		}
@@ -336,18 +687,114 @@ func runGroupedMenu(ctx launcher.Launcher, cfg *config.Config) error {
 	}
 }
 
+// moduleWildcard in a group's modules stands for every module that no group
+// lists by name and no earlier wildcard has taken
+const moduleWildcard = "*"
+
+// resolveModuleGroups returns the enabled groups with "*" expanded in place,
+// in module order. Groups are visited in module_groups_order, then the rest
+// by key, so the expansion never depends on map order and a module lands in
+// one group only.
+func resolveModuleGroups(cfg *config.Config, registeredCommands []commands.Command) map[string]config.ModuleGroup {
+	groups := cfg.GetModuleGroups()
+
+	var keys []string
+	for _, key := range cfg.GetModuleGroupsOrder() {
+		if _, exists := groups[key]; exists && !slices.Contains(keys, key) {
+			keys = append(keys, key)
+		}
+	}
+	for _, key := range slices.Sorted(maps.Keys(groups)) {
+		if !slices.Contains(keys, key) {
+			keys = append(keys, key)
+		}
+	}
+
+	claimed := make(map[string]bool)
+	for _, group := range groups {
+		for _, moduleName := range group.Modules {
+			if moduleName != moduleWildcard {
+				claimed[moduleName] = true
+			}
+		}
+	}
+
+	moduleOrder, _ := resolveModuleOrder(cfg, registeredCommands)
+
+	for _, key := range keys {
+		group := groups[key]
+		if !slices.Contains(group.Modules, moduleWildcard) {
+			continue
+		}
+
+		var modules []string
+		for _, moduleName := range group.Modules {
+			if moduleName != moduleWildcard {
+				modules = append(modules, moduleName)
+				continue
+			}
+			for _, name := range moduleOrder {
+				if !claimed[name] {
+					claimed[name] = true
+					modules = append(modules, name)
+				}
+			}
+		}
+
+		group.Modules = modules
+		groups[key] = group
+	}
+
+	return groups
+}
+
+// ungroupedModules collects available modules that are not shown by any group
+// of the grouped menu, so new modules don't vanish until groups are edited
+func ungroupedModules(cfg *config.Config, groups map[string]config.ModuleGroup, groupOrder []string, registeredCommands []commands.Command) config.ModuleGroup {
+	grouped := make(map[string]bool)
+	for _, groupKey := range groupOrder {
+		if group, exists := groups[groupKey]; exists {
+			for _, moduleName := range group.Modules {
+				grouped[moduleName] = true
+			}
+		}
+	}
+
+	commandMap := make(map[string]commands.Command)
+	for _, cmd := range registeredCommands {
+		commandMap[cmd.Name] = cmd
+	}
+
+	moduleOrder, _ := resolveModuleOrder(cfg, registeredCommands)
+
+	group := config.ModuleGroup{Name: "Other", Enabled: true}
+	for _, moduleName := range moduleOrder {
+		cmd, exists := commandMap[moduleName]
+		if !exists || grouped[moduleName] || !isCommandAvailable(cfg, cmd) {
+			continue
+		}
+		group.Modules = append(group.Modules, moduleName)
+	}
+
+	return group
+}
+
+// runModuleMenuDirect shows a single group as the top-level menu (ql --group).
+// Finished modules return to the group menu; "← Back" and ESC exit ql.
 func runModuleMenuDirect(ctx launcher.Launcher, cfg *config.Config, group config.ModuleGroup, commandMap map[string]commands.Command) commands.CommandResult {
 	for {
 		var moduleOptions []string
 		moduleToCommand := make(map[string]commands.Command)
 
+		moduleOptions = append(moduleOptions, "← Back")
+
 		for _, moduleName := range group.Modules {
 			cmd, exists := commandMap[moduleName]
 			if !exists {
 				continue
 			}
 
-			if !isCommandEnabled(cfg, cmd.Name) {
+			if !isCommandAvailable(cfg, cmd) {
 				continue
 			}
 
@@ -355,7 +802,7 @@ func runModuleMenuDirect(ctx launcher.Launcher, cfg *config.Config, group config
 			moduleToCommand[cmd.Description] = cmd
 		}
 
-		if len(moduleOptions) == 0 {
+		if len(moduleOptions) == 1 {
 			return commands.CommandResult{
 				Success: false,
 				Error:   fmt.Errorf("no enabled commands in group"),
@@ -367,13 +814,22 @@ func runModuleMenuDirect(ctx launcher.Launcher, cfg *config.Config, group config
 			return commands.CommandResult{Success: false}
 		}
 
+		// There is no parent menu in --group mode, so back exits
+		if moduleChoice == "← Back" {
+			return commands.CommandResult{Success: true}
+		}
+
 		cmd, ok := moduleToCommand[moduleChoice]
 		if !ok {
 			showErrorNotification("Error", fmt.Sprintf("Unknown command: %s", moduleChoice))
 			continue
 		}
 
-		result := cmd.Run(ctx)
+		result := runCommand(ctx, cmd)
+
+		if result.Success || errors.Is(result.Error, commands.ErrBack) {
+			continue
+		}
 
 		return result
 	}
@@ -392,7 +848,7 @@ func runModuleMenuWithBack(ctx launcher.Launcher, cfg *config.Config, group conf
 				continue
 			}
 
-			if !isCommandEnabled(cfg, cmd.Name) {
+			if !isCommandAvailable(cfg, cmd) {
 				continue
 			}
 
@@ -425,7 +881,7 @@ func runModuleMenuWithBack(ctx launcher.Launcher, cfg *config.Config, group conf
 			continue
 		}
 
-		result := cmd.Run(ctx)
+		result := runCommand(ctx, cmd)
 
 		if result.Success {
 			return result
@@ -440,18 +896,74 @@ func runModuleMenuWithBack(ctx launcher.Launcher, cfg *config.Config, group conf
 }
 
 func isCommandEnabled(cfg *config.Config, cmdName string) bool {
-	commandCfg, exists := cfg.Commands[cmdName]
-	if !exists {
-		return true
+	return cfg.IsCommandEnabled(cmdName)
+}
+
+// isCommandAvailable reports whether a command is enabled, all its required
+// tools are installed and its Init has not failed. Init itself runs lazily,
+// on the first launch.
+func isCommandAvailable(cfg *config.Config, cmd commands.Command) bool {
+	return isCommandEnabled(cfg, cmd.Name) && len(cmd.MissingRequirements()) == 0 && !cmd.InitFailed()
+}
+
+// runCommand verifies the command's required tools and runs its Init, the
+// first time, before invoking it under its module_timeout, if any. Launches
+// without arguments (from a menu, or 'ql <module>') are counted for the
+// frequent modules section; direct actions such as 'ql mpc status', which a
+// status bar may run every second, are not. Modules that open other modules
+// (hub) reach it through commands.Launch.
+func runCommand(ctx commands.LauncherContext, cmd commands.Command) commands.CommandResult {
+	if missing := cmd.MissingRequirements(); len(missing) > 0 {
+		err := missingRequirementsError(cmd.Name, missing)
+		notifCfg := ctx.Config().GetNotificationConfig()
+		utils.ShowErrorNotificationWithConfig(&notifCfg, "Missing Dependency", err.Error())
+		return commands.CommandResult{Success: false, Error: err}
 	}
 
-	if enabledVal, ok := commandCfg["enabled"]; ok {
-		if enabled, ok := enabledVal.(bool); ok {
-			return enabled
-		}
+	if err := cmd.Initialize(ctx.Config()); err != nil {
+		err = fmt.Errorf("module %s cannot run: %w", cmd.Name, err)
+		notifCfg := ctx.Config().GetNotificationConfig()
+		utils.ShowErrorNotificationWithConfig(&notifCfg, "Module Unavailable", err.Error())
+		return commands.CommandResult{Success: false, Error: err}
+	}
+
+	if cmd.Background != nil && cmd.Background(ctx.Args()) {
+		return cmd.Run(ctx)
 	}
 
-	return true
+	if len(ctx.Args()) == 0 {
+		recordUsage(cmd.Name)
+	}
+	utils.LogDebug("run module", "module", cmd.Name, "args", strings.Join(ctx.Args(), " "))
+
+	result := runModule(ctx, cmd)
+	utils.LogDebug("module finished", "module", cmd.Name, "success", result.Success, "err", result.Error)
+
+	// keep_open shows the module's menu again after each completed action,
+	// until the user goes back (ErrBack) or closes the menu. Direct actions
+	// (ql mpc next) never loop: they have no menu to return to.
+	for result.Success && len(ctx.Args()) == 0 && ctx.Config().GetKeepOpen(cmd.Name) {
+		result = runModule(ctx, cmd)
+		utils.LogDebug("module finished", "module", cmd.Name, "success", result.Success, "err", result.Error)
+	}
+
+	return result
+}
+
+// runModule runs the command under its module_timeout, if any
+func runModule(ctx commands.LauncherContext, cmd commands.Command) commands.CommandResult {
+	if timeout := ctx.Config().GetModuleTimeout(cmd.Name); timeout > 0 {
+		return runWithTimeout(ctx, cmd, timeout)
+	}
+
+	return cmd.Run(ctx)
+}
+
+func missingRequirementsError(name string, missing []string) error {
+	if len(missing) == 1 {
+		return fmt.Errorf("module %s needs tool %s (not installed)", name, missing[0])
+	}
+	return fmt.Errorf("module %s needs tools %s (not installed)", name, strings.Join(missing, ", "))
 }
 
 func showErrorNotification(title, message string) {
@@ -491,11 +1003,120 @@ func handleInit() error {
 	return nil
 }
 
+func handleConfig(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: ql config <edit|upgrade|check|show>")
+	}
+
+	switch args[0] {
+	case "edit":
+		return handleConfigEdit()
+	case "upgrade":
+		return handleConfigUpgrade()
+	case "check":
+		return handleConfigCheck()
+	case "show":
+		return handleConfigShow()
+	default:
+		return fmt.Errorf("unknown config action: %s (use: edit, upgrade, check, show)", args[0])
+	}
+}
+
+// handleConfigShow prints the merged config (defaults, includes and user
+// config) as ql uses it. Load warnings go to stderr so the output stays valid TOML.
+func handleConfigShow() error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	for _, warning := range cfg.Warnings() {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+	}
+
+	data, err := cfg.EncodeTOML()
+	if err != nil {
+		return err
+	}
+
+	fmt.Print(data)
+	return nil
+}
+
+func handleConfigUpgrade() error {
+	configPath := config.GetUserConfigPath()
+	if _, err := os.Stat(configPath); os.IsNotExist(err) {
+		return fmt.Errorf("no user config found at %s (run 'ql --init' first)", configPath)
+	}
+
+	from, err := config.UpgradeUserConfig()
+	if err != nil {
+		return err
+	}
+
+	if from >= config.CurrentConfigVersion {
+		fmt.Printf("Config is up to date (version %d)\n", from)
+		return nil
+	}
+
+	fmt.Printf("Config upgraded from version %d to %d: %s\n", from, config.CurrentConfigVersion, configPath)
+	fmt.Printf("Previous config saved to: %s.bak\n", configPath)
+
+	return nil
+}
+
+func handleConfigCheck() error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	problems := 0
+
+	if warnings := cfg.Warnings(); len(warnings) > 0 {
+		fmt.Println("Config files:")
+		for _, warning := range warnings {
+			fmt.Printf("  %s\n", warning)
+		}
+		fmt.Println()
+		problems += len(warnings)
+	}
+
+	fmt.Println("Module dependencies:")
+	for _, cmd := range commands.GetAll() {
+		status := "ok"
+		if missing := cmd.MissingRequirements(); len(missing) > 0 {
+			status = "missing " + strings.Join(missing, ", ")
+			problems++
+		}
+		if !isCommandEnabled(cfg, cmd.Name) {
+			status += " (disabled)"
+		}
+		fmt.Printf("  %-14s %s\n", cmd.Name, status)
+	}
+
+	if _, unknown := resolveModuleOrder(cfg, commands.GetAll()); len(unknown) > 0 {
+		fmt.Println()
+		fmt.Println("Module order:")
+		for _, name := range unknown {
+			fmt.Printf("  %-12s not a registered module\n", name)
+		}
+		problems += len(unknown)
+	}
+
+	if problems > 0 {
+		return fmt.Errorf("%d problem(s) found", problems)
+	}
+
+	return nil
+}
+
 func printHelp() {
 	fmt.Println("ql - Quick Launcher")
 	fmt.Println()
 	fmt.Println("Usage:")
 	fmt.Println("  ql [options] [module] [subcommand]")
+	fmt.Println("  A '-' module argument is read from stdin, one argument per line")
 	fmt.Println()
 	fmt.Println("Options:")
 	fmt.Println("  --init              Initialize user config (~/.config/ql/config. toml)")
@@ -503,8 +1124,16 @@ func printHelp() {
 	fmt.Println("  --help              Show this help message")
 	fmt.Println("  --flat              Use flat menu style")
 	fmt.Println("  --grouped           Use grouped menu style")
-	fmt.Println("  --launcher NAME     Override launcher (rofi, dmenu, fzf, bemenu, fuzzel)")
+	fmt.Println("  --launcher NAME     Override launcher (rofi, dmenu, fzf, bemenu, fuzzel, tui)")
+	fmt.Println("  --tui               Draw menus in the terminal (arrows to move, type to filter, Tab to mark)")
 	fmt.Println("  --group NAME        Show only commands from specific group")
+	fmt.Println("  --recent            Show all modules, most used first")
+	fmt.Println("  --debug             Log commands and results to stderr and ~/.cache/ql/ql.log (or QL_DEBUG=1)")
+	fmt.Println("  --json              Print direct module results as JSON (wifi status, netstat traffic, mpc status)")
+	fmt.Println()
+	fmt.Println("Options go before the module; later arguments belong to the module (except --json).")
+	fmt.Println("--flat, --grouped, --group and --recent pick a menu: use at most one, and none with a module.")
+	fmt.Println("Launcher: --tui, then --launcher (or a legacy positional launcher), then QL_LAUNCHER, then default_launcher.")
 	fmt.Println()
 	fmt.Println("Available groups:")
 	fmt.Println("  system, network, media, info")
@@ -514,7 +1143,25 @@ func printHelp() {
 	fmt.Println("  ql power logout     Execute logout directly")
 	fmt.Println("  ql power shutdown   Execute shutdown directly")
 	fmt.Println("  ql clipboard        Run clipboard module")
+	fmt.Println("  ql clipboard export FILE  Save the full history as JSON (images base64); import FILE restores it")
 	fmt.Println("  ql kill             Run kill module")
+	fmt.Println("  ql kill --tree PID  Kill a process and all its children")
+	fmt.Println("  ql kill --trend [SECONDS]  Mark processes whose memory grew (▲) or shrank (▼)")
+	fmt.Println("  ql kill --unit [UNIT]  Stop a systemd user unit (pick one when omitted)")
+	fmt.Println("  ql screenshot region --annotate  Capture a region and edit it before saving")
+	fmt.Println("  ql screenshot region --upload    Capture a region and upload it (enable_upload), URL to clipboard")
+	fmt.Println("  ql screenshot color              Pick a pixel's color and copy it (color_format: hex or rgb)")
+	fmt.Println("  ql mpc status --format FMT       Print now playing for status bars (mpc format)")
+	fmt.Println()
+	fmt.Println("Config management:")
+	fmt.Println("  ql config edit      Open the config in the editor and validate it after saving")
+	fmt.Println("  ql config upgrade   Migrate user config to the current schema version")
+	fmt.Println("  ql config check     Report missing module dependencies, unknown module_order entries and include problems")
+	fmt.Println("  ql config show      Print the effective config after merging defaults, includes and user config")
+	fmt.Println("  ql doctor [MODULE]  Check each enabled module's tools and preconditions (MPD reachable, wifi device, ...)")
+	fmt.Println()
+	fmt.Println("Shell completion:")
+	fmt.Println("  ql completions [MODULE]  List modules, or a module's subcommands, one per line")
 	fmt.Println()
 	fmt.Println("Legacy usage (still supported):")
 	fmt.Println("  ql [launcher]       Run ql with specified launcher")
@@ -526,6 +1173,7 @@ func printHelp() {
 	fmt.Println("  ql power logout")
 	fmt.Println("  ql power shutdown")
 	fmt.Println("  ql --launcher fuzzel power")
+	fmt.Println("  ql --launcher fuzzel --group media")
 	fmt.Println("  ql --flat --launcher rofi")
 	fmt.Println("  ql --grouped")
 	fmt.Println("  ql --group system")
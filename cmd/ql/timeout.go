@@ -0,0 +1,150 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/lvim-tech/ql/pkg/commands"
+	"github.com/lvim-tech/ql/pkg/utils"
+)
+
+// watchdog wraps a launcher and enforces a module timeout that only runs while
+// the module is working, not while it waits for the user in a launcher menu
+type watchdog struct {
+	commands.LauncherContext
+
+	timeout time.Duration
+	expired chan struct{}
+	once    sync.Once
+
+	// ctx is cancelled when the deadline passes
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mu      sync.Mutex
+	timer   *time.Timer
+	stopped bool
+}
+
+func newWatchdog(l commands.LauncherContext, timeout time.Duration) *watchdog {
+	ctx, cancel := context.WithCancel(l.Context())
+	w := &watchdog{
+		LauncherContext: l,
+		timeout:         timeout,
+		expired:         make(chan struct{}),
+		ctx:             ctx,
+		cancel:          cancel,
+	}
+	w.timer = time.AfterFunc(timeout, func() {
+		w.once.Do(func() {
+			w.cancel()
+			close(w.expired)
+		})
+	})
+	return w
+}
+
+// Context is cancelled when the module times out
+func (w *watchdog) Context() context.Context {
+	return w.ctx
+}
+
+// Show pauses the deadline while the menu is open and restarts it afterwards
+func (w *watchdog) Show(options []string, prompt string) (string, error) {
+	w.pause()
+	defer w.resume()
+	return w.LauncherContext.Show(options, prompt)
+}
+
+// ShowOrdered pauses the deadline like Show
+func (w *watchdog) ShowOrdered(options []string, prompt string) (string, error) {
+	w.pause()
+	defer w.resume()
+	return w.LauncherContext.ShowOrdered(options, prompt)
+}
+
+// ShowAllowCustom pauses the deadline like Show
+func (w *watchdog) ShowAllowCustom(options []string, prompt string) (string, error) {
+	w.pause()
+	defer w.resume()
+	return w.LauncherContext.ShowAllowCustom(options, prompt)
+}
+
+// ShowMulti pauses the deadline like Show
+func (w *watchdog) ShowMulti(options []string, prompt string) ([]string, error) {
+	w.pause()
+	defer w.resume()
+	return w.LauncherContext.ShowMulti(options, prompt)
+}
+
+// ShowWithMessage pauses the deadline like Show
+func (w *watchdog) ShowWithMessage(options []string, prompt, message string) (string, error) {
+	w.pause()
+	defer w.resume()
+	return w.LauncherContext.ShowWithMessage(options, prompt, message)
+}
+
+func (w *watchdog) pause() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.timer.Stop()
+}
+
+func (w *watchdog) resume() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if !w.stopped {
+		w.timer.Reset(w.timeout)
+	}
+}
+
+func (w *watchdog) stop() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.stopped = true
+	w.timer.Stop()
+	w.cancel()
+}
+
+// timeoutGrace is how long a timed out module gets to unwind once its
+// context is cancelled, so its commands are killed before ql exits
+const timeoutGrace = 2 * time.Second
+
+// runWithTimeout runs the command under the module timeout. A module that
+// exceeds it has its context cancelled, which kills the commands it runs
+// through utils.RunCommandContext or RunCommandTimeout; its result is
+// ignored and an error is returned.
+func runWithTimeout(ctx commands.LauncherContext, cmd commands.Command, timeout time.Duration) commands.CommandResult {
+	wd := newWatchdog(ctx, timeout)
+	utils.SetCommandContext(wd.Context())
+	defer utils.SetCommandContext(utils.InterruptContext())
+	// Password prompts are not launcher menus but wait for the user as well
+	utils.SetPromptPause(func() func() {
+		wd.pause()
+		return wd.resume
+	})
+	defer utils.SetPromptPause(nil)
+
+	done := make(chan commands.CommandResult, 1)
+	go func() {
+		done <- cmd.Run(wd)
+	}()
+
+	select {
+	case result := <-done:
+		wd.stop()
+		return result
+	case <-wd.expired:
+		select {
+		case <-done:
+		case <-time.After(timeoutGrace):
+		}
+
+		err := fmt.Errorf("module %s timed out after %s", cmd.Name, timeout)
+		notifCfg := ctx.Config().GetNotificationConfig()
+		utils.ShowErrorNotificationWithConfig(&notifCfg, "Module Timed Out", err.Error())
+		return commands.CommandResult{Success: false, Error: err}
+	}
+}
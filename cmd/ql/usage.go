@@ -0,0 +1,90 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/lvim-tech/ql/pkg/config"
+	"github.com/lvim-tech/ql/pkg/utils"
+)
+
+// moduleUsage records how often and how recently a module was launched
+type moduleUsage struct {
+	Count    int       `json:"count"`
+	LastUsed time.Time `json:"last_used"`
+}
+
+// usageStateKey is where launch counts live in the state file
+const usageStateKey = "usage"
+
+// legacyUsageFile is where launch counts were kept before the shared state file
+func legacyUsageFile() string {
+	return filepath.Join(utils.GetDataDir(), "ql", "usage.json")
+}
+
+// loadUsage reads the usage state; a missing or broken file means no history
+func loadUsage() map[string]moduleUsage {
+	usage := make(map[string]moduleUsage)
+
+	state, err := config.LoadState()
+	if err == nil && state.Get(usageStateKey, &usage) {
+		return usage
+	}
+
+	if data, err := os.ReadFile(legacyUsageFile()); err == nil {
+		json.Unmarshal(data, &usage)
+	}
+	return usage
+}
+
+// recordUsage counts a module launch. Failures are ignored: usage tracking
+// must never keep a module from running. A state file that cannot be read is
+// left alone, since it holds other modules' data as well.
+func recordUsage(name string) {
+	err := config.UpdateState(func(state *config.State) error {
+		usage := make(map[string]moduleUsage)
+		if !state.Get(usageStateKey, &usage) {
+			if data, err := os.ReadFile(legacyUsageFile()); err == nil {
+				json.Unmarshal(data, &usage)
+			}
+		}
+
+		entry := usage[name]
+		entry.Count++
+		entry.LastUsed = time.Now()
+		usage[name] = entry
+
+		return state.Set(usageStateKey, usage)
+	})
+	if err != nil {
+		utils.LogDebug("usage not recorded", "error", err)
+	}
+}
+
+// frequentModules returns launched modules, most used first and the most
+// recent first among equals. limit <= 0 returns all of them.
+func frequentModules(limit int) []string {
+	usage := loadUsage()
+
+	names := make([]string, 0, len(usage))
+	for name := range usage {
+		names = append(names, name)
+	}
+
+	sort.Slice(names, func(i, j int) bool {
+		a, b := usage[names[i]], usage[names[j]]
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		return a.LastUsed.After(b.LastUsed)
+	})
+
+	if limit > 0 && len(names) > limit {
+		names = names[:limit]
+	}
+
+	return names
+}
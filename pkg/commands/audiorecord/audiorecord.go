@@ -22,6 +22,7 @@ func init() {
 	commands.Register(commands.Command{
 		Name:        "audiorecord",
 		Description: "Record audio from microphone",
+		Config:      commands.ConfigOf(DefaultConfig),
 		Run:         Run,
 	})
 }
@@ -54,7 +55,7 @@ func Run(ctx commands.LauncherContext) commands.CommandResult {
 	// Check for direct command
 	args := ctx.Args()
 	if len(args) > 0 {
-		return executeDirectCommand(args[0], &cfg, &notifCfg)
+		return executeDirectCommand(args, &cfg, &notifCfg)
 	}
 
 	for {
@@ -64,9 +65,9 @@ func Run(ctx commands.LauncherContext) commands.CommandResult {
 			options = append(options, "← Back")
 		}
 
-		options = append(options, "Start Recording", "Stop Recording")
+		options = append(options, "Start Recording", "Record From...", "Stop Recording")
 
-		choice, err := ctx.Show(options, "Audio Record")
+		choice, err := ctx.ShowWithMessage(options, "Audio Record", recordingStatus())
 		if err != nil {
 			// ESC pressed - exit completely
 			return commands.CommandResult{Success: false}
@@ -82,15 +83,24 @@ func Run(ctx commands.LauncherContext) commands.CommandResult {
 		var actionErr error
 		switch choice {
 		case "Start Recording":
-			actionErr = startRecording(&cfg, &notifCfg)
+			actionErr = startRecording(&cfg, &notifCfg, "")
+		case "Record From...":
+			var source string
+			if source, actionErr = selectSource(ctx); actionErr == nil {
+				actionErr = startRecording(&cfg, &notifCfg, source)
+			}
 		case "Stop Recording":
-			actionErr = stopRecording(&notifCfg)
+			actionErr = stopRecording(&cfg, &notifCfg)
 		default:
 			utils.ShowErrorNotificationWithConfig(&notifCfg, "Audio Record Error", fmt.Sprintf("Unknown choice: %s", choice))
 			continue
 		}
 
 		if actionErr != nil {
+			// ESC in the source menu - exit completely
+			if actionErr.Error() == "cancelled" {
+				return commands.CommandResult{Success: false}
+			}
 			// Show error and loop back to menu
 			utils.ShowErrorNotificationWithConfig(&notifCfg, "Audio Record Error", actionErr.Error())
 			continue
@@ -101,18 +111,36 @@ func Run(ctx commands.LauncherContext) commands.CommandResult {
 	}
 }
 
-func executeDirectCommand(action string, cfg *Config, notifCfg *config.NotificationConfig) commands.CommandResult {
+func executeDirectCommand(args []string, cfg *Config, notifCfg *config.NotificationConfig) commands.CommandResult {
+	action := args[0]
+
 	var err error
 
 	switch strings.ToLower(action) {
 	case "start":
-		err = startRecording(cfg, notifCfg)
+		// 'ql audiorecord start <source>' overrides audio_source for one recording
+		var source string
+		if len(args) > 1 {
+			source = args[1]
+		}
+		err = startRecording(cfg, notifCfg, source)
 	case "stop":
-		err = stopRecording(notifCfg)
+		err = stopRecording(cfg, notifCfg)
+	case "transcribe":
+		if len(args) < 2 {
+			return commands.CommandResult{
+				Success: false,
+				Error:   fmt.Errorf("usage: ql audiorecord transcribe <file>"),
+			}
+		}
+		// Usually runs detached after 'stop', so failures are only visible as a notification
+		if err = transcribeFile(args[1], cfg, notifCfg); err != nil {
+			utils.ShowErrorNotificationWithConfig(notifCfg, "Transcription Error", err.Error())
+		}
 	default:
 		return commands.CommandResult{
 			Success: false,
-			Error:   fmt.Errorf("unknown audiorecord action: %s (use 'start' or 'stop')", action),
+			Error:   fmt.Errorf("unknown audiorecord action: %s (use 'start', 'stop' or 'transcribe')", action),
 		}
 	}
 
@@ -123,7 +151,8 @@ func executeDirectCommand(action string, cfg *Config, notifCfg *config.Notificat
 	return commands.CommandResult{Success: true}
 }
 
-func startRecording(cfg *Config, notifCfg *config.NotificationConfig) error {
+// startRecording records from source, or from audio_source when source is empty
+func startRecording(cfg *Config, notifCfg *config.NotificationConfig, source string) error {
 	if isRecording() {
 		return fmt.Errorf("recording already in progress")
 	}
@@ -132,8 +161,8 @@ func startRecording(cfg *Config, notifCfg *config.NotificationConfig) error {
 		return fmt.Errorf("ffmpeg is not installed")
 	}
 
-	saveDir := utils.ExpandHomeDir(cfg.SaveDir)
-	if err := utils.EnsureDir(saveDir); err != nil {
+	saveDir, err := utils.SaveDir(cfg.saveDir(), cfg.SaveDirLayout)
+	if err != nil {
 		return fmt.Errorf("failed to create save directory: %w", err)
 	}
 
@@ -147,9 +176,13 @@ func startRecording(cfg *Config, notifCfg *config.NotificationConfig) error {
 		outputPath = filepath.Join(saveDir, filename)
 	}
 
+	if source == "" {
+		source = cfg.audioSource()
+	}
+
 	args := []string{
 		"-f", "pulse",
-		"-i", "default",
+		"-i", source,
 		"-q:a", cfg.Quality,
 		"-y",
 		outputPath,
@@ -199,12 +232,16 @@ func startRecording(cfg *Config, notifCfg *config.NotificationConfig) error {
 		return fmt.Errorf("recording process failed to start")
 	}
 
-	utils.NotifyWithConfig(notifCfg, "Recording Started", filename)
+	message := filename
+	if source != defaultSource {
+		message = fmt.Sprintf("%s\nSource: %s", filename, source)
+	}
+	utils.NotifyWithConfig(notifCfg, "Recording Started", message)
 
 	return nil
 }
 
-func stopRecording(notifCfg *config.NotificationConfig) error {
+func stopRecording(cfg *Config, notifCfg *config.NotificationConfig) error {
 	if !isRecording() {
 		return fmt.Errorf("no recording in progress")
 	}
@@ -245,6 +282,10 @@ func stopRecording(notifCfg *config.NotificationConfig) error {
 
 	utils.NotifyWithConfig(notifCfg, "Recording Stopped", filename)
 
+	if err := startTranscription(string(outputPath), cfg); err != nil {
+		utils.ShowErrorNotificationWithConfig(notifCfg, "Transcription Error", err.Error())
+	}
+
 	return nil
 }
 
@@ -279,6 +320,26 @@ func isRecording() bool {
 	return true
 }
 
+// recordingStatus describes the running recording for the menu, e.g.
+// "● Recording 04:37 — recording_20250101_120000.wav"
+func recordingStatus() string {
+	if !isRecording() {
+		return ""
+	}
+
+	// The PID file is written when recording starts
+	info, err := os.Stat(getPIDFile())
+	if err != nil {
+		return "● Recording"
+	}
+
+	status := "● Recording " + utils.FormatElapsed(time.Since(info.ModTime()))
+	if outputPath, err := os.ReadFile(getOutputPathFile()); err == nil {
+		status += " — " + filepath.Base(strings.TrimSpace(string(outputPath)))
+	}
+	return status
+}
+
 func getPIDFile() string {
 	return "/tmp/ql_audiorecord. pid"
 }
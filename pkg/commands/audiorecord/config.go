@@ -1,21 +1,53 @@
 package audiorecord
 
+import (
+	"path/filepath"
+
+	"github.com/lvim-tech/ql/pkg/utils"
+)
+
 // Config за audio recording
 type Config struct {
-	Enabled    bool   `toml:"enabled" mapstructure:"enabled"`
-	SaveDir    string `toml:"save_dir" mapstructure:"save_dir"`
-	FilePrefix string `toml:"file_prefix" mapstructure:"file_prefix"`
-	Format     string `toml:"format" mapstructure:"format"`
-	Quality    string `toml:"quality" mapstructure:"quality"`
+	Enabled bool   `toml:"enabled" mapstructure:"enabled"`
+	SaveDir string `toml:"save_dir" mapstructure:"save_dir"`
+	// SaveDirLayout is "flat" or "date" (save_dir/YYYY/MM)
+	SaveDirLayout string `toml:"save_dir_layout" mapstructure:"save_dir_layout"`
+	FilePrefix    string `toml:"file_prefix" mapstructure:"file_prefix"`
+	Format        string `toml:"format" mapstructure:"format"`
+	Quality       string `toml:"quality" mapstructure:"quality"`
+	// AudioSource is the PulseAudio/PipeWire source to record; empty means "default"
+	AudioSource string `toml:"audio_source" mapstructure:"audio_source"`
+	// TranscribeCommand runs after a recording stops; {file} is replaced with the audio path
+	TranscribeCommand string `toml:"transcribe_command" mapstructure:"transcribe_command"`
 }
 
 // DefaultConfig връща default настройки
 func DefaultConfig() Config {
 	return Config{
-		Enabled:    true,
-		SaveDir:    "~/Music/Recordings",
-		FilePrefix: "audio",
-		Format:     "mp3",
-		Quality:    "2",
+		Enabled:           true,
+		SaveDir:           "",
+		SaveDirLayout:     "flat",
+		FilePrefix:        "audio",
+		Format:            "mp3",
+		Quality:           "2",
+		AudioSource:       "",
+		TranscribeCommand: "",
+	}
+}
+
+// audioSource returns audio_source, or the server's default input when unset
+func (c *Config) audioSource() string {
+	if c.AudioSource != "" {
+		return c.AudioSource
+	}
+	return defaultSource
+}
+
+// saveDir returns save_dir, or Recordings under the XDG music directory
+// (~/Music/Recordings without user-dirs.dirs) when it is unset
+func (c *Config) saveDir() string {
+	if c.SaveDir != "" {
+		return c.SaveDir
 	}
+	return filepath.Join(utils.GetUserDir("MUSIC", "~/Music"), "Recordings")
 }
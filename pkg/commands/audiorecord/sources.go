@@ -0,0 +1,162 @@
+package audiorecord
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lvim-tech/ql/pkg/commands"
+	"github.com/lvim-tech/ql/pkg/utils"
+)
+
+// defaultSource is the PulseAudio name of the server's default input
+const defaultSource = "default"
+
+// sourceListTimeout bounds pactl/pw-cli while building the source menu
+const sourceListTimeout = 5 * time.Second
+
+// Source is an input ffmpeg can record from with '-f pulse -i <Name>'
+type Source struct {
+	Name        string
+	Description string
+}
+
+// Label is the source's line in the selection menu
+func (s Source) Label() string {
+	description := s.Description
+	if description == "" && strings.HasSuffix(s.Name, ".monitor") {
+		description = "system audio"
+	}
+	if description == "" {
+		return s.Name
+	}
+	return fmt.Sprintf("%s (%s)", s.Name, description)
+}
+
+// listSources lists the recordable inputs, including the monitors of output
+// devices, from pactl or, on PipeWire without pipewire-pulse tools, pw-cli
+func listSources() ([]Source, error) {
+	if utils.CommandExists("pactl") {
+		output, err := utils.RunCommandTimeout(sourceListTimeout, "pactl", "list", "short", "sources")
+		if err == nil {
+			return parsePactlSources(output), nil
+		}
+		utils.LogDebug("pactl source listing failed", "error", err)
+	}
+
+	if utils.CommandExists("pw-cli") {
+		output, err := utils.RunCommandTimeout(sourceListTimeout, "pw-cli", "ls", "Node")
+		if err != nil {
+			return nil, fmt.Errorf("failed to list audio sources: %w", err)
+		}
+		return parsePwCliNodes(output), nil
+	}
+
+	return nil, fmt.Errorf("cannot list audio sources (install pactl or pw-cli)")
+}
+
+// parsePactlSources parses 'pactl list short sources', tab-separated as
+//
+//	56	alsa_input.pci-0000_00_1f.3.analog-stereo	PipeWire	s32le 2ch 48000Hz	SUSPENDED
+func parsePactlSources(output string) []Source {
+	var sources []Source
+
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Split(line, "\t")
+		if len(fields) < 2 || fields[1] == "" {
+			continue
+		}
+		sources = append(sources, Source{Name: fields[1]})
+	}
+
+	return sources
+}
+
+// parsePwCliNodes parses 'pw-cli ls Node'. Audio/Source nodes are inputs;
+// Audio/Sink nodes are recorded through their "<name>.monitor" source.
+//
+//	id 56, type PipeWire:Interface:Node/3
+//		node.description = "Built-in Audio Analog Stereo"
+//		node.name = "alsa_input.pci-0000_00_1f.3.analog-stereo"
+//		media.class = "Audio/Source"
+func parsePwCliNodes(output string) []Source {
+	var sources []Source
+	var name, description, class string
+
+	flush := func() {
+		switch class {
+		case "Audio/Source":
+			sources = append(sources, Source{Name: name, Description: description})
+		case "Audio/Sink":
+			monitor := Source{Name: name + ".monitor"}
+			if description != "" {
+				monitor.Description = "monitor of " + description
+			}
+			sources = append(sources, monitor)
+		}
+		name, description, class = "", "", ""
+	}
+
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "id ") {
+			flush()
+			continue
+		}
+
+		key, value, found := strings.Cut(line, " = ")
+		if !found {
+			continue
+		}
+		value = strings.Trim(value, `"`)
+
+		switch key {
+		case "node.name":
+			name = value
+		case "node.description":
+			description = value
+		case "media.class":
+			class = value
+		}
+	}
+	flush()
+
+	// A node without a name cannot be opened
+	valid := sources[:0]
+	for _, s := range sources {
+		if s.Name != "" && s.Name != ".monitor" {
+			valid = append(valid, s)
+		}
+	}
+
+	return valid
+}
+
+// selectSource lets the user pick an input. When the sources cannot be
+// listed only the default input is offered.
+func selectSource(ctx commands.LauncherContext) (string, error) {
+	sources, err := listSources()
+	if err != nil {
+		utils.LogDebug("audio source listing failed, offering default only", "error", err)
+	}
+
+	options := []string{defaultSource}
+	names := map[string]string{defaultSource: defaultSource}
+	for _, s := range sources {
+		label := s.Label()
+		options = append(options, label)
+		names[label] = s.Name
+	}
+
+	choice, err := ctx.Show(options, "Audio Source")
+	if err != nil {
+		return "", fmt.Errorf("cancelled")
+	}
+
+	name, ok := names[choice]
+	if !ok {
+		return "", fmt.Errorf("unknown audio source: %s", choice)
+	}
+
+	return name, nil
+}
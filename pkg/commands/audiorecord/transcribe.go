@@ -0,0 +1,60 @@
+package audiorecord
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/lvim-tech/ql/pkg/config"
+	"github.com/lvim-tech/ql/pkg/utils"
+)
+
+// startTranscription runs 'ql audiorecord transcribe <file>' detached, so the
+// stop action returns immediately while the transcript is produced in the background
+func startTranscription(audioPath string, cfg *Config) error {
+	if cfg.TranscribeCommand == "" {
+		return nil
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to locate ql executable: %w", err)
+	}
+
+	return utils.StartDetachedProcess(exe, "audiorecord", "transcribe", audioPath)
+}
+
+// transcribeFile runs transcribe_command on audioPath and writes its output
+// to a .txt file next to the audio
+func transcribeFile(audioPath string, cfg *Config, notifCfg *config.NotificationConfig) error {
+	if cfg.TranscribeCommand == "" {
+		return fmt.Errorf("transcribe_command is not configured")
+	}
+
+	if !utils.FileExists(audioPath) {
+		return fmt.Errorf("audio file not found: %s", audioPath)
+	}
+
+	shellCmd := strings.ReplaceAll(cfg.TranscribeCommand, "{file}", shellQuote(audioPath))
+
+	output, err := exec.Command("sh", "-c", shellCmd).Output()
+	if err != nil {
+		return fmt.Errorf("transcription failed: %w", err)
+	}
+
+	txtPath := strings.TrimSuffix(audioPath, filepath.Ext(audioPath)) + ".txt"
+	if err := os.WriteFile(txtPath, output, 0644); err != nil {
+		return fmt.Errorf("failed to write transcript: %w", err)
+	}
+
+	utils.NotifyWithConfig(notifCfg, "Transcript Ready", filepath.Base(txtPath))
+
+	return nil
+}
+
+// shellQuote wraps s in single quotes for safe use in a sh -c command line
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
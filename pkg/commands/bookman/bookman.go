@@ -5,14 +5,21 @@ import (
 	"bufio"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/lvim-tech/ql/pkg/commands"
+	"github.com/lvim-tech/ql/pkg/config"
 	"github.com/lvim-tech/ql/pkg/utils"
 	_ "github.com/mattn/go-sqlite3"
 	"github.com/mitchellh/mapstructure"
+	"io"
+	"net/url"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"slices"
 	"strings"
+	"time"
 )
 
 // Entry represents a menu entry/bookmark.
@@ -24,11 +31,25 @@ type Entry struct {
 
 const sepString = "********************"
 
+// statsItem opens the per-source statistics view from the bookmark menu
+const statsItem = "Source Stats / Refresh"
+
+// multiItem opens a multi-select menu to open several bookmarks at once
+const multiItem = "Open Several..."
+
+// multiURLBrowsers accept several URLs in one invocation and open each in a
+// new tab; other browsers are started once per URL
+var multiURLBrowsers = []string{
+	"qutebrowser", "firefox", "firefox-esr", "librewolf", "chromium",
+	"google-chrome", "google-chrome-stable", "brave", "brave-browser", "vivaldi",
+}
+
 // Register the bookman command at initialization
 func init() {
 	commands.Register(commands.Command{
 		Name:        "bookman",
 		Description: "Browser bookmarks & quickmarks manager",
+		Config:      commands.ConfigOf(DefaultConfig),
 		Run:         Run,
 	})
 }
@@ -61,91 +82,219 @@ func Run(ctx commands.LauncherContext) commands.CommandResult {
 
 	notifCfg := ctx.Config().GetNotificationConfig()
 
-	var allEntries []Entry
-	for _, src := range cfg.Sources {
-		entries, err := parseSource(src)
-		if err != nil {
-			// Show a notification for a failed source, but continue with remaining sources.
-			utils.ShowErrorNotificationWithConfig(&notifCfg, "Bookman", fmt.Sprintf("Failed: %s (%s)", src.Name, err))
-			continue
-		}
-		if len(entries) > 0 {
-			for _, e := range entries {
-				allEntries = append(allEntries, Entry{
-					Source:  src.Name,
-					Display: e.Display,
-					URL:     e.URL,
-				})
+	args := ctx.Args()
+	if len(args) > 0 {
+		if strings.ToLower(args[0]) != "stats" {
+			return commands.CommandResult{
+				Success: false,
+				Error:   fmt.Errorf("unknown bookman action: %s (use: stats)", args[0]),
 			}
-			allEntries = append(allEntries, Entry{Display: sepString})
 		}
+		_, stats := loadSources(&cfg)
+		if ctx.IsJSONOutput() {
+			return commands.CommandResult{Success: true, Message: formatStats(stats), Data: stats}
+		}
+		if utils.IsTerminal() {
+			fmt.Print(formatStats(stats))
+			return commands.CommandResult{Success: true}
+		}
+		return showStats(ctx, stats)
 	}
 
-	// Remove trailing separator(s)
-	for len(allEntries) > 0 && allEntries[len(allEntries)-1].Display == sepString {
-		allEntries = allEntries[:len(allEntries)-1]
-	}
-	if len(allEntries) == 0 {
-		utils.ShowErrorNotificationWithConfig(&notifCfg, "Bookman", "No bookmarks or quickmarks found!")
-		return commands.CommandResult{Success: false}
-	}
+	var choice string
+	for {
+		allEntries, stats := loadSources(&cfg)
 
-	// Build menu items for selection (adding group separators, source info, Back if not direct launch)
-	var items []string
-	if !ctx.IsDirectLaunch() {
-		items = append(items, "← Back")
-	}
-	for _, e := range allEntries {
-		if e.Display == sepString {
-			items = append(items, sepString)
-			continue
+		for _, st := range stats {
+			if st.Error != "" {
+				// Show a notification for a failed source, but continue with remaining sources.
+				utils.ShowErrorNotificationWithConfig(&notifCfg, "Bookman", fmt.Sprintf("Failed: %s (%s)", st.Name, st.Error))
+			}
 		}
-		items = append(items, fmt.Sprintf("[%s] %s", e.Source, e.Display))
-	}
 
-	// Let the user select an item
-	choice, err := ctx.Show(items, "Bookman")
-	if err != nil || choice == "" {
-		return commands.CommandResult{Success: false}
-	}
-	if choice == "← Back" {
-		return commands.CommandResult{
-			Success: false,
-			Error:   commands.ErrBack,
+		if len(allEntries) == 0 {
+			utils.ShowErrorNotificationWithConfig(&notifCfg, "Bookman", "No bookmarks or quickmarks found!")
+			return commands.CommandResult{Success: false}
 		}
-	}
-	if choice == sepString {
-		return commands.CommandResult{Success: true}
-	}
 
-	// Extract the URL (always the last http(s) word)
-	url := ""
-	fields := strings.Fields(choice)
-	for i := len(fields) - 1; i >= 0; i-- {
-		f := fields[i]
-		if strings.HasPrefix(f, "http://") || strings.HasPrefix(f, "https://") {
-			url = f
+		// Build menu items for selection (adding group separators, source info, Back if not direct launch)
+		var entryItems []string
+		for _, e := range allEntries {
+			if e.Display == sepString {
+				entryItems = append(entryItems, sepString)
+				continue
+			}
+			entryItems = append(entryItems, fmt.Sprintf("[%s] %s", e.Source, e.Display))
+		}
+
+		var items []string
+		if !ctx.IsDirectLaunch() {
+			items = append(items, "← Back")
+		}
+		items = append(items, statsItem, multiItem)
+		items = append(items, entryItems...)
+
+		// Let the user select an item
+		var err error
+		choice, err = ctx.Show(items, "Bookman")
+		if err != nil || choice == "" {
+			return commands.CommandResult{Success: false}
+		}
+		if choice == "← Back" {
+			return commands.CommandResult{
+				Success: false,
+				Error:   commands.ErrBack,
+			}
+		}
+		if choice == sepString {
+			return commands.CommandResult{Success: true}
+		}
+		if choice == multiItem {
+			return openSeveral(ctx, entryItems, &cfg, &notifCfg)
+		}
+		if choice != statsItem {
 			break
 		}
+
+		// Stats view returns here and sources are reloaded (refresh)
+		if result := showStats(ctx, stats); !errors.Is(result.Error, commands.ErrBack) {
+			return result
+		}
 	}
+
+	url := extractURL(choice)
 	if url == "" {
 		utils.ShowErrorNotificationWithConfig(&notifCfg, "Bookman", "Invalid URL entry!")
 		return commands.CommandResult{Success: false}
 	}
 
-	// Use the globally configured browser
+	exec.Command(browserCommand(ctx), url).Start()
+
+	return commands.CommandResult{Success: true}
+}
+
+// extractURL returns the URL of a menu entry (always the last http(s) word)
+func extractURL(entry string) string {
+	fields := strings.Fields(entry)
+	for i := len(fields) - 1; i >= 0; i-- {
+		f := fields[i]
+		if strings.HasPrefix(f, "http://") || strings.HasPrefix(f, "https://") {
+			return f
+		}
+	}
+	return ""
+}
+
+// browserCommand returns the globally configured browser
+func browserCommand(ctx commands.LauncherContext) string {
 	browser := ctx.Config().GetBrowser()
 	if browser == "" {
 		browser = "qutebrowser"
 	}
-	exec.Command(browser, url).Start()
+	return browser
+}
+
+// openSeveral lets the user mark several bookmarks and opens them all
+func openSeveral(ctx commands.LauncherContext, entryItems []string, cfg *Config, notifCfg *config.NotificationConfig) commands.CommandResult {
+	selected, err := ctx.ShowMulti(entryItems, "Open Bookmarks")
+	if err != nil {
+		return commands.CommandResult{Success: false}
+	}
+
+	var urls []string
+	for _, entry := range selected {
+		if url := extractURL(entry); url != "" {
+			urls = append(urls, url)
+		}
+	}
+
+	if len(urls) == 0 {
+		utils.ShowErrorNotificationWithConfig(notifCfg, "Bookman", "No bookmarks selected")
+		return commands.CommandResult{Success: false}
+	}
+
+	if err := openURLs(browserCommand(ctx), urls, time.Duration(cfg.OpenDelay)*time.Millisecond); err != nil {
+		utils.ShowErrorNotificationWithConfig(notifCfg, "Bookman", err.Error())
+		return commands.CommandResult{Success: false}
+	}
 
 	return commands.CommandResult{Success: true}
 }
 
+// openURLs opens urls in one browser invocation when the browser takes
+// several URLs, otherwise one invocation per URL. The first launch gets
+// delay to start up, so later ones join its profile instead of racing it.
+func openURLs(browser string, urls []string, delay time.Duration) error {
+	if slices.Contains(multiURLBrowsers, filepath.Base(browser)) {
+		if err := exec.Command(browser, urls...).Start(); err != nil {
+			return fmt.Errorf("failed to start %s: %w", browser, err)
+		}
+		return nil
+	}
+
+	for i, url := range urls {
+		if i > 0 && delay > 0 {
+			time.Sleep(delay)
+		}
+		if err := exec.Command(browser, url).Start(); err != nil {
+			return fmt.Errorf("failed to start %s: %w", browser, err)
+		}
+	}
+
+	return nil
+}
+
+// loadSources parses all configured sources into menu entries separated per
+// source, together with per-source load statistics.
+func loadSources(cfg *Config) ([]Entry, []SourceStats) {
+	var allEntries []Entry
+	var stats []SourceStats
+
+	for _, src := range cfg.Sources {
+		st := SourceStats{
+			Name:   src.Name,
+			Format: src.Format,
+			Path:   utils.ExpandPath(src.Path),
+		}
+		st.Found = utils.FileExists(st.Path)
+
+		entries, err := parseSource(src)
+		if err != nil {
+			st.Error = err.Error()
+			stats = append(stats, st)
+			continue
+		}
+
+		st.Entries = len(entries)
+		stats = append(stats, st)
+
+		if len(entries) > 0 {
+			for _, e := range entries {
+				allEntries = append(allEntries, Entry{
+					Source:  src.Name,
+					Display: e.Display,
+					URL:     e.URL,
+				})
+			}
+			allEntries = append(allEntries, Entry{Display: sepString})
+		}
+	}
+
+	// Remove trailing separator(s)
+	for len(allEntries) > 0 && allEntries[len(allEntries)-1].Display == sepString {
+		allEntries = allEntries[:len(allEntries)-1]
+	}
+
+	return allEntries, stats
+}
+
 // parseSource determines which format parser to call based on source.Format.
 func parseSource(src Source) ([]Entry, error) {
-	path := utils.ExpandHomeDir(src.Path)
+	path := utils.ExpandPath(src.Path)
+	// sql.Open would silently create a missing sqlite file, so check up front
+	if !utils.FileExists(path) {
+		return nil, fmt.Errorf("file not found: %s", path)
+	}
 	switch src.Format {
 	case "qutebrowser_quickmarks":
 		return parseQuteQuickmarks(src.Name, path)
@@ -251,10 +400,20 @@ func parseChromeBookmarksJSON(srcName, path string) ([]Entry, error) {
 
 // parseFirefoxBookmarks parses bookmarks from a Firefox places.sqlite file using go-sqlite3.
 // Returns the newest 200 bookmarks with titles.
+//
+// A running Firefox keeps places.sqlite locked, so the database is queried from
+// a temporary copy (including the -wal/-shm sidecars holding recent changes).
+// If copying fails, the original is opened read-only in immutable mode.
 func parseFirefoxBookmarks(srcName, path string) ([]Entry, error) {
-	db, err := sql.Open("sqlite3", path)
+	dsn := sqliteImmutableDSN(path)
+
+	if tmpDir, err := copySQLiteDB(path); err == nil {
+		defer os.RemoveAll(tmpDir)
+		dsn = sqliteDSN(filepath.Join(tmpDir, filepath.Base(path)), "mode=ro")
+	}
+
+	db, err := sql.Open("sqlite3", dsn)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "BOOKMAN DEBUG: sqlite open error for %q: %v\n", path, err)
 		return nil, fmt.Errorf("open sqlite: %w", err)
 	}
 	defer db.Close()
@@ -269,13 +428,11 @@ func parseFirefoxBookmarks(srcName, path string) ([]Entry, error) {
 	`
 	rows, err := db.Query(q)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "BOOKMAN DEBUG: sqlite query error: %v\n", err)
 		return nil, fmt.Errorf("sqlite query: %w", err)
 	}
 	defer rows.Close()
 
 	var result []Entry
-	count := 0
 	for rows.Next() {
 		var title, url string
 		if err := rows.Scan(&title, &url); err != nil {
@@ -284,21 +441,73 @@ func parseFirefoxBookmarks(srcName, path string) ([]Entry, error) {
 		if title == "" {
 			title = "[untitled]"
 		}
-		count++
 		result = append(result, Entry{
 			Source:  srcName,
 			Display: fmt.Sprintf("[F] %s - %s", title, url),
 			URL:     url,
 		})
 	}
-	fmt.Fprintf(os.Stderr, "BOOKMAN DEBUG: Firefox loaded %d entries\n", count)
 	if err := rows.Err(); err != nil {
-		fmt.Fprintf(os.Stderr, "BOOKMAN DEBUG: rows error: %v\n", err)
-		return result, err
+		return result, fmt.Errorf("sqlite rows: %w", err)
 	}
 	return result, nil
 }
 
+// copySQLiteDB copies a SQLite database and its -wal/-shm sidecar files into a
+// new temporary directory, which the caller must remove
+func copySQLiteDB(path string) (string, error) {
+	tmpDir, err := os.MkdirTemp("", "ql_bookman_")
+	if err != nil {
+		return "", err
+	}
+
+	for _, suffix := range []string{"", "-wal", "-shm"} {
+		src := path + suffix
+		if suffix != "" && !utils.FileExists(src) {
+			continue
+		}
+
+		if err := copyFile(src, filepath.Join(tmpDir, filepath.Base(src))); err != nil {
+			os.RemoveAll(tmpDir)
+			return "", fmt.Errorf("copy %s: %w", filepath.Base(src), err)
+		}
+	}
+
+	return tmpDir, nil
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+
+	return out.Close()
+}
+
+// sqliteImmutableDSN opens a database read-only without taking any locks.
+// Changes still in the -wal file are not visible in this mode.
+func sqliteImmutableDSN(path string) string {
+	return sqliteDSN(path, "mode=ro&immutable=1")
+}
+
+// sqliteDSN builds a file: URI with the path escaped for use in a query string
+func sqliteDSN(path, query string) string {
+	u := url.URL{Scheme: "file", Path: path, RawQuery: query}
+	return u.String()
+}
+
 // readLines reads a text file into a slice of strings (one per line).
 func readLines(filename string) ([]string, error) {
 	f, err := os.Open(filename)
@@ -0,0 +1,104 @@
+package bookman
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// lockedPlaces creates a Firefox-like places.sqlite and holds it the way a
+// running Firefox does: WAL journal, exclusive locking mode and an open write
+// transaction. The first bookmark is checkpointed into the database file,
+// the second exists only in the -wal file. The lock is released at cleanup.
+func lockedPlaces(t *testing.T) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "places.sqlite")
+	db, err := sql.Open("sqlite3", sqliteDSN(path, "_journal_mode=WAL"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	ctx := context.Background()
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	exec := func(query string) {
+		t.Helper()
+		if _, err := conn.ExecContext(ctx, query); err != nil {
+			t.Fatalf("%s: %v", query, err)
+		}
+	}
+
+	exec(`PRAGMA wal_autocheckpoint = 0`)
+	exec(`CREATE TABLE moz_places (id INTEGER PRIMARY KEY, url TEXT)`)
+	exec(`CREATE TABLE moz_bookmarks (id INTEGER PRIMARY KEY, type INTEGER, fk INTEGER, title TEXT, dateAdded INTEGER)`)
+	exec(`INSERT INTO moz_places VALUES (1, 'https://checkpointed.example')`)
+	exec(`INSERT INTO moz_bookmarks VALUES (1, 1, 1, 'Checkpointed', 1)`)
+	exec(`PRAGMA wal_checkpoint(TRUNCATE)`)
+	exec(`INSERT INTO moz_places VALUES (2, 'https://wal-only.example')`)
+	exec(`INSERT INTO moz_bookmarks VALUES (2, 1, 2, 'WAL only', 2)`)
+
+	exec(`PRAGMA locking_mode = EXCLUSIVE`)
+	exec(`BEGIN EXCLUSIVE`)
+	exec(`INSERT INTO moz_places VALUES (3, 'https://uncommitted.example')`)
+	t.Cleanup(func() { conn.ExecContext(ctx, `ROLLBACK`) })
+
+	return path
+}
+
+func TestParseFirefoxBookmarksLocked(t *testing.T) {
+	path := lockedPlaces(t)
+
+	// The scenario only means something if the database cannot be read in place
+	direct, err := sql.Open("sqlite3", sqliteDSN(path, "mode=ro&_busy_timeout=0"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer direct.Close()
+	var count int
+	if err := direct.QueryRow(`SELECT count(*) FROM moz_bookmarks`).Scan(&count); err == nil {
+		t.Fatalf("database was readable in place (%d bookmarks), the lock is not held", count)
+	}
+
+	entries, err := parseFirefoxBookmarks("firefox", path)
+	if err != nil {
+		t.Fatalf("parseFirefoxBookmarks: %v", err)
+	}
+
+	var urls []string
+	for _, e := range entries {
+		urls = append(urls, e.URL)
+	}
+
+	for _, want := range []string{"https://checkpointed.example", "https://wal-only.example"} {
+		if !slices.Contains(urls, want) {
+			t.Errorf("bookmark %s missing, got %v", want, urls)
+		}
+	}
+	if len(urls) != 2 {
+		t.Errorf("got %d bookmarks, want 2: %v", len(urls), urls)
+	}
+}
+
+func TestSQLiteDSNEscapesPath(t *testing.T) {
+	tests := []struct {
+		path, query, want string
+	}{
+		{"/home/u/places.sqlite", "mode=ro", "file:///home/u/places.sqlite?mode=ro"},
+		{"/home/u/a b/places.sqlite", "mode=ro", "file:///home/u/a%20b/places.sqlite?mode=ro"},
+		{"/home/u/a?b#c/places.sqlite", "mode=ro", "file:///home/u/a%3Fb%23c/places.sqlite?mode=ro"},
+	}
+
+	for _, tt := range tests {
+		if got := sqliteDSN(tt.path, tt.query); got != tt.want {
+			t.Errorf("sqliteDSN(%q, %q) = %q, want %q", tt.path, tt.query, got, tt.want)
+		}
+	}
+}
@@ -11,12 +11,16 @@ type Source struct {
 type Config struct {
 	Enabled bool     `toml:"enabled" mapstructure:"enabled"`
 	Sources []Source `toml:"sources" mapstructure:"sources"`
+	// OpenDelay is the pause in milliseconds between browser launches when
+	// opening several bookmarks with a browser that takes one URL at a time
+	OpenDelay int `toml:"open_delay" mapstructure:"open_delay"`
 }
 
 // DefaultConfig returns default bookman configuration
 func DefaultConfig() Config {
 	return Config{
-		Enabled: true,
+		Enabled:   true,
+		OpenDelay: 500,
 		Sources: []Source{
 			{
 				Name:   "Qutebrowser Quickmarks",
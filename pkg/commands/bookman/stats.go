@@ -0,0 +1,61 @@
+package bookman
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/lvim-tech/ql/pkg/commands"
+)
+
+// SourceStats reports how a single configured source loaded
+type SourceStats struct {
+	Name    string `json:"name"`
+	Format  string `json:"format"`
+	Path    string `json:"path"`
+	Found   bool   `json:"found"`
+	Entries int    `json:"entries"`
+	Error   string `json:"error,omitempty"`
+}
+
+// statusLine summarizes a source in one line
+func (s SourceStats) statusLine() string {
+	switch {
+	case !s.Found:
+		return fmt.Sprintf("%s: file not found (%s)", s.Name, s.Path)
+	case s.Error != "":
+		return fmt.Sprintf("%s: parse error (%s)", s.Name, s.Error)
+	default:
+		return fmt.Sprintf("%s: %d entries", s.Name, s.Entries)
+	}
+}
+
+func formatStats(stats []SourceStats) string {
+	var output strings.Builder
+
+	total := 0
+	for _, st := range stats {
+		total += st.Entries
+		output.WriteString(st.statusLine() + "\n")
+	}
+	fmt.Fprintf(&output, "Total: %d entries from %d sources\n", total, len(stats))
+
+	return output.String()
+}
+
+// showStats lists per-source statistics in the launcher. "← Back" returns
+// commands.ErrBack so the caller can reload the sources.
+func showStats(ctx commands.LauncherContext, stats []SourceStats) commands.CommandResult {
+	items := []string{"← Back"}
+	items = append(items, strings.Split(strings.TrimSpace(formatStats(stats)), "\n")...)
+
+	choice, err := ctx.Show(items, "Bookman Sources")
+	if err != nil {
+		return commands.CommandResult{Success: false}
+	}
+
+	if choice == "← Back" {
+		return commands.CommandResult{Success: false, Error: commands.ErrBack}
+	}
+
+	return commands.CommandResult{Success: true}
+}
@@ -5,6 +5,7 @@ package clipboard
 import (
 	"fmt"
 	"os/exec"
+	"slices"
 	"strings"
 
 	"github.com/lvim-tech/ql/pkg/commands"
@@ -17,10 +18,23 @@ func init() {
 	commands.Register(commands.Command{
 		Name:        "clipboard",
 		Description: "Clipboard manager",
+		Check:       check,
+		Config:      commands.ConfigOf(DefaultConfig),
 		Run:         Run,
 	})
 }
 
+// check verifies that a clipboard history backend is available
+func check(qlCfg *config.Config) error {
+	cfg := DefaultConfig()
+	if err := commands.DecodeConfig(qlCfg.GetClipboardConfig(), &cfg); err != nil {
+		return err
+	}
+
+	_, err := detectBackend(cfg.Backend)
+	return err
+}
+
 func Run(ctx commands.LauncherContext) commands.CommandResult {
 	cfgInterface := ctx.Config().GetClipboardConfig()
 
@@ -44,12 +58,11 @@ func Run(ctx commands.LauncherContext) commands.CommandResult {
 		}
 	}
 
-	backend := detectBackend()
-	if backend == "" {
+	backend, err := detectBackend(cfg.Backend)
+	if err != nil {
 		notifCfg := ctx.Config().GetNotificationConfig()
-		utils.ShowErrorNotificationWithConfig(&notifCfg, "Clipboard Error",
-			"No clipboard backend found. Install cliphist, clipman, or clipmenu")
-		return commands.CommandResult{Success: false}
+		utils.ShowErrorNotificationWithConfig(&notifCfg, "Clipboard Error", err.Error())
+		return commands.CommandResult{Success: false, Error: err}
 	}
 
 	notifCfg := ctx.Config().GetNotificationConfig()
@@ -57,7 +70,7 @@ func Run(ctx commands.LauncherContext) commands.CommandResult {
 	// Check for direct command
 	args := ctx.Args()
 	if len(args) > 0 {
-		return executeDirectCommand(ctx, args[0], backend, &cfg, &notifCfg)
+		return executeDirectCommand(ctx, args, backend, &cfg, &notifCfg)
 	}
 
 	for {
@@ -69,6 +82,7 @@ func Run(ctx commands.LauncherContext) commands.CommandResult {
 
 		options = append(options,
 			"Show History",
+			"Type Entry",
 			"Clear History",
 		)
 
@@ -86,8 +100,12 @@ func Run(ctx commands.LauncherContext) commands.CommandResult {
 		}
 
 		switch choice {
-		case "Show History":
-			result := showHistory(ctx, backend, &cfg)
+		case "Show History", "Type Entry":
+			action := cfg.DefaultAction
+			if choice == "Type Entry" {
+				action = "type"
+			}
+			result := showHistory(ctx, backend, &cfg, action)
 			if result.Success {
 				return result
 			}
@@ -104,6 +122,9 @@ func Run(ctx commands.LauncherContext) commands.CommandResult {
 
 		case "Clear History":
 			result := clearHistory(ctx, backend, &notifCfg)
+			if result.Success {
+				return result
+			}
 			// If error is NOT ErrBack - it's ESC, exit completely
 			if result.Error != nil && result.Error != commands.ErrBack {
 				return commands.CommandResult{Success: false}
@@ -118,16 +139,31 @@ func Run(ctx commands.LauncherContext) commands.CommandResult {
 	}
 }
 
-func executeDirectCommand(ctx commands.LauncherContext, action string, backend string, cfg *Config, notifCfg *config.NotificationConfig) commands.CommandResult {
+func executeDirectCommand(ctx commands.LauncherContext, args []string, backend string, cfg *Config, notifCfg *config.NotificationConfig) commands.CommandResult {
+	action := args[0]
+
 	switch strings.ToLower(action) {
 	case "show", "history":
-		return showHistory(ctx, backend, cfg)
+		return showHistory(ctx, backend, cfg, cfg.DefaultAction)
+	case "copy", "type":
+		return showHistory(ctx, backend, cfg, strings.ToLower(action))
 	case "clear":
 		return clearHistoryDirect(backend, notifCfg)
+	case "export", "import":
+		if len(args) < 2 {
+			return commands.CommandResult{
+				Success: false,
+				Error:   fmt.Errorf("usage: ql clipboard %s <file>", strings.ToLower(action)),
+			}
+		}
+		if strings.ToLower(action) == "export" {
+			return exportHistory(backend, args[1], notifCfg)
+		}
+		return importHistory(backend, args[1], notifCfg)
 	default:
 		return commands.CommandResult{
 			Success: false,
-			Error:   fmt.Errorf("unknown clipboard action: %s (use 'show' or 'clear')", action),
+			Error:   fmt.Errorf("unknown clipboard action: %s (use 'show', 'copy', 'type', 'clear', 'export' or 'import')", action),
 		}
 	}
 }
@@ -162,21 +198,54 @@ func clearHistoryDirect(backend string, notifCfg *config.NotificationConfig) com
 	return commands.CommandResult{Success: true}
 }
 
-func detectBackend() string {
-	if utils.CommandExists("cliphist") {
-		return "cliphist"
+// supportedBackends lists clipboard managers in auto-detection priority
+var supportedBackends = []string{"cliphist", "clipman", "clipmenu"}
+
+// detectBackend returns the configured backend, or the first installed one for "auto"
+func detectBackend(preference string) (string, error) {
+	preference = strings.ToLower(strings.TrimSpace(preference))
+
+	if preference == "" || preference == "auto" {
+		for _, backend := range supportedBackends {
+			if utils.CommandExists(backend) {
+				return backend, nil
+			}
+		}
+		return "", fmt.Errorf("no clipboard backend found. Install cliphist, clipman, or clipmenu")
 	}
-	if utils.CommandExists("clipman") {
-		return "clipman"
+
+	if !slices.Contains(supportedBackends, preference) {
+		return "", fmt.Errorf("unknown clipboard backend: %s (use: auto, %s)", preference, strings.Join(supportedBackends, ", "))
 	}
-	if utils.CommandExists("clipmenu") {
-		return "clipmenu"
+
+	if !utils.CommandExists(preference) {
+		return "", fmt.Errorf("clipboard backend %s is configured but not installed", preference)
 	}
-	return ""
+
+	return preference, nil
 }
 
-func showHistory(ctx commands.LauncherContext, backend string, cfg *Config) commands.CommandResult {
-	historyLines, err := getHistory(backend, cfg.MaxItems)
+// historyEntry separates what the history menu shows from what gets copied
+type historyEntry struct {
+	Label string
+	Value string
+	// raw is the backend's own line (cliphist "<id>\t<preview>"), used to
+	// decode the full content on selection
+	raw string
+}
+
+// showHistory lets the user pick a history entry and then copies or types it
+func showHistory(ctx commands.LauncherContext, backend string, cfg *Config, action string) commands.CommandResult {
+	var mask *maskMatcher
+	if cfg.MaskSensitive {
+		var err error
+		mask, err = newMaskMatcher(cfg.MaskPatterns, true)
+		if err != nil {
+			return commands.CommandResult{Success: false, Error: err}
+		}
+	}
+
+	entries, err := getHistory(backend, cfg.MaxItems, mask)
 	if err != nil {
 		return commands.CommandResult{Success: false, Error: err}
 	}
@@ -187,13 +256,15 @@ func showHistory(ctx commands.LauncherContext, backend string, cfg *Config) comm
 		options = append(options, "← Back")
 	}
 
-	if len(historyLines) == 0 {
+	if len(entries) == 0 {
 		options = append(options, "Clipboard history is empty")
 	} else {
-		options = append(options, historyLines...)
+		for _, entry := range entries {
+			options = append(options, entry.Label)
+		}
 	}
 
-	selected, err := ctx.Show(options, "Clipboard History")
+	selected, err := ctx.ShowOrdered(options, "Clipboard History")
 	if err != nil {
 		// ESC pressed - return error that's NOT ErrBack
 		return commands.CommandResult{Success: false, Error: fmt.Errorf("ESC")}
@@ -210,17 +281,43 @@ func showHistory(ctx commands.LauncherContext, backend string, cfg *Config) comm
 		return commands.CommandResult{Success: false, Error: commands.ErrBack}
 	}
 
-	if err := copyToClipboard(selected); err != nil {
+	index := slices.IndexFunc(entries, func(entry historyEntry) bool {
+		return entry.Label == selected
+	})
+	if index == -1 {
+		return commands.CommandResult{Success: false, Error: commands.ErrBack}
+	}
+	content := entryContent(backend, entries[index])
+
+	if action == "type" {
+		if err := typeText(content); err != nil {
+			return commands.CommandResult{Success: false, Error: err}
+		}
+		return commands.CommandResult{Success: true}
+	}
+
+	if err := utils.CopyToClipboard(content); err != nil {
 		return commands.CommandResult{Success: false, Error: err}
 	}
 
 	notifCfg := ctx.Config().GetNotificationConfig()
+
+	if cfg.SyncPrimary {
+		// The clipboard copy worked, so a missing primary selection is only a warning
+		if err := utils.CopyToPrimary(content); err != nil {
+			utils.NotifyWithConfig(&notifCfg, "Clipboard", "Copied to clipboard only: "+err.Error())
+			return commands.CommandResult{Success: true}
+		}
+	}
+
 	utils.NotifyWithConfig(&notifCfg, "Clipboard", "Copied to clipboard")
 
 	return commands.CommandResult{Success: true}
 }
 
-func getHistory(backend string, maxItems int) ([]string, error) {
+// getHistory lists history entries. Labels are truncated, masked when mask
+// matches, and made unique so a selected label maps back to one entry.
+func getHistory(backend string, maxItems int, mask *maskMatcher) ([]historyEntry, error) {
 	var cmd *exec.Cmd
 
 	switch backend {
@@ -241,39 +338,66 @@ func getHistory(backend string, maxItems int) ([]string, error) {
 
 	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
 
-	var filtered []string
+	var entries []historyEntry
+	seen := make(map[string]int)
 	for _, line := range lines {
 		if line == "" {
 			continue
 		}
 
-		displayLine := line
+		content := line
 		if backend == "cliphist" {
-			if _, content, found := strings.Cut(line, "\t"); found {
-				displayLine = content
+			if _, preview, found := strings.Cut(line, "\t"); found {
+				content = preview
 			}
 		}
 
-		if len(displayLine) > 100 {
-			displayLine = displayLine[:97] + "..."
+		label := content
+		if mask != nil && mask.matches(content) {
+			label = maskedLabel
+		} else if len(label) > 100 {
+			label = label[:97] + "..."
 		}
 
-		filtered = append(filtered, displayLine)
+		seen[label]++
+		if seen[label] > 1 {
+			label = fmt.Sprintf("%s [%d]", label, seen[label])
+		}
+
+		entries = append(entries, historyEntry{Label: label, Value: content, raw: line})
+	}
+
+	if maxItems > 0 && len(entries) > maxItems {
+		entries = entries[:maxItems]
 	}
 
-	if maxItems > 0 && len(filtered) > maxItems {
-		filtered = filtered[:maxItems]
+	return entries, nil
+}
+
+// entryContent returns the full content of an entry. cliphist only lists a
+// preview, so the entry is decoded; the preview is used if that fails.
+func entryContent(backend string, entry historyEntry) string {
+	if backend != "cliphist" || entry.raw == "" {
+		return entry.Value
 	}
 
-	return filtered, nil
+	cmd := exec.Command("cliphist", "decode")
+	cmd.Stdin = strings.NewReader(entry.raw)
+	output, err := cmd.Output()
+	if err != nil {
+		return entry.Value
+	}
+
+	return string(output)
 }
 
-func getClipmenuHistory() ([]string, error) {
-	return []string{"clipmenu:   Use 'clipmenu' directly"}, nil
+func getClipmenuHistory() ([]historyEntry, error) {
+	message := "clipmenu:   Use 'clipmenu' directly"
+	return []historyEntry{{Label: message, Value: message}}, nil
 }
 
 func clearHistory(ctx commands.LauncherContext, backend string, notifCfg *config.NotificationConfig) commands.CommandResult {
-	options := []string{"← Back", "Yes", "No"}
+	options := commands.ConfirmOptions(ctx)
 	choice, err := ctx.Show(options, "Clear clipboard history? ")
 	if err != nil {
 		// ESC pressed - return error that's NOT ErrBack
@@ -314,42 +438,5 @@ func clearHistory(ctx commands.LauncherContext, backend string, notifCfg *config
 	}
 
 	utils.NotifyWithConfig(notifCfg, "Clipboard", "History cleared")
-	return commands.CommandResult{Success: false, Error: commands.ErrBack}
-}
-
-func copyToClipboard(content string) error {
-	server := utils.DetectDisplayServer()
-
-	var cmd *exec.Cmd
-	if server.IsWayland() {
-		if !utils.CommandExists("wl-copy") {
-			return fmt.Errorf("wl-copy not found (install wl-clipboard)")
-		}
-		cmd = exec.Command("wl-copy")
-	} else {
-		if utils.CommandExists("xclip") {
-			cmd = exec.Command("xclip", "-selection", "clipboard")
-		} else if utils.CommandExists("xsel") {
-			cmd = exec.Command("xsel", "-b")
-		} else {
-			return fmt.Errorf("no clipboard tool found (install xclip or xsel)")
-		}
-	}
-
-	stdin, err := cmd.StdinPipe()
-	if err != nil {
-		return err
-	}
-
-	if err := cmd.Start(); err != nil {
-		return err
-	}
-
-	_, err = stdin.Write([]byte(content))
-	if err != nil {
-		return err
-	}
-	stdin.Close()
-
-	return cmd.Wait()
+	return commands.CommandResult{Success: true}
 }
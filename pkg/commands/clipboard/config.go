@@ -4,12 +4,37 @@ package clipboard
 type Config struct {
 	Enabled  bool `mapstructure:"enabled"`
 	MaxItems int  `mapstructure:"max_items"`
+	// Backend forces cliphist, clipman or clipmenu; "auto" picks the first installed
+	Backend string `mapstructure:"backend"`
+	// DefaultAction is what selecting a history entry does: "copy" or "type"
+	DefaultAction string `mapstructure:"default_action"`
+	// MaskSensitive hides entries that look like passwords or tokens in the menu
+	MaskSensitive bool `mapstructure:"mask_sensitive"`
+	// MaskPatterns are regexes for entries to hide in addition to the entropy check
+	MaskPatterns []string `mapstructure:"mask_patterns"`
+	// SyncPrimary also sets the PRIMARY selection when copying a history entry
+	SyncPrimary bool `mapstructure:"sync_primary"`
 }
 
 // DefaultConfig returns default clipboard configuration
 func DefaultConfig() Config {
 	return Config{
-		Enabled:  true,
-		MaxItems: 50,
+		Enabled:       true,
+		MaxItems:      50,
+		Backend:       "auto",
+		DefaultAction: "copy",
+		MaskSensitive: true,
+		MaskPatterns:  defaultMaskPatterns,
 	}
 }
+
+// defaultMaskPatterns match well-known token and key formats
+var defaultMaskPatterns = []string{
+	`^gh[pousr]_[A-Za-z0-9]{36,}$`,
+	`^(sk|pk|rk)[-_][A-Za-z0-9_-]{20,}$`,
+	`^xox[abprs]-[A-Za-z0-9-]{10,}$`,
+	`^AKIA[0-9A-Z]{16}$`,
+	`^eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+$`,
+	`-----BEGIN [A-Z ]*PRIVATE KEY-----`,
+	`(?i)^(password|passwd|pwd|secret|token|api[_-]?key)\s*[:=]`,
+}
@@ -0,0 +1,216 @@
+package clipboard
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/lvim-tech/ql/pkg/commands"
+	"github.com/lvim-tech/ql/pkg/config"
+	"github.com/lvim-tech/ql/pkg/utils"
+)
+
+// exportVersion is the format version written to export files
+const exportVersion = 1
+
+// historyExport is the file written by 'ql clipboard export'
+type historyExport struct {
+	Version  int           `json:"version"`
+	Backend  string        `json:"backend"`
+	Exported string        `json:"exported"`
+	Entries  []exportEntry `json:"entries"` // newest first, as the backend lists them
+}
+
+// exportEntry holds one clip. Text is stored as is; anything else (images,
+// invalid UTF-8) is base64 with Encoding "base64" and its detected MIME type.
+type exportEntry struct {
+	Content  string `json:"content"`
+	Encoding string `json:"encoding,omitempty"`
+	MIME     string `json:"mime,omitempty"`
+}
+
+// newExportEntry encodes raw clip data for the export file
+func newExportEntry(data []byte) exportEntry {
+	if utf8.Valid(data) && !bytes.ContainsRune(data, 0) {
+		return exportEntry{Content: string(data)}
+	}
+	return exportEntry{
+		Content:  base64.StdEncoding.EncodeToString(data),
+		Encoding: "base64",
+		MIME:     http.DetectContentType(data),
+	}
+}
+
+// data returns the raw clip
+func (e exportEntry) data() ([]byte, error) {
+	if e.Encoding == "base64" {
+		return base64.StdEncoding.DecodeString(e.Content)
+	}
+	return []byte(e.Content), nil
+}
+
+// exportHistory writes the full history to path. Unlike the menu nothing is
+// truncated, masked or capped at max_items, so the file is readable by the
+// user only.
+func exportHistory(backend, path string, notifCfg *config.NotificationConfig) commands.CommandResult {
+	clips, err := readFullHistory(backend)
+	if err != nil {
+		return commands.CommandResult{Success: false, Error: err}
+	}
+
+	export := historyExport{
+		Version:  exportVersion,
+		Backend:  backend,
+		Exported: time.Now().Format(time.RFC3339),
+		Entries:  make([]exportEntry, 0, len(clips)),
+	}
+	for _, clip := range clips {
+		export.Entries = append(export.Entries, newExportEntry(clip))
+	}
+
+	data, err := json.MarshalIndent(export, "", "  ")
+	if err != nil {
+		return commands.CommandResult{Success: false, Error: fmt.Errorf("failed to encode history: %w", err)}
+	}
+
+	path = utils.ExpandPath(path)
+	if err := utils.EnsureDir(filepath.Dir(path)); err != nil {
+		return commands.CommandResult{Success: false, Error: fmt.Errorf("failed to create directory: %w", err)}
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return commands.CommandResult{Success: false, Error: fmt.Errorf("failed to write export: %w", err)}
+	}
+
+	message := fmt.Sprintf("Exported %d entries to %s", len(export.Entries), path)
+	utils.NotifyWithConfig(notifCfg, "Clipboard", message)
+	return commands.CommandResult{Success: true, Message: message}
+}
+
+// importHistory stores every entry of an export file in the active backend,
+// oldest first so the history keeps its order. clipman keeps text only, so
+// binary entries are skipped there.
+func importHistory(backend, path string, notifCfg *config.NotificationConfig) commands.CommandResult {
+	data, err := os.ReadFile(utils.ExpandPath(path))
+	if err != nil {
+		return commands.CommandResult{Success: false, Error: fmt.Errorf("failed to read export: %w", err)}
+	}
+
+	var export historyExport
+	if err := json.Unmarshal(data, &export); err != nil {
+		return commands.CommandResult{Success: false, Error: fmt.Errorf("failed to parse %s: %w", path, err)}
+	}
+	if export.Version > exportVersion {
+		return commands.CommandResult{Success: false, Error: fmt.Errorf("export version %d is newer than this ql supports (%d)", export.Version, exportVersion)}
+	}
+
+	var imported, skipped int
+	for i := len(export.Entries) - 1; i >= 0; i-- {
+		entry := export.Entries[i]
+
+		clip, err := entry.data()
+		if err != nil || len(clip) == 0 || (backend == "clipman" && entry.Encoding != "") {
+			skipped++
+			continue
+		}
+
+		if err := storeClip(backend, clip); err != nil {
+			return commands.CommandResult{Success: false, Error: fmt.Errorf("imported %d entries, then failed: %w", imported, err)}
+		}
+		imported++
+	}
+
+	message := fmt.Sprintf("Imported %d entries", imported)
+	if skipped > 0 {
+		message += fmt.Sprintf(" (%d skipped)", skipped)
+	}
+	utils.NotifyWithConfig(notifCfg, "Clipboard", message)
+	return commands.CommandResult{Success: true, Message: message}
+}
+
+// readFullHistory returns the untruncated content of every history entry,
+// newest first
+func readFullHistory(backend string) ([][]byte, error) {
+	switch backend {
+	case "cliphist":
+		output, err := exec.Command("cliphist", "list").Output()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get clipboard history: %w", err)
+		}
+
+		var clips [][]byte
+		for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+			if line == "" {
+				continue
+			}
+			cmd := exec.Command("cliphist", "decode")
+			cmd.Stdin = strings.NewReader(line)
+			clip, err := cmd.Output()
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode history entry: %w", err)
+			}
+			clips = append(clips, clip)
+		}
+		return clips, nil
+
+	case "clipman":
+		// clipman keeps its history as a JSON array of strings, oldest first
+		data, err := os.ReadFile(clipmanHistoryPath())
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read clipman history: %w", err)
+		}
+
+		var history []string
+		if err := json.Unmarshal(data, &history); err != nil {
+			return nil, fmt.Errorf("failed to parse clipman history: %w", err)
+		}
+
+		clips := make([][]byte, 0, len(history))
+		for i := len(history) - 1; i >= 0; i-- {
+			clips = append(clips, []byte(history[i]))
+		}
+		return clips, nil
+
+	case "clipmenu":
+		return nil, fmt.Errorf("export not supported for clipmenu")
+	default:
+		return nil, fmt.Errorf("unsupported backend: %s", backend)
+	}
+}
+
+// storeClip adds one clip to the backend's history, the way its clipboard
+// watcher would
+func storeClip(backend string, clip []byte) error {
+	var cmd *exec.Cmd
+	switch backend {
+	case "cliphist":
+		cmd = exec.Command("cliphist", "store")
+	case "clipman":
+		cmd = exec.Command("clipman", "store", "--no-persist")
+	case "clipmenu":
+		return fmt.Errorf("import not supported for clipmenu")
+	default:
+		return fmt.Errorf("unsupported backend: %s", backend)
+	}
+
+	cmd.Stdin = bytes.NewReader(clip)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("%s store failed: %s", backend, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// clipmanHistoryPath is clipman's default --histpath
+func clipmanHistoryPath() string {
+	return filepath.Join(utils.GetDataDir(), "clipman.json")
+}
@@ -0,0 +1,106 @@
+package clipboard
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// maskedLabel replaces sensitive entries in the history menu
+const maskedLabel = "•••• (hidden)"
+
+// minSecretLength is the shortest entry the entropy heuristic considers
+const minSecretLength = 10
+
+// minSecretEntropy is the Shannon entropy in bits per character above which
+// a single token with mixed character classes is treated as a secret
+const minSecretEntropy = 3.0
+
+// maskMatcher decides which history entries are shown masked
+type maskMatcher struct {
+	patterns []*regexp.Regexp
+	entropy  bool
+}
+
+func newMaskMatcher(patterns []string, entropy bool) (*maskMatcher, error) {
+	m := &maskMatcher{entropy: entropy}
+
+	for _, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid mask pattern %q: %w", pattern, err)
+		}
+		m.patterns = append(m.patterns, re)
+	}
+
+	return m, nil
+}
+
+// matches reports whether content looks like a password, token or key
+func (m *maskMatcher) matches(content string) bool {
+	content = strings.TrimSpace(content)
+
+	for _, re := range m.patterns {
+		if re.MatchString(content) {
+			return true
+		}
+	}
+
+	return m.entropy && looksLikeSecret(content)
+}
+
+// looksLikeSecret flags single tokens that are long, mix at least three
+// character classes and have high entropy. URLs and paths are never flagged.
+func looksLikeSecret(content string) bool {
+	if len(content) < minSecretLength || strings.ContainsFunc(content, unicode.IsSpace) {
+		return false
+	}
+
+	if strings.Contains(content, "://") || strings.HasPrefix(content, "/") || strings.HasPrefix(content, "~") {
+		return false
+	}
+
+	var lower, upper, digit, symbol bool
+	for _, r := range content {
+		switch {
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsDigit(r):
+			digit = true
+		case !strings.ContainsRune(".-_", r):
+			// Separators alone are common in versions and identifiers
+			symbol = true
+		}
+	}
+
+	classes := 0
+	for _, present := range []bool{lower, upper, digit, symbol} {
+		if present {
+			classes++
+		}
+	}
+
+	return classes >= 3 && shannonEntropy(content) >= minSecretEntropy
+}
+
+// shannonEntropy returns the entropy of s in bits per character
+func shannonEntropy(s string) float64 {
+	counts := make(map[rune]int)
+	total := 0
+	for _, r := range s {
+		counts[r]++
+		total++
+	}
+
+	var entropy float64
+	for _, count := range counts {
+		p := float64(count) / float64(total)
+		entropy -= p * math.Log2(p)
+	}
+
+	return entropy
+}
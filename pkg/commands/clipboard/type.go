@@ -0,0 +1,41 @@
+package clipboard
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/lvim-tech/ql/pkg/utils"
+)
+
+// typeDelay lets the launcher window close so keystrokes reach the previously
+// focused window
+const typeDelay = 200 * time.Millisecond
+
+// typeText simulates keystrokes for content with wtype (Wayland) or xdotool
+// (X11). The text goes through stdin, so leading dashes and shell characters
+// are typed literally; newlines are typed as Return.
+func typeText(content string) error {
+	var cmd *exec.Cmd
+	if utils.DetectDisplayServer().IsWayland() {
+		if !utils.CommandExists("wtype") {
+			return fmt.Errorf("wtype not found (required to type on Wayland)")
+		}
+		cmd = exec.Command("wtype", "-")
+	} else {
+		if !utils.CommandExists("xdotool") {
+			return fmt.Errorf("xdotool not found (required to type on X11)")
+		}
+		cmd = exec.Command("xdotool", "type", "--clearmodifiers", "--file", "-")
+	}
+
+	time.Sleep(typeDelay)
+
+	cmd.Stdin = strings.NewReader(content)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to type text: %s", strings.TrimSpace(string(output)))
+	}
+
+	return nil
+}
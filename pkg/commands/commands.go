@@ -4,14 +4,33 @@
 package commands
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"os/exec"
+	"slices"
+	"strings"
+	"sync"
 
 	"github.com/lvim-tech/ql/pkg/config"
+	"github.com/lvim-tech/ql/pkg/utils"
+	"github.com/mitchellh/mapstructure"
 )
 
-// Sentinel errors for command navigation
+// ConfirmOptions returns the entries of a yes/no confirmation for a
+// destructive action. Most launchers preselect the first entry, so with
+// dangerous_default_no (the default) "No" leads and a stray Enter is safe.
+func ConfirmOptions(ctx LauncherContext) []string {
+	if ctx.Config().GetDangerousDefaultNo() {
+		return []string{"No", "Yes", "← Back"}
+	}
+	return []string{"← Back", "Yes", "No"}
+}
+
+// Sentinel errors for command navigation. ErrCancelled is the one
+// utils.PromptPassword returns, so a dismissed prompt needs no translating.
 var (
-	ErrCancelled = errors.New("cancelled")
+	ErrCancelled = utils.ErrCancelled
 	ErrBack      = errors.New("back")
 )
 
@@ -19,31 +38,182 @@ var (
 type CommandResult struct {
 	Success bool
 	Error   error
+	// Message and Data are emitted as structured output in JSON mode
+	Message string
+	Data    any
 }
 
 // Command represents a command
 type Command struct {
 	Name        string
 	Description string
-	Run         func(LauncherContext) CommandResult
+	// Requires lists external tools that must be in PATH for the command to run
+	Requires []string
+	// Subcommands lists the direct actions ('ql <name> <action>'), if declared
+	Subcommands []SubcommandInfo
+	// Check optionally verifies the module's config and preconditions (a
+	// reachable server, a device, a backend) for 'ql doctor'
+	Check func(*config.Config) error
+	// Init optionally prepares the module once per process, before its
+	// first Run: decoding and caching config, validating dependencies,
+	// setting up connections. A module whose Init fails is not run and is
+	// left out of menus from then on.
+	Init func(*config.Config) error
+	// Config returns the module's defaults as a pointer to its config type,
+	// for 'ql doctor' to decode [commands.<name>] into. See ConfigOf.
+	Config func() any
+	// Background reports whether args start a long-lived helper the module
+	// spawned detached (a sampler, a watcher). Those run without
+	// module_timeout and are not counted as launches.
+	Background func(args []string) bool
+	Run        func(LauncherContext) CommandResult
+}
+
+// DecodeConfig decodes a module's [commands.<name>] table into target the
+// way modules do in Run, reporting values of the wrong type
+func DecodeConfig(raw any, target any) error {
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		WeaklyTypedInput: true,
+		Result:           target,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := decoder.Decode(raw); err != nil {
+		// One line per problem reads badly in 'ql doctor' and notifications
+		var decodeErr *mapstructure.Error
+		if errors.As(err, &decodeErr) {
+			return fmt.Errorf("invalid config: %s", strings.Join(decodeErr.Errors, "; "))
+		}
+		return fmt.Errorf("invalid config: %w", err)
+	}
+
+	return nil
+}
+
+// ConfigOf adapts a module's DefaultConfig to Command.Config
+func ConfigOf[T any](defaults func() T) func() any {
+	return func() any {
+		cfg := defaults()
+		return &cfg
+	}
+}
+
+// initResults holds the outcome of each module's Init, by module name
+var (
+	initMu      sync.Mutex
+	initResults = make(map[string]error)
+)
+
+// Initialize runs the command's Init the first time it is called and
+// returns the same result on later calls. Commands without Init succeed.
+func (c Command) Initialize(cfg *config.Config) error {
+	if c.Init == nil {
+		return nil
+	}
+
+	initMu.Lock()
+	defer initMu.Unlock()
+
+	if err, done := initResults[c.Name]; done {
+		return err
+	}
+
+	err := c.Init(cfg)
+	initResults[c.Name] = err
+	return err
+}
+
+// InitFailed reports whether the command's Init already ran and failed,
+// without running it
+func (c Command) InitFailed() bool {
+	initMu.Lock()
+	defer initMu.Unlock()
+
+	return initResults[c.Name] != nil
+}
+
+// MissingRequirements returns the required tools that are not installed
+func (c Command) MissingRequirements() []string {
+	var missing []string
+	for _, tool := range c.Requires {
+		if _, err := exec.LookPath(tool); err != nil {
+			missing = append(missing, tool)
+		}
+	}
+	return missing
 }
 
 // LauncherContext interface for launcher
 type LauncherContext interface {
+	// Name returns the active launcher (rofi, dmenu, fzf, bemenu, fuzzel)
+	Name() string
 	Show(options []string, prompt string) (string, error)
+	// ShowOrdered is Show for menus whose order matters: launchers that rank
+	// matches (rofi, fuzzel, fzf) are told to keep the given order
+	ShowOrdered(options []string, prompt string) (string, error)
+	// ShowAllowCustom is Show that also accepts text matching no option
+	ShowAllowCustom(options []string, prompt string) (string, error)
+	// ShowMulti lets the user pick several options; launchers without
+	// multi-select ask for one option at a time until "✓ Done"
+	ShowMulti(options []string, prompt string) ([]string, error)
+	// ShowWithMessage is Show with a status line above the options that
+	// cannot be selected (rofi -mesg, fzf --header); launchers without one
+	// show it after the prompt
+	ShowWithMessage(options []string, prompt, message string) (string, error)
 	Config() *config.Config
+	// Context is cancelled when the module exceeds its module_timeout. Run
+	// commands with utils.RunCommandContext(ctx.Context(), ...) so their
+	// process group is killed with it.
+	Context() context.Context
 	IsDirectLaunch() bool
+	IsJSONOutput() bool
 	Args() []string
 }
 
 var registry []Command
 
-// Register registers a command
+// Register registers a command. A second registration under the same name
+// is ignored, so the first one wins regardless of how often init runs.
 func Register(cmd Command) {
+	for _, existing := range registry {
+		if existing.Name == cmd.Name {
+			return
+		}
+	}
 	registry = append(registry, cmd)
 }
 
-// GetAll returns all registered commands
+// GetAll returns all registered commands sorted by name, so the order does
+// not depend on package initialization order
 func GetAll() []Command {
-	return registry
+	sorted := slices.Clone(registry)
+	slices.SortFunc(sorted, func(a, b Command) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return sorted
+}
+
+// runner launches a command with everything the main menu does around Run
+var runner func(LauncherContext, Command) CommandResult
+
+// SetRunner installs the function Launch uses. The ql binary sets it to its
+// own runner: required tools, Init, module_timeout, keep_open and launch
+// counting.
+func SetRunner(run func(LauncherContext, Command) CommandResult) {
+	runner = run
+}
+
+// Launch runs cmd the way the main menu does, for modules that open other
+// modules (hub). Without a runner it only checks Init before calling Run.
+func Launch(ctx LauncherContext, cmd Command) CommandResult {
+	if runner != nil {
+		return runner(ctx, cmd)
+	}
+
+	if err := cmd.Initialize(ctx.Config()); err != nil {
+		return CommandResult{Success: false, Error: fmt.Errorf("module %s cannot run: %w", cmd.Name, err)}
+	}
+	return cmd.Run(ctx)
 }
@@ -0,0 +1,13 @@
+package hub
+
+// Config represents hub module configuration
+type Config struct {
+	Enabled bool `mapstructure:"enabled"`
+}
+
+// DefaultConfig returns default hub configuration
+func DefaultConfig() Config {
+	return Config{
+		Enabled: true,
+	}
+}
@@ -0,0 +1,137 @@
+// Package hub provides a single flat menu of every enabled ql module.
+// It ignores module groups, which makes it a convenient single keybind target.
+package hub
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/lvim-tech/ql/pkg/commands"
+	"github.com/mitchellh/mapstructure"
+)
+
+func init() {
+	commands.Register(commands.Command{
+		Name:        "hub",
+		Description: "All modules",
+		Config:      commands.ConfigOf(DefaultConfig),
+		Run:         Run,
+	})
+}
+
+// moduleContext runs a module as if it was opened from a menu: it gets
+// "← Back" entries and none of the hub's own arguments
+type moduleContext struct {
+	commands.LauncherContext
+}
+
+func (moduleContext) IsDirectLaunch() bool {
+	return false
+}
+
+func (moduleContext) Args() []string {
+	return nil
+}
+
+func Run(ctx commands.LauncherContext) commands.CommandResult {
+	cfgInterface := ctx.Config().GetHubConfig()
+
+	var cfg Config
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		WeaklyTypedInput: true,
+		Result:           &cfg,
+	})
+	if err != nil {
+		cfg = DefaultConfig()
+	} else {
+		if decodeErr := decoder.Decode(cfgInterface); decodeErr != nil {
+			cfg = DefaultConfig()
+		}
+	}
+
+	if !cfg.Enabled {
+		return commands.CommandResult{
+			Success: false,
+			Error:   fmt.Errorf("hub module is disabled in config"),
+		}
+	}
+
+	for {
+		var options []string
+		optionToCommand := make(map[string]commands.Command)
+
+		if !ctx.IsDirectLaunch() {
+			options = append(options, "← Back")
+		}
+
+		for _, cmd := range orderedCommands(ctx) {
+			options = append(options, cmd.Description)
+			optionToCommand[cmd.Description] = cmd
+		}
+
+		if len(optionToCommand) == 0 {
+			return commands.CommandResult{
+				Success: false,
+				Error:   fmt.Errorf("no enabled commands"),
+			}
+		}
+
+		choice, err := ctx.Show(options, "ql")
+		if err != nil {
+			// ESC pressed - exit completely
+			return commands.CommandResult{Success: false}
+		}
+
+		if choice == "← Back" {
+			return commands.CommandResult{
+				Success: false,
+				Error:   commands.ErrBack,
+			}
+		}
+
+		cmd, ok := optionToCommand[choice]
+		if !ok {
+			continue
+		}
+
+		// Launch, not Run: the module needs its Init, module_timeout and
+		// keep_open just as it does from the main menu
+		result := commands.Launch(moduleContext{ctx}, cmd)
+
+		if errors.Is(result.Error, commands.ErrBack) {
+			continue
+		}
+
+		return result
+	}
+}
+
+// orderedCommands returns the available modules other than hub itself, in
+// module_order first and then by name. Modules whose Init failed are left
+// out, as in the main menu.
+func orderedCommands(ctx commands.LauncherContext) []commands.Command {
+	cfg := ctx.Config()
+	all := commands.GetAll()
+
+	moduleOrder := slices.Clone(cfg.GetModuleOrder())
+	for _, cmd := range all {
+		if !slices.Contains(moduleOrder, cmd.Name) {
+			moduleOrder = append(moduleOrder, cmd.Name)
+		}
+	}
+
+	var result []commands.Command
+	for _, name := range moduleOrder {
+		idx := slices.IndexFunc(all, func(cmd commands.Command) bool { return cmd.Name == name })
+		if idx == -1 || name == "hub" || !cfg.IsCommandEnabled(name) {
+			continue
+		}
+		if len(all[idx].MissingRequirements()) > 0 || all[idx].InitFailed() {
+			continue
+		}
+		result = append(result, all[idx])
+	}
+
+	return result
+}
@@ -6,7 +6,10 @@ type Config struct {
 	ShowUserProcesses bool     `mapstructure:"show_user_processes"`
 	ShowAllProcesses  bool     `mapstructure:"show_all_processes"`
 	ExcludeProcesses  []string `mapstructure:"exclude_processes"`
-	ConfirmKill       bool     `mapstructure:"confirm_kill"`
+	// ProtectedProcesses are listed but need their name typed to be killed
+	ProtectedProcesses []string `mapstructure:"protected_processes"`
+	ConfirmKill        bool     `mapstructure:"confirm_kill"`
+	ShowAbsoluteMem    bool     `mapstructure:"show_absolute_mem"`
 }
 
 // DefaultConfig returns default kill configuration
@@ -16,10 +19,28 @@ func DefaultConfig() Config {
 		ShowUserProcesses: true,
 		ShowAllProcesses:  false,
 		ExcludeProcesses: []string{
-			"systemd",
-			"init",
-			"kthreadd",
+			"/^systemd$/",
+			"/^init$/",
+			"/^kthreadd$/",
 		},
-		ConfirmKill: true,
+		ProtectedProcesses: defaultProtectedProcesses,
+		ConfirmKill:        true,
+		ShowAbsoluteMem:    true,
 	}
 }
+
+// defaultProtectedProcesses are the display server, audio and compositor
+// processes whose death ends or breaks the session
+var defaultProtectedProcesses = []string{
+	"/^Xorg$/",
+	"/^Xwayland$/",
+	"/^pipewire/",
+	"/^wireplumber$/",
+	"/^pulseaudio$/",
+	"/^dbus-/",
+	"/^sway$/",
+	"/^Hyprland$/",
+	"/^niri$/",
+	"/^gnome-shell$/",
+	"/^kwin_/",
+}
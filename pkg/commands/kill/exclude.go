@@ -0,0 +1,64 @@
+package kill
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// processMatcher matches process names against a list from the config:
+// exclude_processes (hidden from the list) or protected_processes (shown,
+// but killed only after typing the name). Entries written as /regex/ are
+// matched against the full command; any other entry is a case-insensitive
+// substring, as in older configs.
+type processMatcher struct {
+	substrings []string
+	patterns   []*regexp.Regexp
+}
+
+// newProcessMatcher compiles the entries of the named setting once per listing
+func newProcessMatcher(excludeList []string, setting string) (*processMatcher, error) {
+	m := &processMatcher{}
+
+	for _, exclude := range excludeList {
+		if expr, ok := regexEntry(exclude); ok {
+			re, err := regexp.Compile(expr)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s pattern %q: %w", setting, exclude, err)
+			}
+			m.patterns = append(m.patterns, re)
+			continue
+		}
+
+		if exclude != "" {
+			m.substrings = append(m.substrings, strings.ToLower(exclude))
+		}
+	}
+
+	return m, nil
+}
+
+// regexEntry unwraps a /regex/ entry
+func regexEntry(exclude string) (string, bool) {
+	if len(exclude) < 2 || !strings.HasPrefix(exclude, "/") || !strings.HasSuffix(exclude, "/") {
+		return "", false
+	}
+	return exclude[1 : len(exclude)-1], true
+}
+
+func (m *processMatcher) matches(command string) bool {
+	for _, re := range m.patterns {
+		if re.MatchString(command) {
+			return true
+		}
+	}
+
+	commandLower := strings.ToLower(command)
+	for _, exclude := range m.substrings {
+		if strings.Contains(commandLower, exclude) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,59 @@
+package kill
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestProcessMatcher(t *testing.T) {
+	tests := []struct {
+		name    string
+		entries []string
+		command string
+		want    bool
+	}{
+		// Plain entries are substrings, as in older configs: excluding
+		// systemd hides systemd-resolved too
+		{"substring exact", []string{"systemd"}, "systemd", true},
+		{"substring prefix", []string{"systemd"}, "systemd-resolved", true},
+		{"substring case-insensitive", []string{"Xorg"}, "xorg", true},
+		{"substring no match", []string{"systemd"}, "firefox", false},
+
+		// /^systemd$/ hides only systemd itself
+		{"anchored exact", []string{"/^systemd$/"}, "systemd", true},
+		{"anchored keeps systemd-resolved", []string{"/^systemd$/"}, "systemd-resolved", false},
+		{"anchored keeps systemd-journald", []string{"/^systemd$/"}, "systemd-journald", false},
+		{"regex is case-sensitive", []string{"/^systemd$/"}, "Systemd", false},
+		{"regex alternation", []string{"/^(init|kthreadd)$/"}, "kthreadd", true},
+
+		{"mixed entries", []string{"/^systemd$/", "pipewire"}, "pipewire-pulse", true},
+		{"empty entry ignored", []string{""}, "anything", false},
+		{"single slash is a substring", []string{"/"}, "/usr/bin/foo", true},
+		{"no entries", nil, "systemd", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := newProcessMatcher(tt.entries, "exclude_processes")
+			if err != nil {
+				t.Fatalf("newProcessMatcher: %v", err)
+			}
+			if got := m.matches(tt.command); got != tt.want {
+				t.Errorf("matches(%q) with %q = %v, want %v", tt.command, tt.entries, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProcessMatcherInvalidRegex(t *testing.T) {
+	_, err := newProcessMatcher([]string{"/^systemd$/", "/[unclosed/"}, "exclude_processes")
+	if err == nil {
+		t.Fatal("expected an error for an invalid pattern")
+	}
+
+	for _, want := range []string{"exclude_processes", "/[unclosed/"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not name %q", err, want)
+		}
+	}
+}
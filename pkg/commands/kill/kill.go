@@ -6,7 +6,10 @@ import (
 	"fmt"
 	"os/exec"
 	"os/user"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/lvim-tech/ql/pkg/commands"
 	"github.com/lvim-tech/ql/pkg/config"
@@ -18,6 +21,8 @@ func init() {
 	commands.Register(commands.Command{
 		Name:        "kill",
 		Description: "Kill processes",
+		Requires:    []string{"ps", "kill"},
+		Config:      commands.ConfigOf(DefaultConfig),
 		Run:         Run,
 	})
 }
@@ -27,8 +32,12 @@ type Process struct {
 	User    string
 	CPU     string
 	MEM     string
+	RSS     uint64 // resident memory in bytes
 	Command string
 	Display string
+	// Protected processes match protected_processes and need the name typed
+	// before they are killed
+	Protected bool
 }
 
 func Run(ctx commands.LauncherContext) commands.CommandResult {
@@ -54,19 +63,37 @@ func Run(ctx commands.LauncherContext) commands.CommandResult {
 		}
 	}
 
-	if !utils.CommandExists("ps") {
-		notifCfg := ctx.Config().GetNotificationConfig()
-		utils.ShowErrorNotificationWithConfig(&notifCfg, "Kill Error",
-			"ps command not found")
-		return commands.CommandResult{Success: false}
-	}
-
 	notifCfg := ctx.Config().GetNotificationConfig()
 
 	// Check for direct command (kill by PID or process name)
 	args := ctx.Args()
+
+	var trendInterval time.Duration
+	if len(args) > 0 && args[0] == "--trend" {
+		trendInterval, err = parseTrendInterval(args[1:])
+		if err != nil {
+			return commands.CommandResult{Success: false, Error: err}
+		}
+		args = nil
+	}
+
 	if len(args) > 0 {
-		return executeDirectKill(args[0], &cfg, &notifCfg)
+		if args[0] == "--tree" {
+			if len(args) < 2 {
+				return commands.CommandResult{
+					Success: false,
+					Error:   fmt.Errorf("usage: ql kill --tree <pid>"),
+				}
+			}
+			return executeDirectKillTree(ctx, args[1], &cfg, &notifCfg)
+		}
+		if args[0] == "--unit" {
+			if len(args) < 2 {
+				return selectUnit(ctx, &cfg, &notifCfg)
+			}
+			return executeDirectStopUnit(ctx, args[1], &cfg, &notifCfg)
+		}
+		return executeDirectKill(ctx, args[0], &cfg, &notifCfg)
 	}
 
 	processes, err := getProcesses(&cfg)
@@ -75,6 +102,13 @@ func Run(ctx commands.LauncherContext) commands.CommandResult {
 		return commands.CommandResult{Success: false}
 	}
 
+	if trendInterval > 0 {
+		notifyID := utils.ShowPersistentNotificationWithConfig(&notifCfg, "Kill",
+			fmt.Sprintf("Sampling memory for %s...", trendInterval))
+		processes = annotateTrend(processes, trendInterval)
+		utils.ClosePersistentNotificationWithConfig(&notifCfg, notifyID)
+	}
+
 	if len(processes) == 0 {
 		utils.ShowErrorNotificationWithConfig(&notifCfg, "Kill Error", "No processes found")
 		return commands.CommandResult{Success: false}
@@ -86,27 +120,38 @@ func Run(ctx commands.LauncherContext) commands.CommandResult {
 		options = append(options, "← Back")
 	}
 
+	if utils.CommandExists("systemctl") {
+		options = append(options, unitsItem)
+	}
+
+	options = append(options, multipleItem)
+
 	for _, proc := range processes {
 		options = append(options, proc.Display)
 	}
 
-	selected, err := ctx.Show(options, "Kill Process")
+	choice, err := ctx.Show(options, "Kill Process")
 	if err != nil {
 		// ESC pressed - exit completely
 		return commands.CommandResult{Success: false}
 	}
 
-	if selected == "← Back" || selected == "" {
+	switch choice {
+	case "← Back":
 		return commands.CommandResult{
 			Success: false,
 			Error:   commands.ErrBack,
 		}
+	case unitsItem:
+		return selectUnit(ctx, &cfg, &notifCfg)
+	case multipleItem:
+		return selectMultiple(ctx, processes, &cfg, &notifCfg)
 	}
 
 	var selectedProc *Process
-	for _, proc := range processes {
-		if proc.Display == selected {
-			selectedProc = &proc
+	for i := range processes {
+		if processes[i].Display == choice {
+			selectedProc = &processes[i]
 			break
 		}
 	}
@@ -115,9 +160,46 @@ func Run(ctx commands.LauncherContext) commands.CommandResult {
 		return commands.CommandResult{Success: false, Error: commands.ErrBack}
 	}
 
+	killTree := false
+	childCount := countDescendants(selectedProc.PID)
+	unit := unitForPID(selectedProc.PID)
+	if childCount > 0 || unit != "" {
+		modeOpts := []string{"← Back", "Kill process only"}
+		prompt := fmt.Sprintf("%s runs in %s", selectedProc.Command, unit)
+		if childCount > 0 {
+			modeOpts = append(modeOpts, fmt.Sprintf("Kill with children (%d)", childCount))
+			prompt = fmt.Sprintf("%s has %d child processes", selectedProc.Command, childCount)
+		}
+		if unit != "" {
+			// Stopping the unit takes down every process systemd tracks for it
+			modeOpts = append(modeOpts, fmt.Sprintf("Stop unit %s", unit))
+		}
+
+		mode, err := ctx.Show(modeOpts, prompt)
+		if err != nil {
+			// ESC pressed - exit completely
+			return commands.CommandResult{Success: false}
+		}
+
+		if mode == "← Back" {
+			return commands.CommandResult{Success: false, Error: commands.ErrBack}
+		}
+
+		if strings.HasPrefix(mode, "Stop unit ") {
+			return stopUnitWithConfirm(ctx, unit, &cfg, &notifCfg)
+		}
+
+		killTree = strings.HasPrefix(mode, "Kill with children")
+	}
+
 	if cfg.ConfirmKill {
-		confirmOpts := []string{"← Back", "Yes", "No"}
-		confirm, err := ctx.Show(confirmOpts, fmt.Sprintf("Kill process %s (PID:       %s)?    ", selectedProc.Command, selectedProc.PID))
+		prompt := fmt.Sprintf("Kill process %s (PID: %s)? ", selectedProc.Command, selectedProc.PID)
+		if killTree {
+			prompt = fmt.Sprintf("Kill %s (PID: %s) and %d children? ", selectedProc.Command, selectedProc.PID, childCount)
+		}
+
+		confirmOpts := commands.ConfirmOptions(ctx)
+		confirm, err := ctx.Show(confirmOpts, prompt)
 		if err != nil {
 			// ESC pressed - exit completely
 			return commands.CommandResult{Success: false}
@@ -132,6 +214,36 @@ func Run(ctx commands.LauncherContext) commands.CommandResult {
 		}
 	}
 
+	if killTree {
+		tree, err := processTree(*selectedProc, &cfg)
+		if err != nil {
+			utils.ShowErrorNotificationWithConfig(&notifCfg, "Kill Error",
+				fmt.Sprintf("Failed to list child processes: %v", err))
+			return commands.CommandResult{Success: false}
+		}
+
+		// A protected child is asked for just like a protected parent
+		if err := confirmProtected(ctx, tree); err != nil {
+			return protectedResult(err)
+		}
+
+		killed, err := killProcessTree(tree)
+		if err != nil {
+			utils.ShowErrorNotificationWithConfig(&notifCfg, "Kill Error",
+				fmt.Sprintf("Failed to kill process tree: %v", err))
+			return commands.CommandResult{Success: false}
+		}
+
+		utils.NotifyWithConfig(&notifCfg, "Process Tree Killed",
+			fmt.Sprintf("Killed %s (PID: %s) and %d children", selectedProc.Command, selectedProc.PID, killed))
+
+		return commands.CommandResult{Success: true}
+	}
+
+	if err := confirmProtected(ctx, []Process{*selectedProc}); err != nil {
+		return protectedResult(err)
+	}
+
 	if err := killProcess(selectedProc.PID); err != nil {
 		utils.ShowErrorNotificationWithConfig(&notifCfg, "Kill Error",
 			fmt.Sprintf("Failed to kill process:  %v", err))
@@ -144,9 +256,91 @@ func Run(ctx commands.LauncherContext) commands.CommandResult {
 	return commands.CommandResult{Success: true}
 }
 
-func executeDirectKill(target string, cfg *Config, notifCfg *config.NotificationConfig) commands.CommandResult {
+// multipleItem switches the process menu to picking several processes
+const multipleItem = "☰ Select Multiple"
+
+// selectMultiple lets several processes be picked from the list and kills
+// them together. Launchers without multi-select ask for one at a time.
+func selectMultiple(ctx commands.LauncherContext, processes []Process, cfg *Config, notifCfg *config.NotificationConfig) commands.CommandResult {
+	options := []string{"← Back"}
+	for _, proc := range processes {
+		options = append(options, proc.Display)
+	}
+
+	selections, err := ctx.ShowMulti(options, "Kill Processes")
+	if err != nil {
+		// ESC pressed - exit completely
+		return commands.CommandResult{Success: false}
+	}
+
+	var selectedProcs []Process
+	for _, proc := range processes {
+		if slices.Contains(selections, proc.Display) {
+			selectedProcs = append(selectedProcs, proc)
+		}
+	}
+
+	if len(selectedProcs) == 0 || slices.Contains(selections, "← Back") {
+		return commands.CommandResult{Success: false, Error: commands.ErrBack}
+	}
+
+	return killSelected(ctx, selectedProcs, cfg, notifCfg)
+}
+
+// killSelected kills several processes picked from the menu in one pass
+func killSelected(ctx commands.LauncherContext, procs []Process, cfg *Config, notifCfg *config.NotificationConfig) commands.CommandResult {
+	if cfg.ConfirmKill {
+		confirmOpts := commands.ConfirmOptions(ctx)
+		prompt := fmt.Sprintf("Kill %d processes? ", len(procs))
+		if len(procs) == 1 {
+			prompt = fmt.Sprintf("Kill process %s (PID: %s)? ", procs[0].Command, procs[0].PID)
+		}
+		confirm, err := ctx.Show(confirmOpts, prompt)
+		if err != nil {
+			// ESC pressed - exit completely
+			return commands.CommandResult{Success: false}
+		}
+
+		if confirm != "Yes" {
+			return commands.CommandResult{Success: false, Error: commands.ErrBack}
+		}
+	}
+
+	if err := confirmProtected(ctx, procs); err != nil {
+		return protectedResult(err)
+	}
+
+	var killed []string
+	for _, proc := range procs {
+		if err := killProcess(proc.PID); err != nil {
+			utils.ShowErrorNotificationWithConfig(notifCfg, "Kill Error",
+				fmt.Sprintf("Failed to kill %s (PID:  %s): %v", proc.Command, proc.PID, err))
+		} else {
+			killed = append(killed, fmt.Sprintf("%s (PID: %s)", proc.Command, proc.PID))
+		}
+	}
+
+	if len(killed) == 0 {
+		return commands.CommandResult{Success: false}
+	}
+
+	utils.NotifyWithConfig(notifCfg, "Processes Killed", strings.Join(killed, "\n"))
+	return commands.CommandResult{Success: true}
+}
+
+// executeDirectKill kills by PID or name without a menu; protected processes
+// still ask for their name through the launcher
+func executeDirectKill(ctx commands.LauncherContext, target string, cfg *Config, notifCfg *config.NotificationConfig) commands.CommandResult {
 	// Try to parse as PID (numeric)
 	if isPID(target) {
+		proc, err := processByPID(target, cfg)
+		if err != nil {
+			return commands.CommandResult{Success: false, Error: err}
+		}
+		if err := confirmProtected(ctx, []Process{proc}); err != nil {
+			return protectedResult(err)
+		}
+
 		if err := killProcess(target); err != nil {
 			return commands.CommandResult{
 				Success: false,
@@ -179,6 +373,10 @@ func executeDirectKill(target string, cfg *Config, notifCfg *config.Notification
 		}
 	}
 
+	if err := confirmProtected(ctx, matches); err != nil {
+		return protectedResult(err)
+	}
+
 	// Kill all matching processes
 	var killed []string
 	for _, proc := range matches {
@@ -201,6 +399,42 @@ func executeDirectKill(target string, cfg *Config, notifCfg *config.Notification
 	}
 }
 
+func executeDirectKillTree(ctx commands.LauncherContext, pid string, cfg *Config, notifCfg *config.NotificationConfig) commands.CommandResult {
+	if !isPID(pid) {
+		return commands.CommandResult{
+			Success: false,
+			Error:   fmt.Errorf("invalid PID: %s", pid),
+		}
+	}
+
+	proc, err := processByPID(pid, cfg)
+	if err != nil {
+		return commands.CommandResult{Success: false, Error: err}
+	}
+	tree, err := processTree(proc, cfg)
+	if err != nil {
+		return commands.CommandResult{
+			Success: false,
+			Error:   fmt.Errorf("failed to list children of %s: %w", pid, err),
+		}
+	}
+	if err := confirmProtected(ctx, tree); err != nil {
+		return protectedResult(err)
+	}
+
+	killed, err := killProcessTree(tree)
+	if err != nil {
+		return commands.CommandResult{
+			Success: false,
+			Error:   fmt.Errorf("failed to kill process tree %s: %w", pid, err),
+		}
+	}
+
+	utils.NotifyWithConfig(notifCfg, "Process Tree Killed",
+		fmt.Sprintf("Killed PID: %s and %d children", pid, killed))
+	return commands.CommandResult{Success: true}
+}
+
 func isPID(s string) bool {
 	for _, c := range s {
 		if c < '0' || c > '9' {
@@ -213,14 +447,17 @@ func isPID(s string) bool {
 func getProcesses(cfg *Config) ([]Process, error) {
 	var cmd *exec.Cmd
 
+	// rss is in KiB; both branches share the column layout parsed below
+	const columns = "pid,user,%cpu,%mem,rss,comm"
+
 	if cfg.ShowAllProcesses {
-		cmd = exec.Command("ps", "aux", "--sort=-%cpu")
+		cmd = exec.Command("ps", "-e", "-o", columns, "--sort=-%cpu")
 	} else {
 		currentUser, err := user.Current()
 		if err != nil {
 			return nil, fmt.Errorf("failed to get current user:    %w", err)
 		}
-		cmd = exec.Command("ps", "-u", currentUser.Username, "-o", "pid,user,%cpu,%mem,comm", "--sort=-%cpu")
+		cmd = exec.Command("ps", "-u", currentUser.Username, "-o", columns, "--sort=-%cpu")
 	}
 
 	output, err := cmd.Output()
@@ -233,6 +470,16 @@ func getProcesses(cfg *Config) ([]Process, error) {
 		return nil, fmt.Errorf("no processes found")
 	}
 
+	exclude, err := newProcessMatcher(cfg.ExcludeProcesses, "exclude_processes")
+	if err != nil {
+		return nil, err
+	}
+
+	protected, err := newProcessMatcher(cfg.ProtectedProcesses, "protected_processes")
+	if err != nil {
+		return nil, err
+	}
+
 	var processes []Process
 
 	for i, line := range lines {
@@ -241,7 +488,7 @@ func getProcesses(cfg *Config) ([]Process, error) {
 		}
 
 		fields := strings.Fields(line)
-		if len(fields) < 5 {
+		if len(fields) < 6 {
 			continue
 		}
 
@@ -249,9 +496,10 @@ func getProcesses(cfg *Config) ([]Process, error) {
 		userName := fields[1]
 		cpu := fields[2]
 		mem := fields[3]
-		command := strings.Join(fields[4:], " ")
+		rssKiB, _ := strconv.ParseUint(fields[4], 10, 64)
+		command := strings.Join(fields[5:], " ")
 
-		if shouldExclude(command, cfg.ExcludeProcesses) {
+		if exclude.matches(command) {
 			continue
 		}
 
@@ -260,9 +508,12 @@ func getProcesses(cfg *Config) ([]Process, error) {
 			User:    userName,
 			CPU:     cpu,
 			MEM:     mem,
+			RSS:     rssKiB * 1024,
 			Command: command,
-			Display: fmt.Sprintf("PID:    %-7s | CPU: %-5s%% | MEM: %-5s%% | %s", pid, cpu, mem, command),
+
+			Protected: protected.matches(command),
 		}
+		proc.Display = processDisplay(proc, cfg.ShowAbsoluteMem)
 
 		processes = append(processes, proc)
 	}
@@ -270,14 +521,18 @@ func getProcesses(cfg *Config) ([]Process, error) {
 	return processes, nil
 }
 
-func shouldExclude(command string, excludeList []string) bool {
-	commandLower := strings.ToLower(command)
-	for _, exclude := range excludeList {
-		if strings.Contains(commandLower, strings.ToLower(exclude)) {
-			return true
-		}
+// processDisplay formats a menu entry; with showAbsoluteMem the resident
+// memory replaces %MEM, which says little without knowing the total RAM
+func processDisplay(proc Process, showAbsoluteMem bool) string {
+	command := proc.Command
+	if proc.Protected {
+		command += protectedMark
+	}
+
+	if showAbsoluteMem {
+		return fmt.Sprintf("PID:    %-7s | CPU: %-5s%% | RES: %-9s | %s", proc.PID, proc.CPU, utils.FormatBytes(proc.RSS), command)
 	}
-	return false
+	return fmt.Sprintf("PID:    %-7s | CPU: %-5s%% | MEM: %-5s%% | %s", proc.PID, proc.CPU, proc.MEM, command)
 }
 
 func killProcess(pid string) error {
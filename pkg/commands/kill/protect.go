@@ -0,0 +1,72 @@
+package kill
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/lvim-tech/ql/pkg/commands"
+)
+
+// protectedMark follows protected processes in the menu
+const protectedMark = "  [protected]"
+
+// confirmProtected asks for the name of each protected process in procs to
+// be typed before it is killed. Unlike confirm_kill this cannot be turned
+// off; the processes that need it are chosen by protected_processes.
+// Returns ErrCancelled on ESC and an error naming the process when the
+// typed name does not match.
+func confirmProtected(ctx commands.LauncherContext, procs []Process) error {
+	asked := make(map[string]bool)
+
+	for _, proc := range procs {
+		if !proc.Protected || asked[proc.Command] {
+			continue
+		}
+		asked[proc.Command] = true
+
+		typed, err := ctx.ShowAllowCustom([]string{"← Back"},
+			fmt.Sprintf("%s is protected, type its name to kill it", proc.Command))
+		if err != nil {
+			return commands.ErrCancelled
+		}
+		if typed == "← Back" {
+			return commands.ErrBack
+		}
+		if strings.TrimSpace(typed) != proc.Command {
+			return fmt.Errorf("%s was not killed: the typed name did not match", proc.Command)
+		}
+	}
+
+	return nil
+}
+
+// protectedResult turns a failed confirmProtected into the module result
+func protectedResult(err error) commands.CommandResult {
+	if errors.Is(err, commands.ErrCancelled) {
+		return commands.CommandResult{Success: false}
+	}
+	return commands.CommandResult{Success: false, Error: err}
+}
+
+// processByPID describes a PID given on the command line, which is not
+// looked up in the ps listing, so protected_processes applies to it too
+func processByPID(pid string, cfg *Config) (Process, error) {
+	proc := Process{PID: pid}
+
+	comm, err := os.ReadFile("/proc/" + pid + "/comm")
+	if err != nil {
+		// Gone already, or not Linux; kill reports the real error
+		return proc, nil
+	}
+	proc.Command = strings.TrimSpace(string(comm))
+
+	protected, err := newProcessMatcher(cfg.ProtectedProcesses, "protected_processes")
+	if err != nil {
+		return proc, err
+	}
+	proc.Protected = protected.matches(proc.Command)
+
+	return proc, nil
+}
@@ -0,0 +1,156 @@
+package kill
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// getChildrenMap reads /proc/*/stat and maps every parent PID to its direct children
+func getChildrenMap() (map[int][]int, error) {
+	entries, err := os.ReadDir("/proc")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read /proc: %w", err)
+	}
+
+	children := make(map[int][]int)
+
+	for _, entry := range entries {
+		pid, err := strconv.Atoi(entry.Name())
+		if err != nil {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join("/proc", entry.Name(), "stat"))
+		if err != nil {
+			// Process exited while walking /proc
+			continue
+		}
+
+		ppid, ok := parseStatPPID(string(data))
+		if !ok {
+			continue
+		}
+
+		children[ppid] = append(children[ppid], pid)
+	}
+
+	return children, nil
+}
+
+// parseStatPPID extracts the parent PID from the content of /proc/<pid>/stat.
+// The command name is wrapped in parentheses and may itself contain spaces
+// or parentheses, so fields are read after the last closing parenthesis.
+func parseStatPPID(stat string) (int, bool) {
+	end := strings.LastIndex(stat, ")")
+	if end == -1 {
+		return 0, false
+	}
+
+	// Fields after comm: state ppid ...
+	fields := strings.Fields(stat[end+1:])
+	if len(fields) < 2 {
+		return 0, false
+	}
+
+	ppid, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return 0, false
+	}
+
+	return ppid, true
+}
+
+// getDescendants returns all descendants of pid ordered so that children
+// always come before their parents (deepest first)
+func getDescendants(pid int) ([]int, error) {
+	children, err := getChildrenMap()
+	if err != nil {
+		return nil, err
+	}
+
+	var result []int
+	visited := map[int]bool{pid: true}
+
+	var walk func(parent int)
+	walk = func(parent int) {
+		for _, child := range children[parent] {
+			if visited[child] {
+				continue
+			}
+			visited[child] = true
+			walk(child)
+			result = append(result, child)
+		}
+	}
+	walk(pid)
+
+	return result, nil
+}
+
+// processTree returns the descendants of root, deepest first, followed by
+// root itself: the order they are killed in. Each is described with
+// processByPID so protected_processes applies to the children as well.
+func processTree(root Process, cfg *Config) ([]Process, error) {
+	pidNum, err := strconv.Atoi(root.PID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid PID: %s", root.PID)
+	}
+
+	descendants, err := getDescendants(pidNum)
+	if err != nil {
+		return nil, err
+	}
+
+	tree := make([]Process, 0, len(descendants)+1)
+	for _, child := range descendants {
+		proc, err := processByPID(strconv.Itoa(child), cfg)
+		if err != nil {
+			return nil, err
+		}
+		tree = append(tree, proc)
+	}
+
+	return append(tree, root), nil
+}
+
+// killProcessTree kills the processes of a processTree in order, so the
+// root goes last. Only the processes confirmed beforehand are killed, not
+// children started since. Returns the number of descendants that were killed.
+func killProcessTree(tree []Process) (int, error) {
+	if len(tree) == 0 {
+		return 0, nil
+	}
+	root := tree[len(tree)-1]
+
+	killed := 0
+	for _, child := range tree[:len(tree)-1] {
+		// Children may already be gone after their own parent died
+		if err := killProcess(child.PID); err == nil {
+			killed++
+		}
+	}
+
+	if err := killProcess(root.PID); err != nil {
+		return killed, err
+	}
+
+	return killed, nil
+}
+
+// countDescendants returns the number of descendants of pid, or 0 on error
+func countDescendants(pid string) int {
+	pidNum, err := strconv.Atoi(pid)
+	if err != nil {
+		return 0
+	}
+
+	descendants, err := getDescendants(pidNum)
+	if err != nil {
+		return 0
+	}
+
+	return len(descendants)
+}
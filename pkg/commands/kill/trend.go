@@ -0,0 +1,102 @@
+package kill
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// defaultTrendInterval is the gap between the two memory samples of --trend
+const defaultTrendInterval = 2 * time.Second
+
+// parseTrendInterval reads the optional seconds argument of 'ql kill --trend [seconds]'
+func parseTrendInterval(args []string) (time.Duration, error) {
+	if len(args) == 0 {
+		return defaultTrendInterval, nil
+	}
+
+	seconds, err := strconv.Atoi(args[0])
+	if err != nil || seconds <= 0 {
+		return 0, fmt.Errorf("invalid trend interval: %s (use: ql kill --trend [seconds])", args[0])
+	}
+
+	return time.Duration(seconds) * time.Second, nil
+}
+
+// readRSS returns a process's resident memory in bytes from /proc/<pid>/statm,
+// whose second field is the resident set in pages
+func readRSS(pid string) (int64, bool) {
+	data, err := os.ReadFile(filepath.Join("/proc", pid, "statm"))
+	if err != nil {
+		return 0, false
+	}
+
+	fields := strings.Fields(string(data))
+	if len(fields) < 2 {
+		return 0, false
+	}
+
+	pages, err := strconv.ParseInt(fields[1], 10, 64)
+	if err != nil {
+		return 0, false
+	}
+
+	return pages * int64(os.Getpagesize()), true
+}
+
+// sampleRSS reads the resident memory of every process, keyed by PID
+func sampleRSS(procs []Process) map[string]int64 {
+	samples := make(map[string]int64, len(procs))
+	for _, proc := range procs {
+		if rss, ok := readRSS(proc.PID); ok {
+			samples[proc.PID] = rss
+		}
+	}
+	return samples
+}
+
+// annotateTrend samples memory twice, interval apart, and marks each
+// process's menu entry with ▲ (grew), ▼ (shrank) or = and the change.
+// Processes that exited in between are dropped.
+func annotateTrend(procs []Process, interval time.Duration) []Process {
+	before := sampleRSS(procs)
+	time.Sleep(interval)
+	after := sampleRSS(procs)
+
+	var result []Process
+	for _, proc := range procs {
+		start, ok1 := before[proc.PID]
+		end, ok2 := after[proc.PID]
+		if !ok1 || !ok2 {
+			continue
+		}
+
+		proc.Display = fmt.Sprintf("%s | %s", trendIndicator(end-start), proc.Display)
+		result = append(result, proc)
+	}
+
+	return result
+}
+
+// trendIndicator formats a memory change, e.g. "▲ +1.5M"
+func trendIndicator(delta int64) string {
+	switch {
+	case delta > 0:
+		return "▲ +" + formatRSSDelta(delta)
+	case delta < 0:
+		return "▼ -" + formatRSSDelta(-delta)
+	default:
+		return "=       "
+	}
+}
+
+// formatRSSDelta formats a byte count in K or M, padded to a fixed width
+func formatRSSDelta(bytes int64) string {
+	if bytes >= 1024*1024 {
+		return fmt.Sprintf("%-5s", fmt.Sprintf("%.1fM", float64(bytes)/(1024*1024)))
+	}
+	return fmt.Sprintf("%-5s", fmt.Sprintf("%dK", bytes/1024))
+}
@@ -0,0 +1,254 @@
+package kill
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/lvim-tech/ql/pkg/commands"
+	"github.com/lvim-tech/ql/pkg/config"
+	"github.com/lvim-tech/ql/pkg/utils"
+)
+
+// unitsItem opens the systemd user unit list from the process menu
+const unitsItem = "⚙ Systemd Units"
+
+// systemctlTimeout bounds systemctl calls; stopping a unit may wait for its
+// processes to exit
+const systemctlTimeout = 30 * time.Second
+
+// Unit is a running systemd user service or scope
+type Unit struct {
+	Name        string
+	Description string
+	Display     string
+}
+
+// getUserUnits lists running user services and scopes
+func getUserUnits() ([]Unit, error) {
+	output, err := utils.RunCommandTimeout(systemctlTimeout, "systemctl", "--user", "list-units",
+		"--type=service,scope", "--state=running", "--no-legend", "--plain", "--no-pager")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list units: %w", err)
+	}
+
+	return parseUnits(output), nil
+}
+
+// managerScope holds the user systemd instance itself and is never offered
+const managerScope = "init.scope"
+
+// parseUnits parses 'systemctl list-units --no-legend --plain' output:
+// UNIT LOAD ACTIVE SUB DESCRIPTION...
+func parseUnits(output string) []Unit {
+	var units []Unit
+
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 4 || fields[0] == managerScope {
+			continue
+		}
+
+		unit := Unit{
+			Name:        fields[0],
+			Description: strings.Join(fields[4:], " "),
+		}
+		unit.Display = unit.Name
+		if unit.Description != "" {
+			unit.Display = fmt.Sprintf("%-50s %s", unit.Name, unit.Description)
+		}
+
+		units = append(units, unit)
+	}
+
+	return units
+}
+
+// unitForPID returns the user unit (service or scope) the process runs in,
+// or "" when it is not managed by the user's systemd instance
+func unitForPID(pid string) string {
+	data, err := os.ReadFile(filepath.Join("/proc", pid, "cgroup"))
+	if err != nil {
+		return ""
+	}
+
+	return parseCgroupUnit(string(data))
+}
+
+// parseCgroupUnit extracts the innermost user unit from /proc/<pid>/cgroup,
+// e.g. "0::/user.slice/user-1000.slice/user@1000.service/app.slice/app-firefox-1234.scope".
+// The user manager itself (init.scope) and units outside it (session scopes)
+// are never returned, since stopping them would end the whole session.
+func parseCgroupUnit(cgroup string) string {
+	for _, line := range strings.Split(cgroup, "\n") {
+		// cgroup v2 unified hierarchy: "0::<path>"; v1 has a name=systemd line
+		_, path, found := strings.Cut(line, "::")
+		if !found {
+			idx := strings.Index(line, ":name=systemd:")
+			if idx == -1 {
+				continue
+			}
+			path = line[idx+len(":name=systemd:"):]
+		}
+
+		parts := strings.Split(strings.Trim(path, "/"), "/")
+
+		manager := slices.IndexFunc(parts, func(part string) bool {
+			return strings.HasPrefix(part, "user@") && strings.HasSuffix(part, ".service")
+		})
+		if manager == -1 {
+			continue
+		}
+
+		for i := len(parts) - 1; i > manager; i-- {
+			if parts[i] == managerScope {
+				break
+			}
+			if strings.HasSuffix(parts[i], ".service") || strings.HasSuffix(parts[i], ".scope") {
+				return parts[i]
+			}
+		}
+	}
+
+	return ""
+}
+
+// unitProcesses returns the processes running in a user unit, described
+// with processByPID so protected_processes applies to them
+func unitProcesses(name string, cfg *Config) ([]Process, error) {
+	entries, err := os.ReadDir("/proc")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list processes: %w", err)
+	}
+
+	var procs []Process
+	for _, entry := range entries {
+		if !isPID(entry.Name()) || unitForPID(entry.Name()) != name {
+			continue
+		}
+
+		proc, err := processByPID(entry.Name(), cfg)
+		if err != nil {
+			return nil, err
+		}
+		procs = append(procs, proc)
+	}
+
+	return procs, nil
+}
+
+// confirmUnitProtected runs confirmProtected on the unit's processes, since
+// stopping the unit kills every one of them
+func confirmUnitProtected(ctx commands.LauncherContext, name string, cfg *Config) error {
+	procs, err := unitProcesses(name, cfg)
+	if err != nil {
+		return err
+	}
+	return confirmProtected(ctx, procs)
+}
+
+// stopUnit stops a systemd user unit, which terminates all its processes
+func stopUnit(name string) error {
+	if _, err := utils.RunCommandTimeout(systemctlTimeout, "systemctl", "--user", "stop", name); err != nil {
+		return fmt.Errorf("failed to stop %s: %w", name, err)
+	}
+	return nil
+}
+
+// confirmStopUnit asks for confirmation when confirm_kill is set.
+// Returns false when the user backed out.
+func confirmStopUnit(ctx commands.LauncherContext, name string, cfg *Config) (bool, error) {
+	if !cfg.ConfirmKill {
+		return true, nil
+	}
+
+	confirm, err := ctx.Show(commands.ConfirmOptions(ctx), fmt.Sprintf("Stop unit %s? ", name))
+	if err != nil {
+		return false, err
+	}
+
+	return confirm == "Yes", nil
+}
+
+// selectUnit lets the user pick a running user unit and stops it
+func selectUnit(ctx commands.LauncherContext, cfg *Config, notifCfg *config.NotificationConfig) commands.CommandResult {
+	units, err := getUserUnits()
+	if err != nil {
+		utils.ShowErrorNotificationWithConfig(notifCfg, "Kill Error", err.Error())
+		return commands.CommandResult{Success: false}
+	}
+
+	if len(units) == 0 {
+		utils.ShowErrorNotificationWithConfig(notifCfg, "Kill Error", "No running user units found")
+		return commands.CommandResult{Success: false}
+	}
+
+	options := []string{"← Back"}
+	for _, unit := range units {
+		options = append(options, unit.Display)
+	}
+
+	selected, err := ctx.Show(options, "Stop Unit")
+	if err != nil {
+		// ESC pressed - exit completely
+		return commands.CommandResult{Success: false}
+	}
+
+	if selected == "← Back" {
+		return commands.CommandResult{Success: false, Error: commands.ErrBack}
+	}
+
+	var name string
+	for _, unit := range units {
+		if unit.Display == selected {
+			name = unit.Name
+			break
+		}
+	}
+
+	if name == "" {
+		return commands.CommandResult{Success: false, Error: commands.ErrBack}
+	}
+
+	return stopUnitWithConfirm(ctx, name, cfg, notifCfg)
+}
+
+func stopUnitWithConfirm(ctx commands.LauncherContext, name string, cfg *Config, notifCfg *config.NotificationConfig) commands.CommandResult {
+	ok, err := confirmStopUnit(ctx, name, cfg)
+	if err != nil {
+		// ESC pressed - exit completely
+		return commands.CommandResult{Success: false}
+	}
+	if !ok {
+		return commands.CommandResult{Success: false, Error: commands.ErrBack}
+	}
+
+	if err := confirmUnitProtected(ctx, name, cfg); err != nil {
+		return protectedResult(err)
+	}
+
+	if err := stopUnit(name); err != nil {
+		utils.ShowErrorNotificationWithConfig(notifCfg, "Kill Error", err.Error())
+		return commands.CommandResult{Success: false}
+	}
+
+	utils.NotifyWithConfig(notifCfg, "Unit Stopped", fmt.Sprintf("Stopped %s", name))
+
+	return commands.CommandResult{Success: true}
+}
+
+func executeDirectStopUnit(ctx commands.LauncherContext, name string, cfg *Config, notifCfg *config.NotificationConfig) commands.CommandResult {
+	if err := confirmUnitProtected(ctx, name, cfg); err != nil {
+		return protectedResult(err)
+	}
+
+	if err := stopUnit(name); err != nil {
+		return commands.CommandResult{Success: false, Error: err}
+	}
+
+	utils.NotifyWithConfig(notifCfg, "Unit Stopped", fmt.Sprintf("Stopped %s", name))
+	return commands.CommandResult{Success: true}
+}
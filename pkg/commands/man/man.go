@@ -18,6 +18,8 @@ func init() {
 	commands.Register(commands.Command{
 		Name:        "man",
 		Description: "Manual pages",
+		Requires:    []string{"man"},
+		Config:      commands.ConfigOf(DefaultConfig),
 		Run:         Run,
 	})
 }
@@ -45,13 +47,6 @@ func Run(ctx commands.LauncherContext) commands.CommandResult {
 		}
 	}
 
-	if !utils.CommandExists("man") {
-		notifCfg := ctx.Config().GetNotificationConfig()
-		utils.ShowErrorNotificationWithConfig(&notifCfg, "Man Error",
-			"man command not found")
-		return commands.CommandResult{Success: false}
-	}
-
 	notifCfg := ctx.Config().GetNotificationConfig()
 
 	// Check for direct command (man page name)
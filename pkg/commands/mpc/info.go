@@ -0,0 +1,190 @@
+package mpc
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/lvim-tech/ql/pkg/config"
+	"github.com/lvim-tech/ql/pkg/utils"
+)
+
+// progressBarWidth is the number of cells in the track info progress bar
+const progressBarWidth = 24
+
+// TrackInfo describes the current track in more detail than PlayerStatus
+type TrackInfo struct {
+	PlayerStatus
+	Track   string `json:"track,omitempty"`
+	Date    string `json:"date,omitempty"`
+	Genre   string `json:"genre,omitempty"`
+	Format  string `json:"format,omitempty"`
+	Audio   string `json:"audio,omitempty"`
+	Bitrate int    `json:"bitrate,omitempty"`
+}
+
+// getTrackInfo combines player status with the extra tags of the current song.
+// Bitrate and audio format are only reported by MPD itself, so they stay
+// empty when falling back to mpc.
+func getTrackInfo() (*TrackInfo, error) {
+	status, err := getPlayerStatus()
+	if err != nil {
+		return nil, err
+	}
+
+	info := &TrackInfo{PlayerStatus: *status}
+	if status.State == "stopped" {
+		return info, nil
+	}
+
+	info.Format = strings.ToUpper(strings.TrimPrefix(filepath.Ext(status.File), "."))
+
+	if mpd != nil {
+		song, err := mpd.pairs("currentsong")
+		if err != nil {
+			return nil, fmt.Errorf("failed to get current song: %w", err)
+		}
+		info.Track = song["Track"]
+		info.Date = song["Date"]
+		info.Genre = song["Genre"]
+
+		pairs, err := mpd.pairs("status")
+		if err != nil {
+			return nil, fmt.Errorf("failed to get status: %w", err)
+		}
+		info.Audio = pairs["audio"]
+		info.Bitrate, _ = strconv.Atoi(pairs["bitrate"])
+
+		return info, nil
+	}
+
+	output, err := runMpcCommand("current", "-f", "%track%\t%date%\t%genre%").Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current song: %w", err)
+	}
+
+	fields := strings.Split(strings.TrimRight(string(output), "\n"), "\t")
+	for len(fields) < 3 {
+		fields = append(fields, "")
+	}
+	info.Track = fields[0]
+	info.Date = fields[1]
+	info.Genre = fields[2]
+
+	return info, nil
+}
+
+// formatTrackInfo renders the track details followed by a progress bar
+func formatTrackInfo(info *TrackInfo) string {
+	if info.State == "stopped" {
+		return "Nothing playing\n"
+	}
+
+	var output strings.Builder
+
+	song := Song{Artist: info.Artist, Title: info.Title, File: info.File}
+	fmt.Fprintf(&output, "%s\n", song.String())
+
+	if info.Album != "" {
+		album := info.Album
+		if info.Date != "" {
+			album = fmt.Sprintf("%s (%s)", album, info.Date)
+		}
+		fmt.Fprintf(&output, "Album:  %s\n", album)
+	}
+	if info.Track != "" {
+		fmt.Fprintf(&output, "Track:  %s\n", info.Track)
+	}
+	if info.Genre != "" {
+		fmt.Fprintf(&output, "Genre:  %s\n", info.Genre)
+	}
+
+	var format []string
+	if info.Format != "" {
+		format = append(format, info.Format)
+	}
+	if info.Bitrate > 0 {
+		format = append(format, fmt.Sprintf("%d kbps", info.Bitrate))
+	}
+	if info.Audio != "" {
+		format = append(format, formatAudio(info.Audio))
+	}
+	if len(format) > 0 {
+		fmt.Fprintf(&output, "Format: %s\n", strings.Join(format, ", "))
+	}
+
+	fmt.Fprintf(&output, "\n%s %s / %s", progressBar(info.Elapsed, info.Duration, progressBarWidth),
+		formatClock(info.Elapsed), formatClock(info.Duration))
+	if info.State == "paused" {
+		output.WriteString(" (paused)")
+	}
+	output.WriteString("\n")
+
+	return output.String()
+}
+
+// formatAudio turns MPD's "samplerate:bits:channels" into "44.1 kHz, 16 bit, stereo"
+func formatAudio(audio string) string {
+	parts := strings.Split(audio, ":")
+	if len(parts) != 3 {
+		return audio
+	}
+
+	var result []string
+
+	if rate, err := strconv.ParseFloat(parts[0], 64); err == nil {
+		result = append(result, strconv.FormatFloat(rate/1000, 'f', -1, 64)+" kHz")
+	}
+	if parts[1] == "f" {
+		result = append(result, "float")
+	} else if parts[1] != "*" {
+		result = append(result, parts[1]+" bit")
+	}
+	switch parts[2] {
+	case "1":
+		result = append(result, "mono")
+	case "2":
+		result = append(result, "stereo")
+	case "*":
+	default:
+		result = append(result, parts[2]+" ch")
+	}
+
+	return strings.Join(result, ", ")
+}
+
+// progressBar draws elapsed/total as a bar of width cells.
+// Streams without a known duration get an empty bar.
+func progressBar(elapsed, total, width int) string {
+	filled := 0
+	if total > 0 {
+		filled = min(max(elapsed*width/total, 0), width)
+	}
+	return strings.Repeat("█", filled) + strings.Repeat("░", width-filled)
+}
+
+// formatClock formats seconds as m:ss, or h:mm:ss for long tracks
+func formatClock(seconds int) string {
+	if seconds >= 3600 {
+		return fmt.Sprintf("%d:%02d:%02d", seconds/3600, seconds%3600/60, seconds%60)
+	}
+	return fmt.Sprintf("%d:%02d", seconds/60, seconds%60)
+}
+
+func showTrackInfo(notifCfg *config.NotificationConfig) error {
+	info, err := getTrackInfo()
+	if err != nil {
+		return err
+	}
+
+	output := formatTrackInfo(info)
+
+	if utils.IsTerminal() {
+		fmt.Print(output)
+	} else {
+		utils.NotifyWithConfig(notifCfg, "Track Info", output)
+	}
+
+	return nil
+}
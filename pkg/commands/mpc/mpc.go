@@ -7,24 +7,66 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/lvim-tech/ql/pkg/commands"
 	"github.com/lvim-tech/ql/pkg/config"
 	"github.com/lvim-tech/ql/pkg/utils"
-	"github.com/mitchellh/mapstructure"
 )
 
 var mpcPath string
 
+// moduleCfg is the decoded config, set once by initModule
+var moduleCfg Config
+
 func init() {
 	commands.Register(commands.Command{
 		Name:        "mpc",
 		Description: "MPD client",
+		Requires:    []string{"mpc"},
+		Subcommands: directCommands.Info(),
+		Check:       check,
+		Init:        initModule,
+		Config:      commands.ConfigOf(DefaultConfig),
 		Run:         Run,
 	})
 }
 
+// check verifies that MPD answers on the configured connection
+func check(qlCfg *config.Config) error {
+	cfg := DefaultConfig()
+	if err := commands.DecodeConfig(qlCfg.GetMpcConfig(), &cfg); err != nil {
+		return err
+	}
+
+	conn, err := dialMpd(&cfg)
+	if err != nil {
+		return fmt.Errorf("cannot connect to MPD: %w", err)
+	}
+	conn.Close()
+
+	return nil
+}
+
+// initModule decodes the config and points mpc at the configured server
+// through MPD_HOST and MPD_PORT, once per process. Each Run still opens its
+// own protocol connection, since one held across menus could go stale.
+func initModule(qlCfg *config.Config) error {
+	cfg := DefaultConfig()
+	if err := commands.DecodeConfig(qlCfg.GetMpcConfig(), &cfg); err != nil {
+		return err
+	}
+
+	if err := setupMpdConnection(&cfg); err != nil {
+		return fmt.Errorf("MPD setup failed: %w", err)
+	}
+
+	mpcPath, _ = exec.LookPath("mpc")
+	moduleCfg = cfg
+	return nil
+}
+
 func runMpcCommand(args ...string) *exec.Cmd {
 	cmd := exec.Command(mpcPath, args...)
 	cmd.Env = os.Environ()
@@ -32,20 +74,8 @@ func runMpcCommand(args ...string) *exec.Cmd {
 }
 
 func Run(ctx commands.LauncherContext) commands.CommandResult {
-	cfgInterface := ctx.Config().GetMpcConfig()
-
-	var cfg Config
-	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
-		WeaklyTypedInput: true,
-		Result:           &cfg,
-	})
-	if err != nil {
-		cfg = DefaultConfig()
-	} else {
-		if decodeErr := decoder.Decode(cfgInterface); decodeErr != nil {
-			cfg = DefaultConfig()
-		}
-	}
+	// Decoded and set up by initModule
+	cfg := moduleCfg
 
 	if !cfg.Enabled {
 		return commands.CommandResult{
@@ -54,29 +84,19 @@ func Run(ctx commands.LauncherContext) commands.CommandResult {
 		}
 	}
 
-	if !utils.CommandExists("mpc") {
-		return commands.CommandResult{
-			Success: false,
-			Error:   fmt.Errorf("mpc is not installed or not in PATH"),
-		}
-	}
-
-	mpcPath, _ = exec.LookPath("mpc")
-
 	notifCfg := ctx.Config().GetNotificationConfig()
 
-	if err := setupMpdConnection(&cfg); err != nil {
-		utils.ShowErrorNotificationWithConfig(&notifCfg, "MPC Setup Error",
-			fmt.Sprintf("MPD setup failed: %v", err))
-		return commands.CommandResult{
-			Success: false,
-			Error:   commands.ErrBack,
-		}
-	}
+	var err error
 
-	testCmd := runMpcCommand("status")
-	output, err := testCmd.CombinedOutput()
-	if err != nil {
+	// Hot-path actions talk to MPD directly over one connection; if it can't
+	// be opened, everything goes through mpc as before
+	mpd, err = dialMpd(&cfg)
+	if err == nil {
+		defer func() {
+			mpd.Close()
+			mpd = nil
+		}()
+	} else if output, err := runMpcCommand("status").CombinedOutput(); err != nil {
 		errMsg := strings.TrimSpace(string(output))
 		if errMsg == "" {
 			errMsg = err.Error()
@@ -85,7 +105,7 @@ func Run(ctx commands.LauncherContext) commands.CommandResult {
 			fmt.Sprintf("MPD connection failed: %s\n\nConnection:     %s\nMPD_HOST: %s",
 				errMsg,
 				cfg.ConnectionType,
-				os.Getenv("MPD_HOST")))
+				redactMpdHost(os.Getenv("MPD_HOST"))))
 		return commands.CommandResult{
 			Success: false,
 			Error:   commands.ErrBack,
@@ -111,8 +131,14 @@ func Run(ctx commands.LauncherContext) commands.CommandResult {
 			"Previous",
 			"Stop",
 			"Select Playlist",
+			"Manage Playlists",
 			"Select Song",
+			"Go to Position",
+			"Add Current to Playlist",
 			"Show Current",
+			"Track Info",
+			"Outputs",
+			"Crossfade",
 		)
 
 		choice, err := ctx.Show(options, "MPC")
@@ -140,10 +166,22 @@ func Run(ctx commands.LauncherContext) commands.CommandResult {
 			actionErr = stop(&notifCfg)
 		case "Select Playlist":
 			actionErr = selectPlaylist(ctx, &cfg, &notifCfg)
+		case "Manage Playlists":
+			actionErr = managePlaylists(ctx, &cfg, &notifCfg)
 		case "Select Song":
 			actionErr = selectSong(ctx, &notifCfg)
+		case "Go to Position":
+			actionErr = gotoPosition(ctx, "", &notifCfg)
+		case "Add Current to Playlist":
+			actionErr = addCurrentToPlaylist(ctx, "", &notifCfg)
 		case "Show Current":
 			actionErr = showCurrent(&notifCfg)
+		case "Track Info":
+			actionErr = showTrackInfo(&notifCfg)
+		case "Outputs":
+			actionErr = selectOutput(ctx, &notifCfg)
+		case "Crossfade":
+			actionErr = selectCrossfade(ctx, &notifCfg)
 		default:
 			utils.ShowErrorNotificationWithConfig(&notifCfg, "MPC Error", fmt.Sprintf("Unknown choice: %s", choice))
 			continue
@@ -154,6 +192,9 @@ func Run(ctx commands.LauncherContext) commands.CommandResult {
 			if actionErr.Error() == "cancelled" {
 				return commands.CommandResult{Success: false}
 			}
+			if actionErr.Error() == "back" {
+				continue
+			}
 			// Other error - show and loop back
 			utils.ShowErrorNotificationWithConfig(&notifCfg, "MPC Error", actionErr.Error())
 			continue
@@ -164,52 +205,177 @@ func Run(ctx commands.LauncherContext) commands.CommandResult {
 	}
 }
 
-func executeDirectCommand(ctx commands.LauncherContext, args []string, cfg *Config, notifCfg *config.NotificationConfig) commands.CommandResult {
-	action := strings.ToLower(args[0])
-
-	var err error
-
-	switch action {
-	case "toggle", "play", "pause":
-		err = togglePlayPause(notifCfg)
-
-	case "next":
-		err = next(notifCfg)
-
-	case "prev", "previous":
-		err = previous(notifCfg)
-
-	case "stop":
-		err = stop(notifCfg)
-
-	case "current", "status":
-		err = showCurrent(notifCfg)
-
-	case "playlist":
-		// If playlist name is provided, load it directly
-		if len(args) > 1 {
-			playlistName := strings.Join(args[1:], " ")
-			err = loadPlaylistDirect(playlistName, cfg, notifCfg)
-		} else {
-			// Otherwise show playlist selection menu
-			err = selectPlaylist(ctx, cfg, notifCfg)
-		}
-
-	case "song":
-		err = selectSong(ctx, notifCfg)
-
-	default:
-		return commands.CommandResult{
-			Success: false,
-			Error:   fmt.Errorf("unknown mpc action: %s (use:  toggle, next, prev, stop, current, playlist, song)", action),
-		}
-	}
+// directEnv is the state shared by the direct subcommands
+type directEnv struct {
+	ctx      commands.LauncherContext
+	cfg      *Config
+	notifCfg *config.NotificationConfig
+}
 
-	if err != nil {
-		return commands.CommandResult{Success: false, Error: err}
-	}
+// directCommands are the actions available as 'ql mpc <action>'
+var directCommands = commands.SubcommandTable[*directEnv]{
+	{
+		Name:    "toggle",
+		Aliases: []string{"play", "pause"},
+		Run: func(e *directEnv, args []string) commands.CommandResult {
+			return commands.ResultOf(togglePlayPause(e.notifCfg))
+		},
+	},
+	{
+		Name: "next",
+		Run: func(e *directEnv, args []string) commands.CommandResult {
+			return commands.ResultOf(next(e.notifCfg))
+		},
+	},
+	{
+		Name:    "prev",
+		Aliases: []string{"previous"},
+		Run: func(e *directEnv, args []string) commands.CommandResult {
+			return commands.ResultOf(previous(e.notifCfg))
+		},
+	},
+	{
+		Name: "stop",
+		Run: func(e *directEnv, args []string) commands.CommandResult {
+			return commands.ResultOf(stop(e.notifCfg))
+		},
+	},
+	{
+		Name:  "status",
+		Usage: "[--format <fmt>]",
+		Run: func(e *directEnv, args []string) commands.CommandResult {
+			// Status bar output: a single stdout line, never a notification
+			if e.ctx.IsJSONOutput() {
+				status, err := getPlayerStatus()
+				if err != nil {
+					return commands.CommandResult{Success: false, Error: err}
+				}
+				return commands.CommandResult{Success: true, Message: status.State, Data: status}
+			}
+			format, err := parseStatusArgs(args)
+			if err != nil {
+				return commands.CommandResult{Success: false, Error: err}
+			}
+			line, err := formatStatusLine(format)
+			if err != nil {
+				return commands.CommandResult{Success: false, Error: err}
+			}
+			fmt.Println(line)
+			return commands.CommandResult{Success: true}
+		},
+	},
+	{
+		Name: "current",
+		Run: func(e *directEnv, args []string) commands.CommandResult {
+			if e.ctx.IsJSONOutput() {
+				song, err := getCurrentSong()
+				if err != nil {
+					return commands.CommandResult{Success: false, Error: err}
+				}
+				return commands.CommandResult{Success: true, Message: song.String(), Data: song}
+			}
+			return commands.ResultOf(showCurrent(e.notifCfg))
+		},
+	},
+	{
+		Name: "info",
+		Run: func(e *directEnv, args []string) commands.CommandResult {
+			if e.ctx.IsJSONOutput() {
+				info, err := getTrackInfo()
+				if err != nil {
+					return commands.CommandResult{Success: false, Error: err}
+				}
+				return commands.CommandResult{Success: true, Message: info.State, Data: info}
+			}
+			return commands.ResultOf(showTrackInfo(e.notifCfg))
+		},
+	},
+	{
+		Name:  "playlist",
+		Usage: "[name]",
+		Run: func(e *directEnv, args []string) commands.CommandResult {
+			// Without a name show the playlist selection menu. Every name
+			// loads, so a playlist may be called anything; deleting and
+			// renaming are the separate playlist-rm and playlist-mv.
+			if len(args) == 0 {
+				return commands.ResultOf(selectPlaylist(e.ctx, e.cfg, e.notifCfg))
+			}
+			return commands.ResultOf(loadPlaylistDirect(strings.Join(args, " "), e.cfg, e.notifCfg))
+		},
+	},
+	{
+		Name:  "playlist-rm",
+		Usage: "<name>",
+		Run: func(e *directEnv, args []string) commands.CommandResult {
+			if len(args) == 0 {
+				return commands.CommandResult{Success: false, Error: fmt.Errorf("usage: ql mpc playlist-rm <name>")}
+			}
+			return commands.ResultOf(deletePlaylist(strings.Join(args, " "), e.notifCfg))
+		},
+	},
+	{
+		Name:  "playlist-mv",
+		Usage: "<old> <new>",
+		Run: func(e *directEnv, args []string) commands.CommandResult {
+			// Names with spaces must be quoted
+			if len(args) != 2 {
+				return commands.CommandResult{Success: false, Error: fmt.Errorf("usage: ql mpc playlist-mv <old> <new>")}
+			}
+			return commands.ResultOf(renamePlaylist(args[0], args[1], e.cfg, e.notifCfg))
+		},
+	},
+	{
+		Name: "song",
+		Run: func(e *directEnv, args []string) commands.CommandResult {
+			return commands.ResultOf(selectSong(e.ctx, e.notifCfg))
+		},
+	},
+	{
+		Name:  "goto",
+		Usage: "[position]",
+		Run: func(e *directEnv, args []string) commands.CommandResult {
+			position := ""
+			if len(args) > 0 {
+				position = args[0]
+			}
+			return commands.ResultOf(gotoPosition(e.ctx, position, e.notifCfg))
+		},
+	},
+	{
+		Name:  "addto",
+		Usage: "[playlist]",
+		Run: func(e *directEnv, args []string) commands.CommandResult {
+			return commands.ResultOf(addCurrentToPlaylist(e.ctx, strings.Join(args, " "), e.notifCfg))
+		},
+	},
+	{
+		Name:    "outputs",
+		Aliases: []string{"output"},
+		Run: func(e *directEnv, args []string) commands.CommandResult {
+			return commands.ResultOf(selectOutput(e.ctx, e.notifCfg))
+		},
+	},
+	{
+		Name:  "crossfade",
+		Usage: "[seconds]",
+		Run: func(e *directEnv, args []string) commands.CommandResult {
+			if len(args) == 0 {
+				return commands.ResultOf(selectCrossfade(e.ctx, e.notifCfg))
+			}
+			seconds, err := strconv.Atoi(args[0])
+			if err != nil {
+				return commands.CommandResult{
+					Success: false,
+					Error:   fmt.Errorf("invalid crossfade: %s (use: ql mpc crossfade <seconds>)", args[0]),
+				}
+			}
+			return commands.ResultOf(setCrossfade(seconds, e.notifCfg))
+		},
+	},
+}
 
-	return commands.CommandResult{Success: true}
+func executeDirectCommand(ctx commands.LauncherContext, args []string, cfg *Config, notifCfg *config.NotificationConfig) commands.CommandResult {
+	return directCommands.Dispatch("mpc", &directEnv{ctx: ctx, cfg: cfg, notifCfg: notifCfg}, args)
 }
 
 func loadPlaylistDirect(playlistName string, cfg *Config, notifCfg *config.NotificationConfig) error {
@@ -243,43 +409,108 @@ func setupMpdConnection(cfg *Config) error {
 		os.Setenv("XDG_RUNTIME_DIR", fmt.Sprintf("/run/user/%d", uid))
 	}
 
-	switch strings.ToLower(cfg.ConnectionType) {
-	case "socket":
-		socketPath := utils.ExpandHomeDir(cfg.Socket)
+	mpdHost, err := mpdHostValue(cfg)
+	if err != nil {
+		return err
+	}
 
-		if !utils.FileExists(socketPath) {
-			return fmt.Errorf("socket not found: %s", socketPath)
+	os.Setenv("MPD_HOST", mpdHost)
+
+	if strings.ToLower(cfg.ConnectionType) == "tcp" {
+		if cfg.Port != "" {
+			os.Setenv("MPD_PORT", cfg.Port)
+		} else {
+			os.Setenv("MPD_PORT", "6600")
 		}
+	}
+
+	return nil
+}
+
+// mpdHostValue builds MPD_HOST for mpc: the host or socket path, prefixed
+// with "password@" when a password is set. mpc reads the password from
+// MPD_HOST for sockets as well as TCP.
+func mpdHostValue(cfg *Config) (string, error) {
+	var target string
 
-		os.Setenv("MPD_HOST", socketPath)
+	switch strings.ToLower(cfg.ConnectionType) {
+	case "socket":
+		target = utils.ExpandPath(cfg.Socket)
+		if !utils.FileExists(target) {
+			return "", fmt.Errorf("socket not found: %s", target)
+		}
 
 	case "tcp":
-		if cfg.Host == "" {
-			return fmt.Errorf("host not specified in config")
+		target = strings.TrimSpace(cfg.Host)
+		if target == "" {
+			return "", fmt.Errorf("host not specified in config")
 		}
 
-		mpdHost := cfg.Host
+	default:
+		return "", fmt.Errorf("invalid connection_type: %s (must be 'tcp' or 'socket')", cfg.ConnectionType)
+	}
 
-		if cfg.Password != "" {
-			mpdHost = cfg.Password + "@" + mpdHost
-		}
+	if strings.TrimSpace(cfg.Password) == "" {
+		return target, nil
+	}
 
-		os.Setenv("MPD_HOST", mpdHost)
+	return cfg.Password + "@" + target, nil
+}
 
-		if cfg.Port != "" {
-			os.Setenv("MPD_PORT", cfg.Port)
-		} else {
-			os.Setenv("MPD_PORT", "6600")
-		}
+// redactMpdHost hides the password part of an MPD_HOST value, so it can be
+// shown in error notifications
+func redactMpdHost(mpdHost string) string {
+	if _, target, found := strings.Cut(mpdHost, "@"); found && !strings.HasPrefix(mpdHost, "@") {
+		return "***@" + target
+	}
+	return mpdHost
+}
 
-	default:
-		return fmt.Errorf("invalid connection_type: %s (must be 'tcp' or 'socket')", cfg.ConnectionType)
+// playerCommand runs a playback command over the MPD connection, or through mpc
+func playerCommand(mpdCmd string, mpcArgs ...string) error {
+	if mpd != nil {
+		_, err := mpd.command(mpdCmd)
+		return err
 	}
 
+	output, err := runMpcCommand(mpcArgs...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%s", strings.TrimSpace(string(output)))
+	}
 	return nil
 }
 
+// currentTitle returns "artist - title" of the current song, or "" when stopped
+func currentTitle() string {
+	song, err := getCurrentSong()
+	if err != nil || song.File == "" {
+		return ""
+	}
+	return song.String()
+}
+
 func togglePlayPause(notifCfg *config.NotificationConfig) error {
+	if mpd != nil {
+		status, err := mpd.pairs("status")
+		if err != nil {
+			return fmt.Errorf("toggle failed: %w", err)
+		}
+
+		if status["state"] == "play" {
+			if _, err := mpd.command("pause 1"); err != nil {
+				return fmt.Errorf("toggle failed: %w", err)
+			}
+			utils.NotifyWithConfig(notifCfg, "MPC", "Paused")
+		} else {
+			if _, err := mpd.command("play"); err != nil {
+				return fmt.Errorf("toggle failed: %w", err)
+			}
+			utils.NotifyWithConfig(notifCfg, "MPC", "Playing")
+		}
+
+		return nil
+	}
+
 	cmd := runMpcCommand("toggle")
 	output, err := cmd.CombinedOutput()
 	if err != nil {
@@ -302,17 +533,11 @@ func togglePlayPause(notifCfg *config.NotificationConfig) error {
 }
 
 func next(notifCfg *config.NotificationConfig) error {
-	cmd := runMpcCommand("next")
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		return fmt.Errorf("next failed: %s", strings.TrimSpace(string(output)))
+	if err := playerCommand("next", "next"); err != nil {
+		return fmt.Errorf("next failed: %v", err)
 	}
 
-	currentCmd := runMpcCommand("current", "-f", "%artist% - %title%")
-	currentOutput, _ := currentCmd.Output()
-	current := strings.TrimSpace(string(currentOutput))
-
-	if current != "" {
+	if current := currentTitle(); current != "" {
 		utils.NotifyWithConfig(notifCfg, "MPC - Next", current)
 	}
 
@@ -320,17 +545,11 @@ func next(notifCfg *config.NotificationConfig) error {
 }
 
 func previous(notifCfg *config.NotificationConfig) error {
-	cmd := runMpcCommand("prev")
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		return fmt.Errorf("prev failed: %s", strings.TrimSpace(string(output)))
+	if err := playerCommand("previous", "prev"); err != nil {
+		return fmt.Errorf("prev failed: %v", err)
 	}
 
-	currentCmd := runMpcCommand("current", "-f", "%artist% - %title%")
-	currentOutput, _ := currentCmd.Output()
-	current := strings.TrimSpace(string(currentOutput))
-
-	if current != "" {
+	if current := currentTitle(); current != "" {
 		utils.NotifyWithConfig(notifCfg, "MPC - Previous", current)
 	}
 
@@ -338,10 +557,8 @@ func previous(notifCfg *config.NotificationConfig) error {
 }
 
 func stop(notifCfg *config.NotificationConfig) error {
-	cmd := runMpcCommand("stop")
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		return fmt.Errorf("stop failed: %s", strings.TrimSpace(string(output)))
+	if err := playerCommand("stop", "stop"); err != nil {
+		return fmt.Errorf("stop failed: %v", err)
 	}
 
 	utils.NotifyWithConfig(notifCfg, "MPC", "Stopped")
@@ -350,19 +567,9 @@ func stop(notifCfg *config.NotificationConfig) error {
 }
 
 func selectPlaylist(ctx commands.LauncherContext, cfg *Config, notifCfg *config.NotificationConfig) error {
-	cmd := runMpcCommand("lsplaylists")
-	output, err := cmd.Output()
+	playlists, err := getPlaylists()
 	if err != nil {
-		return fmt.Errorf("failed to get playlists: %w", err)
-	}
-
-	lines := strings.Split(string(output), "\n")
-	var playlists []string
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if line != "" {
-			playlists = append(playlists, line)
-		}
+		return err
 	}
 
 	if len(playlists) == 0 {
@@ -407,7 +614,7 @@ func selectSong(ctx commands.LauncherContext, notifCfg *config.NotificationConfi
 
 	songs = append([]string{"← Back"}, songs...)
 
-	choice, err := ctx.Show(songs, "Select Song")
+	choice, err := ctx.ShowOrdered(songs, "Select Song")
 	if err != nil {
 		// ESC pressed - return "cancelled" to exit completely
 		return fmt.Errorf("cancelled")
@@ -421,41 +628,83 @@ func selectSong(ctx commands.LauncherContext, notifCfg *config.NotificationConfi
 	var position int
 	fmt.Sscanf(choice, "%d", &position)
 
-	cmd = runMpcCommand("play", fmt.Sprintf("%d", position))
-	if err := cmd.Run(); err != nil {
+	// mpc positions are 1-based, the protocol's are 0-based
+	if err := playerCommand(fmt.Sprintf("play %d", position-1), "play", fmt.Sprintf("%d", position)); err != nil {
 		return fmt.Errorf("failed to play song: %w", err)
 	}
 
-	currentCmd := runMpcCommand("current", "-f", "%artist% - %title%")
-	currentOutput, _ := currentCmd.Output()
-	current := strings.TrimSpace(string(currentOutput))
-
-	if current != "" {
+	if current := currentTitle(); current != "" {
 		utils.NotifyWithConfig(notifCfg, "Now Playing", current)
 	}
 
 	return nil
 }
 
-func showCurrent(notifCfg *config.NotificationConfig) error {
-	cmd := runMpcCommand("current", "-f", "%artist% - %title%")
+// Song describes the currently playing track
+type Song struct {
+	Artist string `json:"artist,omitempty"`
+	Title  string `json:"title,omitempty"`
+	Album  string `json:"album,omitempty"`
+	File   string `json:"file,omitempty"`
+}
+
+// String returns "artist - title" or "Nothing playing"
+func (s *Song) String() string {
+	if s.File == "" {
+		return "Nothing playing"
+	}
+	if s.Artist == "" && s.Title == "" {
+		return s.File
+	}
+	return fmt.Sprintf("%s - %s", s.Artist, s.Title)
+}
+
+func getCurrentSong() (*Song, error) {
+	if mpd != nil {
+		pairs, err := mpd.pairs("currentsong")
+		if err != nil {
+			return nil, fmt.Errorf("failed to get current song: %w", err)
+		}
+		return songFromPairs(pairs), nil
+	}
+
+	cmd := runMpcCommand("current", "-f", "%artist%\t%title%\t%album%\t%file%")
 	output, err := cmd.Output()
 	if err != nil {
-		return fmt.Errorf("failed to get current song:    %w", err)
+		return nil, fmt.Errorf("failed to get current song: %w", err)
+	}
+
+	line := strings.TrimRight(string(output), "\n")
+	if line == "" {
+		return &Song{}, nil
+	}
+
+	fields := strings.Split(line, "\t")
+	for len(fields) < 4 {
+		fields = append(fields, "")
 	}
 
-	current := strings.TrimSpace(string(output))
-	if current == "" {
-		current = "Nothing playing"
+	return &Song{
+		Artist: fields[0],
+		Title:  fields[1],
+		Album:  fields[2],
+		File:   fields[3],
+	}, nil
+}
+
+func showCurrent(notifCfg *config.NotificationConfig) error {
+	song, err := getCurrentSong()
+	if err != nil {
+		return err
 	}
 
-	utils.NotifyWithConfig(notifCfg, "Now Playing", current)
+	utils.NotifyWithConfig(notifCfg, "Now Playing", song.String())
 
 	return nil
 }
 
 func cachePlaylist(cfg *Config, playlist string) {
-	cachePath := utils.ExpandHomeDir(cfg.CurrentPlaylistCache)
+	cachePath := utils.ExpandPath(cfg.CurrentPlaylistCache)
 	cacheDir := filepath.Dir(cachePath)
 
 	utils.EnsureDir(cacheDir)
@@ -0,0 +1,205 @@
+package mpc
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lvim-tech/ql/pkg/utils"
+)
+
+// mpdTimeout bounds a single request/response exchange with MPD
+const mpdTimeout = 5 * time.Second
+
+// mpd is the protocol connection shared by one Run. When it is nil every
+// action falls back to spawning mpc.
+var mpd *mpdConn
+
+// mpdConn is a minimal MPD protocol client over the configured socket or TCP
+// address, used for hot-path actions instead of one mpc process per call
+type mpdConn struct {
+	conn   net.Conn
+	reader *bufio.Reader
+	// cfg is kept to redial after MPD dropped the connection
+	cfg *Config
+}
+
+// dialMpd connects to MPD as configured and authenticates if needed
+func dialMpd(cfg *Config) (*mpdConn, error) {
+	var network, address string
+
+	switch strings.ToLower(cfg.ConnectionType) {
+	case "socket":
+		network, address = "unix", utils.ExpandPath(cfg.Socket)
+	case "tcp":
+		port := cfg.Port
+		if port == "" {
+			port = "6600"
+		}
+		network, address = "tcp", net.JoinHostPort(cfg.Host, port)
+	default:
+		return nil, fmt.Errorf("invalid connection_type: %s", cfg.ConnectionType)
+	}
+
+	conn, err := net.DialTimeout(network, address, mpdTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &mpdConn{conn: conn, reader: bufio.NewReader(conn), cfg: cfg}
+
+	conn.SetDeadline(time.Now().Add(mpdTimeout))
+	greeting, err := c.reader.ReadString('\n')
+	if err != nil || !strings.HasPrefix(greeting, "OK MPD ") {
+		conn.Close()
+		return nil, fmt.Errorf("unexpected MPD greeting: %q", strings.TrimSpace(greeting))
+	}
+
+	if strings.TrimSpace(cfg.Password) != "" {
+		if _, err := c.exchange("password " + quoteArg(cfg.Password)); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+
+	return c, nil
+}
+
+// command sends one protocol command and returns the response lines
+// without the terminating OK. MPD closes clients that stay idle longer than
+// its connection_timeout (60s by default), which a menu left open easily
+// does, so a connection found closed is redialed once and the command sent
+// again. Timeouts are not retried: MPD may have run the command already.
+func (c *mpdConn) command(cmd string) ([]string, error) {
+	lines, err := c.exchange(cmd)
+	if err == nil || !connectionLost(err) {
+		return lines, err
+	}
+
+	utils.LogDebug("mpd connection lost, redialing", "error", err)
+	fresh, dialErr := dialMpd(c.cfg)
+	if dialErr != nil {
+		return nil, err
+	}
+	c.conn.Close()
+	c.conn, c.reader = fresh.conn, fresh.reader
+
+	return c.exchange(cmd)
+}
+
+// connectionLost reports whether err means MPD closed the connection
+func connectionLost(err error) bool {
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) && !netErr.Timeout()
+}
+
+// exchange sends cmd over the current connection and reads the response
+func (c *mpdConn) exchange(cmd string) ([]string, error) {
+	c.conn.SetDeadline(time.Now().Add(mpdTimeout))
+
+	if _, err := fmt.Fprintf(c.conn, "%s\n", cmd); err != nil {
+		return nil, fmt.Errorf("mpd: %w", err)
+	}
+
+	var lines []string
+	for {
+		line, err := c.reader.ReadString('\n')
+		if err != nil {
+			return nil, fmt.Errorf("mpd: %w", err)
+		}
+		line = strings.TrimSuffix(line, "\n")
+
+		if line == "OK" {
+			return lines, nil
+		}
+
+		// ACK [error@command_listNum] {current_command} message_text
+		if strings.HasPrefix(line, "ACK ") {
+			if idx := strings.Index(line, "} "); idx != -1 {
+				return nil, fmt.Errorf("%s", line[idx+2:])
+			}
+			return nil, fmt.Errorf("%s", line)
+		}
+
+		lines = append(lines, line)
+	}
+}
+
+// pairs runs a command and returns its "key: value" lines as a map.
+// For repeated keys only the first value is kept.
+func (c *mpdConn) pairs(cmd string) (map[string]string, error) {
+	lines, err := c.command(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]string)
+	for _, line := range lines {
+		key, value, found := strings.Cut(line, ": ")
+		if !found {
+			continue
+		}
+		if _, exists := result[key]; !exists {
+			result[key] = value
+		}
+	}
+
+	return result, nil
+}
+
+func (c *mpdConn) Close() error {
+	fmt.Fprintf(c.conn, "close\n")
+	return c.conn.Close()
+}
+
+// quoteArg quotes a protocol argument
+func quoteArg(arg string) string {
+	arg = strings.ReplaceAll(arg, `\`, `\\`)
+	arg = strings.ReplaceAll(arg, `"`, `\"`)
+	return `"` + arg + `"`
+}
+
+// songFromPairs builds a Song from a 'currentsong' response
+func songFromPairs(pairs map[string]string) *Song {
+	return &Song{
+		Artist: pairs["Artist"],
+		Title:  pairs["Title"],
+		Album:  pairs["Album"],
+		File:   pairs["file"],
+	}
+}
+
+// statusFromPairs builds a PlayerStatus from a 'status' response
+func statusFromPairs(pairs map[string]string) *PlayerStatus {
+	status := &PlayerStatus{State: "stopped", Volume: -1}
+
+	switch pairs["state"] {
+	case "play":
+		status.State = "playing"
+	case "pause":
+		status.State = "paused"
+	}
+
+	if elapsed, err := strconv.ParseFloat(pairs["elapsed"], 64); err == nil {
+		status.Elapsed = int(elapsed)
+	}
+	if duration, err := strconv.ParseFloat(pairs["duration"], 64); err == nil {
+		status.Duration = int(duration)
+	} else if _, total, found := strings.Cut(pairs["time"], ":"); found {
+		status.Duration, _ = strconv.Atoi(total)
+	}
+	if volume, err := strconv.Atoi(pairs["volume"]); err == nil {
+		status.Volume = volume
+	}
+
+	return status
+}
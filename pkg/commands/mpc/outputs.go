@@ -0,0 +1,190 @@
+package mpc
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/lvim-tech/ql/pkg/commands"
+	"github.com/lvim-tech/ql/pkg/config"
+	"github.com/lvim-tech/ql/pkg/utils"
+)
+
+// Output is an MPD audio output as reported by 'mpc outputs'
+type Output struct {
+	ID      int
+	Name    string
+	Enabled bool
+}
+
+// outputLineRe matches lines like: Output 1 (My ALSA Device) is enabled
+var outputLineRe = regexp.MustCompile(`^Output (\d+) \((.*)\) is (enabled|disabled)$`)
+
+// crossfadeOptions are the durations offered in the crossfade menu
+var crossfadeOptions = []int{0, 2, 3, 5, 8, 10}
+
+func getOutputs() ([]Output, error) {
+	cmd := runMpcCommand("outputs")
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get outputs: %w", err)
+	}
+
+	return parseOutputs(string(output)), nil
+}
+
+func parseOutputs(output string) []Output {
+	var outputs []Output
+
+	for _, line := range strings.Split(output, "\n") {
+		match := outputLineRe.FindStringSubmatch(strings.TrimSpace(line))
+		if match == nil {
+			continue
+		}
+
+		id, err := strconv.Atoi(match[1])
+		if err != nil {
+			continue
+		}
+
+		outputs = append(outputs, Output{
+			ID:      id,
+			Name:    match[2],
+			Enabled: match[3] == "enabled",
+		})
+	}
+
+	return outputs
+}
+
+// outputLabel renders an output with its current state for the menu
+func outputLabel(o Output) string {
+	state := "off"
+	if o.Enabled {
+		state = "on"
+	}
+	return fmt.Sprintf("%d. [%s] %s", o.ID, state, o.Name)
+}
+
+func selectOutput(ctx commands.LauncherContext, notifCfg *config.NotificationConfig) error {
+	outputs, err := getOutputs()
+	if err != nil {
+		return err
+	}
+
+	if len(outputs) == 0 {
+		return fmt.Errorf("no audio outputs configured in MPD")
+	}
+
+	options := []string{"← Back"}
+	for _, o := range outputs {
+		options = append(options, outputLabel(o))
+	}
+
+	choice, err := ctx.Show(options, "Toggle Output")
+	if err != nil {
+		// ESC pressed - return "cancelled" to exit completely
+		return fmt.Errorf("cancelled")
+	}
+
+	if choice == "← Back" {
+		return fmt.Errorf("back")
+	}
+
+	for _, o := range outputs {
+		if outputLabel(o) == choice {
+			return setOutputEnabled(o, !o.Enabled, notifCfg)
+		}
+	}
+
+	return fmt.Errorf("unknown output: %s", choice)
+}
+
+func setOutputEnabled(o Output, enable bool, notifCfg *config.NotificationConfig) error {
+	action, state := "disable", "disabled"
+	if enable {
+		action, state = "enable", "enabled"
+	}
+
+	cmd := runMpcCommand(action, strconv.Itoa(o.ID))
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to %s output: %s", action, strings.TrimSpace(string(output)))
+	}
+
+	utils.NotifyWithConfig(notifCfg, "MPC - Output", fmt.Sprintf("%s %s", o.Name, state))
+
+	return nil
+}
+
+// getCrossfade returns the current crossfade in seconds
+func getCrossfade() (int, error) {
+	cmd := runMpcCommand("crossfade")
+	output, err := cmd.Output()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get crossfade: %w", err)
+	}
+
+	// Output: "crossfade: 5"
+	value := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(string(output)), "crossfade:"))
+	seconds, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("unexpected crossfade output: %s", strings.TrimSpace(string(output)))
+	}
+
+	return seconds, nil
+}
+
+func setCrossfade(seconds int, notifCfg *config.NotificationConfig) error {
+	if seconds < 0 {
+		return fmt.Errorf("crossfade must not be negative")
+	}
+
+	cmd := runMpcCommand("crossfade", strconv.Itoa(seconds))
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to set crossfade: %s", strings.TrimSpace(string(output)))
+	}
+
+	if seconds == 0 {
+		utils.NotifyWithConfig(notifCfg, "MPC", "Crossfade disabled")
+	} else {
+		utils.NotifyWithConfig(notifCfg, "MPC", fmt.Sprintf("Crossfade: %ds", seconds))
+	}
+
+	return nil
+}
+
+func selectCrossfade(ctx commands.LauncherContext, notifCfg *config.NotificationConfig) error {
+	current, err := getCrossfade()
+	if err != nil {
+		return err
+	}
+
+	options := []string{"← Back"}
+	for _, seconds := range crossfadeOptions {
+		label := fmt.Sprintf("%d seconds", seconds)
+		if seconds == 0 {
+			label = "Off"
+		}
+		if seconds == current {
+			label += " (current)"
+		}
+		options = append(options, label)
+	}
+
+	choice, err := ctx.Show(options, "Crossfade")
+	if err != nil {
+		return fmt.Errorf("cancelled")
+	}
+
+	if choice == "← Back" {
+		return fmt.Errorf("back")
+	}
+
+	seconds := 0
+	fmt.Sscanf(choice, "%d", &seconds)
+
+	return setCrossfade(seconds, notifCfg)
+}
@@ -0,0 +1,185 @@
+package mpc
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/lvim-tech/ql/pkg/commands"
+	"github.com/lvim-tech/ql/pkg/config"
+	"github.com/lvim-tech/ql/pkg/utils"
+)
+
+// managePlaylists picks a saved playlist, then renames or deletes it
+func managePlaylists(ctx commands.LauncherContext, cfg *Config, notifCfg *config.NotificationConfig) error {
+	playlists, err := getPlaylists()
+	if err != nil {
+		return err
+	}
+	if len(playlists) == 0 {
+		return fmt.Errorf("no saved playlists found")
+	}
+
+	name, err := ctx.Show(append([]string{"← Back"}, playlists...), "Manage Playlist")
+	if err != nil {
+		return fmt.Errorf("cancelled")
+	}
+	if name == "← Back" {
+		return fmt.Errorf("back")
+	}
+
+	action, err := ctx.Show([]string{"← Back", "Rename", "Delete"}, name)
+	if err != nil {
+		return fmt.Errorf("cancelled")
+	}
+
+	switch action {
+	case "Rename":
+		newName, err := ctx.ShowAllowCustom([]string{"← Back"}, fmt.Sprintf("New name for %s", name))
+		if err != nil {
+			return fmt.Errorf("cancelled")
+		}
+		if newName == "← Back" {
+			return fmt.Errorf("back")
+		}
+		newName = strings.TrimSpace(newName)
+
+		choice, err := ctx.Show(commands.ConfirmOptions(ctx), fmt.Sprintf("Rename %s to %s?", name, newName))
+		if err != nil {
+			return fmt.Errorf("cancelled")
+		}
+		if choice != "Yes" {
+			return fmt.Errorf("back")
+		}
+		return renamePlaylist(name, newName, cfg, notifCfg)
+
+	case "Delete":
+		choice, err := ctx.Show(commands.ConfirmOptions(ctx), fmt.Sprintf("Delete playlist %s?", name))
+		if err != nil {
+			return fmt.Errorf("cancelled")
+		}
+		if choice != "Yes" {
+			return fmt.Errorf("back")
+		}
+		return deletePlaylist(name, notifCfg)
+	}
+
+	return fmt.Errorf("back")
+}
+
+// deletePlaylist removes a saved playlist; the queue is not touched
+func deletePlaylist(name string, notifCfg *config.NotificationConfig) error {
+	if err := requirePlaylist(name); err != nil {
+		return err
+	}
+
+	if err := playerCommand("rm "+quoteArg(name), "rm", name); err != nil {
+		return fmt.Errorf("failed to delete playlist %s: %w", name, err)
+	}
+
+	utils.NotifyWithConfig(notifCfg, "MPC - Playlist Deleted", name)
+	return nil
+}
+
+// renamePlaylist renames a saved playlist. Over the socket this is MPD's
+// rename command. mpc has none, so there the tracks are copied to the new
+// name and the old playlist is removed only once the copy is complete; a
+// failure on the way removes the partial copy and leaves the original as it
+// was. The queue is not touched either way.
+func renamePlaylist(oldName, newName string, cfg *Config, notifCfg *config.NotificationConfig) error {
+	if newName == "" {
+		return fmt.Errorf("playlist name is empty")
+	}
+	if newName == oldName {
+		return nil
+	}
+
+	if err := requirePlaylist(oldName); err != nil {
+		return err
+	}
+	if err := requirePlaylist(newName); err == nil {
+		return fmt.Errorf("playlist %s already exists", newName)
+	}
+
+	if mpd != nil {
+		if _, err := mpd.command("rename " + quoteArg(oldName) + " " + quoteArg(newName)); err != nil {
+			return fmt.Errorf("failed to rename playlist %s: %w", oldName, err)
+		}
+	} else if err := copyPlaylist(oldName, newName); err != nil {
+		return err
+	} else if output, err := runMpcCommand("rm", oldName).CombinedOutput(); err != nil {
+		return fmt.Errorf("copied %s to %s, but could not remove the old playlist: %s", oldName, newName, strings.TrimSpace(string(output)))
+	}
+
+	// Keep the last-loaded playlist cache pointing at the playlist
+	cachePath := utils.ExpandPath(cfg.CurrentPlaylistCache)
+	if data, err := os.ReadFile(cachePath); err == nil && strings.TrimSpace(string(data)) == oldName {
+		cachePlaylist(cfg, newName)
+	}
+
+	utils.NotifyWithConfig(notifCfg, "MPC - Playlist Renamed", fmt.Sprintf("%s → %s", oldName, newName))
+	return nil
+}
+
+// copyPlaylist appends every track of src to a new playlist dst, verifying
+// the count before reporting success. On failure dst is removed again.
+func copyPlaylist(src, dst string) error {
+	files, err := playlistFiles(src)
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("playlist %s is empty; nothing to rename", src)
+	}
+
+	rollback := func(cause error) error {
+		runMpcCommand("rm", dst).Run()
+		return fmt.Errorf("failed to rename playlist %s (left unchanged): %w", src, cause)
+	}
+
+	for _, file := range files {
+		if output, err := runMpcCommand("playlistadd", dst, file).CombinedOutput(); err != nil {
+			return rollback(fmt.Errorf("%s", strings.TrimSpace(string(output))))
+		}
+	}
+
+	copied, err := playlistFiles(dst)
+	if err != nil {
+		return rollback(err)
+	}
+	if len(copied) != len(files) {
+		return rollback(fmt.Errorf("copied %d of %d tracks", len(copied), len(files)))
+	}
+
+	return nil
+}
+
+// playlistFiles returns the song files of a saved playlist
+func playlistFiles(name string) ([]string, error) {
+	output, err := runMpcCommand("-f", "%file%", "playlist", name).Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read playlist %s: %w", name, err)
+	}
+
+	var files []string
+	for _, line := range strings.Split(string(output), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			files = append(files, line)
+		}
+	}
+
+	return files, nil
+}
+
+// requirePlaylist reports an error unless name is a saved playlist
+func requirePlaylist(name string) error {
+	playlists, err := getPlaylists()
+	if err != nil {
+		return err
+	}
+	if !slices.Contains(playlists, name) {
+		return fmt.Errorf("playlist not found: %s", name)
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package mpc
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/lvim-tech/ql/pkg/commands"
+	"github.com/lvim-tech/ql/pkg/config"
+	"github.com/lvim-tech/ql/pkg/utils"
+)
+
+// getPlaylists returns the names of the saved playlists
+func getPlaylists() ([]string, error) {
+	output, err := runMpcCommand("lsplaylists").Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get playlists: %w", err)
+	}
+
+	var playlists []string
+	for _, line := range strings.Split(string(output), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			playlists = append(playlists, line)
+		}
+	}
+
+	return playlists, nil
+}
+
+// addCurrentToPlaylist appends the playing track to a saved playlist. Without
+// a name the user picks a playlist or types a new one, which MPD creates.
+func addCurrentToPlaylist(ctx commands.LauncherContext, name string, notifCfg *config.NotificationConfig) error {
+	song, err := getCurrentSong()
+	if err != nil {
+		return err
+	}
+	if song.File == "" {
+		return fmt.Errorf("nothing is playing")
+	}
+
+	if name == "" {
+		playlists, err := getPlaylists()
+		if err != nil {
+			return err
+		}
+
+		choice, err := ctx.ShowAllowCustom(append([]string{"← Back"}, playlists...), "Add to Playlist (or type a new name)")
+		if err != nil {
+			return fmt.Errorf("cancelled")
+		}
+		if choice == "← Back" {
+			return fmt.Errorf("back")
+		}
+		name = strings.TrimSpace(choice)
+	}
+
+	if name == "" {
+		return fmt.Errorf("playlist name is empty")
+	}
+
+	if output, err := runMpcCommand("playlistadd", name, song.File).CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to add to playlist %s: %s", name, strings.TrimSpace(string(output)))
+	}
+
+	utils.NotifyWithConfig(notifCfg, "Added to "+name, song.String())
+	return nil
+}
+
+// queueLength returns the number of songs in the queue
+func queueLength() (int, error) {
+	if mpd != nil {
+		pairs, err := mpd.pairs("status")
+		if err != nil {
+			return 0, fmt.Errorf("failed to get status: %w", err)
+		}
+		return strconv.Atoi(pairs["playlistlength"])
+	}
+
+	output, err := runMpcCommand("playlist", "-f", "%position%").Output()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get playlist: %w", err)
+	}
+
+	return len(strings.Fields(string(output))), nil
+}
+
+// gotoPosition plays the queue entry at a 1-based position, asking for the
+// number when arg is empty; quicker than scrolling 'Select Song' in a long queue
+func gotoPosition(ctx commands.LauncherContext, arg string, notifCfg *config.NotificationConfig) error {
+	length, err := queueLength()
+	if err != nil {
+		return err
+	}
+	if length == 0 {
+		return fmt.Errorf("playlist is empty")
+	}
+
+	if arg == "" {
+		choice, err := ctx.ShowAllowCustom([]string{"← Back"}, fmt.Sprintf("Go to Position (1-%d)", length))
+		if err != nil {
+			return fmt.Errorf("cancelled")
+		}
+		if choice == "← Back" {
+			return fmt.Errorf("back")
+		}
+		arg = strings.TrimSpace(choice)
+	}
+
+	position, err := strconv.Atoi(arg)
+	if err != nil || position < 1 || position > length {
+		return fmt.Errorf("invalid position: %s (queue has %d songs)", arg, length)
+	}
+
+	// mpc positions are 1-based, the protocol's are 0-based
+	if err := playerCommand(fmt.Sprintf("play %d", position-1), "play", strconv.Itoa(position)); err != nil {
+		return fmt.Errorf("failed to play song: %w", err)
+	}
+
+	if current := currentTitle(); current != "" {
+		utils.NotifyWithConfig(notifCfg, "Now Playing", current)
+	}
+
+	return nil
+}
@@ -0,0 +1,135 @@
+package mpc
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// defaultStatusFormat is used by 'ql mpc status' when --format is not given
+const defaultStatusFormat = "%artist% - %title%"
+
+// PlayerStatus describes the player state for status bars
+type PlayerStatus struct {
+	State    string `json:"state"`
+	Artist   string `json:"artist,omitempty"`
+	Title    string `json:"title,omitempty"`
+	Album    string `json:"album,omitempty"`
+	File     string `json:"file,omitempty"`
+	Elapsed  int    `json:"elapsed"`
+	Duration int    `json:"duration"`
+	Volume   int    `json:"volume"`
+}
+
+// statusLineRe matches: [playing] #3/10   1:23/4:56 (28%)
+var statusLineRe = regexp.MustCompile(`^\[(\w+)\]\s+#\d+/\d+\s+([\d:]+)/([\d:]+)`)
+
+// volumeRe matches: volume: 80%   (or volume: n/a)
+var volumeRe = regexp.MustCompile(`volume:\s*(\d+)%`)
+
+func getPlayerStatus() (*PlayerStatus, error) {
+	song, err := getCurrentSong()
+	if err != nil {
+		return nil, err
+	}
+
+	if mpd != nil {
+		pairs, err := mpd.pairs("status")
+		if err != nil {
+			return nil, fmt.Errorf("failed to get status: %w", err)
+		}
+		status := statusFromPairs(pairs)
+		if status.State != "stopped" {
+			status.Artist = song.Artist
+			status.Title = song.Title
+			status.Album = song.Album
+			status.File = song.File
+		}
+		return status, nil
+	}
+
+	output, err := runMpcCommand("status").Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get status: %w", err)
+	}
+
+	status := parsePlayerStatus(string(output))
+	if status.State != "stopped" {
+		status.Artist = song.Artist
+		status.Title = song.Title
+		status.Album = song.Album
+		status.File = song.File
+	}
+
+	return status, nil
+}
+
+// parsePlayerStatus parses the default 'mpc status' output. When stopped,
+// mpc prints only the volume/options line.
+func parsePlayerStatus(output string) *PlayerStatus {
+	status := &PlayerStatus{State: "stopped", Volume: -1}
+
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+
+		if match := statusLineRe.FindStringSubmatch(line); match != nil {
+			status.State = match[1]
+			status.Elapsed = parseClock(match[2])
+			status.Duration = parseClock(match[3])
+			continue
+		}
+
+		if match := volumeRe.FindStringSubmatch(line); match != nil {
+			status.Volume, _ = strconv.Atoi(match[1])
+		}
+	}
+
+	return status
+}
+
+// parseClock converts m:ss or h:mm:ss into seconds
+func parseClock(value string) int {
+	total := 0
+	for _, part := range strings.Split(value, ":") {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return 0
+		}
+		total = total*60 + n
+	}
+	return total
+}
+
+// formatStatusLine renders the current song with an mpc format string.
+// Returns an empty line when nothing is playing.
+func formatStatusLine(format string) (string, error) {
+	output, err := runMpcCommand("current", "-f", format).Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to get current song: %w", err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// parseStatusArgs extracts --format from 'ql mpc status' arguments
+func parseStatusArgs(args []string) (string, error) {
+	format := defaultStatusFormat
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--format" || arg == "-f":
+			if i+1 >= len(args) {
+				return "", fmt.Errorf("--format needs a value")
+			}
+			format = args[i+1]
+			i++
+		case strings.HasPrefix(arg, "--format="):
+			format = strings.TrimPrefix(arg, "--format=")
+		default:
+			return "", fmt.Errorf("unknown status option: %s (use: --format FMT, --json)", arg)
+		}
+	}
+
+	return format, nil
+}
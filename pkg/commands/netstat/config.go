@@ -2,18 +2,57 @@ package netstat
 
 // Config represents netstat module configuration
 type Config struct {
-	Enabled        bool `toml:"enabled"`
-	ShowNotify     bool `toml:"show_notify"`
-	UpdateInterval int  `toml:"update_interval"` // seconds for live monitor
-	PreferVnstat   bool `toml:"prefer_vnstat"`   // prefer vnstat over /sys/class/net
+	Enabled          bool   `toml:"enabled" mapstructure:"enabled"`
+	ShowNotify       bool   `toml:"show_notify" mapstructure:"show_notify"`
+	UpdateInterval   int    `toml:"update_interval" mapstructure:"update_interval"`     // seconds for live monitor
+	PreferVnstat     bool   `toml:"prefer_vnstat" mapstructure:"prefer_vnstat"`         // prefer vnstat over /sys/class/net
+	TopK             int    `toml:"top_k" mapstructure:"top_k"`                         // max hosts shown by top talkers
+	SpeedTestLog     string `toml:"speedtest_log" mapstructure:"speedtest_log"`         // append speed test results here ("" = disabled)
+	PrimaryInterface string `toml:"primary_interface" mapstructure:"primary_interface"` // interface for 'speed' ("" = auto)
+	LogInterval      int    `toml:"log_interval" mapstructure:"log_interval"`           // seconds between 'log' samples
+	// MonitorHosts are pinged by the Latency view and 'ql netstat ping'
+	MonitorHosts []string `toml:"monitor_hosts" mapstructure:"monitor_hosts"`
+	PingCount    int      `toml:"ping_count" mapstructure:"ping_count"`     // echoes per host
+	PingTimeout  int      `toml:"ping_timeout" mapstructure:"ping_timeout"` // seconds to wait for each reply
 }
 
 // DefaultConfig returns default configuration
 func DefaultConfig() Config {
 	return Config{
-		Enabled:        true,
-		ShowNotify:     true,
-		UpdateInterval: 1,
-		PreferVnstat:   true,
+		Enabled:          true,
+		ShowNotify:       true,
+		UpdateInterval:   1,
+		PreferVnstat:     true,
+		TopK:             10,
+		SpeedTestLog:     "",
+		PrimaryInterface: "",
+		LogInterval:      60,
+		MonitorHosts:     []string{"1.1.1.1", "8.8.8.8", "google.com"},
+		PingCount:        4,
+		PingTimeout:      2,
 	}
 }
+
+// logInterval returns log_interval, at least one second
+func (c *Config) logInterval() int {
+	if c.LogInterval < 1 {
+		return 60
+	}
+	return c.LogInterval
+}
+
+// pingCount returns ping_count, at least one echo
+func (c *Config) pingCount() int {
+	if c.PingCount < 1 {
+		return 4
+	}
+	return c.PingCount
+}
+
+// pingTimeout returns ping_timeout, at least one second
+func (c *Config) pingTimeout() int {
+	if c.PingTimeout < 1 {
+		return 2
+	}
+	return c.PingTimeout
+}
@@ -0,0 +1,220 @@
+package netstat
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lvim-tech/ql/pkg/utils"
+)
+
+// defaultGraphWidth is used when the terminal width is unknown (GUI dialogs, pipes)
+const defaultGraphWidth = 80
+
+// TrafficBucket is the traffic of a single hour or day in a graph
+type TrafficBucket struct {
+	Start   time.Time
+	RxBytes uint64
+	TxBytes uint64
+}
+
+// TrafficHistory is the per-hour or per-day traffic of a period
+type TrafficHistory struct {
+	Period  string
+	Daily   bool
+	Buckets []TrafficBucket
+}
+
+// vnstatEntry is a single hour/day record of 'vnstat --json'
+type vnstatEntry struct {
+	Date struct {
+		Year  int `json:"year"`
+		Month int `json:"month"`
+		Day   int `json:"day"`
+	} `json:"date"`
+	Time struct {
+		Hour int `json:"hour"`
+	} `json:"time"`
+	Rx uint64 `json:"rx"`
+	Tx uint64 `json:"tx"`
+}
+
+// GetTrafficHistory returns vnstat traffic for the period in hourly buckets,
+// or daily buckets when the period spans more than two days
+func GetTrafficHistory(period string, interfaceName string) (*TrafficHistory, error) {
+	start, end, err := parsePeriod(period)
+	if err != nil {
+		return nil, err
+	}
+
+	if !utils.CommandExists("vnstat") || !vnstatHasData() {
+		return nil, fmt.Errorf("traffic graph requires vnstat with collected data")
+	}
+
+	daily := end.Sub(start) > 48*time.Hour
+
+	entries, err := getVnstatEntries(daily, interfaceName)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TrafficHistory{
+		Period:  formatPeriod(start, end),
+		Daily:   daily,
+		Buckets: bucketTraffic(entries, start, end, daily),
+	}, nil
+}
+
+// getVnstatEntries reads hourly or daily records of all (or one) interfaces
+func getVnstatEntries(daily bool, interfaceName string) ([]vnstatEntry, error) {
+	mode := "h"
+	if daily {
+		mode = "d"
+	}
+
+	args := []string{"--json", mode}
+	if interfaceName != "" {
+		args = append(args, "-i", interfaceName)
+	}
+
+	output, err := utils.RunCommandTimeout(commandTimeout, "vnstat", args...)
+	if err != nil {
+		return nil, fmt.Errorf("vnstat query failed: %w", err)
+	}
+
+	var vnstatData struct {
+		Interfaces []struct {
+			Traffic struct {
+				Hour []vnstatEntry `json:"hour"`
+				Day  []vnstatEntry `json:"day"`
+			} `json:"traffic"`
+		} `json:"interfaces"`
+	}
+
+	if err := json.Unmarshal([]byte(output), &vnstatData); err != nil {
+		return nil, fmt.Errorf("failed to parse vnstat data: %w", err)
+	}
+
+	var entries []vnstatEntry
+	for _, iface := range vnstatData.Interfaces {
+		if daily {
+			entries = append(entries, iface.Traffic.Day...)
+		} else {
+			entries = append(entries, iface.Traffic.Hour...)
+		}
+	}
+
+	return entries, nil
+}
+
+// bucketTraffic sums entries into consecutive hour/day buckets covering
+// start..end, so periods without traffic still show up as empty bars
+func bucketTraffic(entries []vnstatEntry, start, end time.Time, daily bool) []TrafficBucket {
+	first := time.Date(start.Year(), start.Month(), start.Day(), start.Hour(), 0, 0, 0, time.Local)
+	if daily {
+		first = time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.Local)
+	}
+
+	next := func(t time.Time) time.Time {
+		if daily {
+			return t.AddDate(0, 0, 1)
+		}
+		return t.Add(time.Hour)
+	}
+
+	var buckets []TrafficBucket
+	index := make(map[time.Time]int)
+	for t := first; t.Before(end) || t.Equal(end); t = next(t) {
+		index[t] = len(buckets)
+		buckets = append(buckets, TrafficBucket{Start: t})
+	}
+
+	for _, entry := range entries {
+		hour := entry.Time.Hour
+		if daily {
+			hour = 0
+		}
+		t := time.Date(entry.Date.Year, time.Month(entry.Date.Month), entry.Date.Day, hour, 0, 0, 0, time.Local)
+
+		if i, ok := index[t]; ok {
+			buckets[i].RxBytes += entry.Rx
+			buckets[i].TxBytes += entry.Tx
+		}
+	}
+
+	return buckets
+}
+
+// graphWidth returns the terminal width from $COLUMNS, or defaultGraphWidth
+func graphWidth() int {
+	if cols, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && cols > 0 {
+		return cols
+	}
+	return defaultGraphWidth
+}
+
+// formatTrafficGraph renders the history as horizontal bars scaled to width.
+// Each bar shows downloaded traffic as '█' followed by uploaded traffic as '░'.
+func formatTrafficGraph(history *TrafficHistory, width int) string {
+	var output strings.Builder
+
+	fmt.Fprintf(&output, "Traffic Graph - %s\n\n", history.Period)
+
+	labelLayout := "01-02 15:00"
+	if history.Daily {
+		labelLayout = "Mon 01-02"
+	}
+
+	var maxTotal, totalRx, totalTx uint64
+	for _, b := range history.Buckets {
+		maxTotal = max(maxTotal, b.RxBytes+b.TxBytes)
+		totalRx += b.RxBytes
+		totalTx += b.TxBytes
+	}
+
+	if maxTotal == 0 {
+		output.WriteString("No traffic recorded for this period.\n")
+		return output.String()
+	}
+
+	// Label, spaces and the trailing size column take a fixed part of the line
+	labelWidth := len(labelLayout)
+	sizeWidth := 10
+	barWidth := max(width-labelWidth-sizeWidth-4, 10)
+
+	for _, b := range history.Buckets {
+		total := b.RxBytes + b.TxBytes
+
+		rxLen := scaleBar(b.RxBytes, maxTotal, barWidth)
+		txLen := scaleBar(total, maxTotal, barWidth) - rxLen
+
+		bar := strings.Repeat("█", rxLen) + strings.Repeat("░", txLen)
+		padding := strings.Repeat(" ", barWidth-rxLen-txLen)
+
+		size := ""
+		if total > 0 {
+			size = utils.FormatBytes(total)
+		}
+
+		fmt.Fprintf(&output, "%-*s │%s%s %*s\n", labelWidth, b.Start.Format(labelLayout), bar, padding, sizeWidth, size)
+	}
+
+	fmt.Fprintf(&output, "\n█ ↓ Downloaded: %s   ░ ↑ Uploaded: %s   Total: %s\n",
+		utils.FormatBytes(totalRx), utils.FormatBytes(totalTx), utils.FormatBytes(totalRx+totalTx))
+
+	return output.String()
+}
+
+// scaleBar returns the bar length of value relative to maxValue.
+// Any non-zero value gets at least one cell so small traffic stays visible.
+func scaleBar(value, maxValue uint64, width int) int {
+	if value == 0 || maxValue == 0 {
+		return 0
+	}
+
+	length := int(float64(value) / float64(maxValue) * float64(width))
+	return min(max(length, 1), width)
+}
@@ -0,0 +1,77 @@
+package netstat
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// LinkInfo describes the physical link of an interface
+type LinkInfo struct {
+	SpeedMbps int    // 0 when unknown (down, wireless, virtual)
+	Bitrate   string // wifi TX bitrate from getWirelessLink, e.g. "866.7 MBit/s"
+	Duplex    string
+	MTU       int
+}
+
+// getLinkInfo reads speed, duplex and MTU from /sys/class/net. Drivers report
+// speed -1 (or fail the read) for down and virtual links; for wifi the caller
+// sets Bitrate from 'iw dev <if> link' instead.
+func getLinkInfo(name string) LinkInfo {
+	var info LinkInfo
+
+	if speed, ok := readSysfsInt(name, "speed"); ok && speed > 0 {
+		info.SpeedMbps = speed
+	}
+
+	if data, err := os.ReadFile(filepath.Join("/sys/class/net", name, "duplex")); err == nil {
+		if duplex := strings.TrimSpace(string(data)); duplex == "full" || duplex == "half" {
+			info.Duplex = duplex
+		}
+	}
+
+	if mtu, ok := readSysfsInt(name, "mtu"); ok {
+		info.MTU = mtu
+	}
+
+	return info
+}
+
+func readSysfsInt(name, file string) (int, bool) {
+	data, err := os.ReadFile(filepath.Join("/sys/class/net", name, file))
+	if err != nil {
+		return 0, false
+	}
+
+	value, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil {
+		return 0, false
+	}
+
+	return value, true
+}
+
+// String formats the link as "1000 Mbps, full duplex, MTU 1500",
+// leaving out whatever is unknown
+func (l LinkInfo) String() string {
+	var parts []string
+
+	switch {
+	case l.SpeedMbps > 0:
+		parts = append(parts, fmt.Sprintf("%d Mbps", l.SpeedMbps))
+	case l.Bitrate != "":
+		parts = append(parts, l.Bitrate)
+	}
+
+	if l.Duplex != "" {
+		parts = append(parts, l.Duplex+" duplex")
+	}
+
+	if l.MTU > 0 {
+		parts = append(parts, fmt.Sprintf("MTU %d", l.MTU))
+	}
+
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,300 @@
+package netstat
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/lvim-tech/ql/pkg/config"
+	"github.com/lvim-tech/ql/pkg/utils"
+)
+
+const logPIDFile = "/tmp/ql_netstat_log.pid"
+
+// logHeader is the first line of the traffic log
+var logHeader = []string{"timestamp", "interface", "rx_bytes", "tx_bytes"}
+
+// logFile returns where the sampler appends its snapshots
+func logFile() string {
+	return filepath.Join(utils.GetDataDir(), "ql", "netstat.csv")
+}
+
+// logSample is one interface's cumulative counters at one moment
+type logSample struct {
+	Time      time.Time
+	Interface string
+	Rx, Tx    uint64
+}
+
+// LogPeriod is the traffic of one report row
+type LogPeriod struct {
+	Period  string `json:"period"`
+	RxBytes uint64 `json:"rx_bytes"`
+	TxBytes uint64 `json:"tx_bytes"`
+}
+
+// startTrafficLog launches 'ql netstat log run' detached
+func startTrafficLog(cfg *Config, notifCfg *config.NotificationConfig) error {
+	if pid, ok := trafficLogPID(); ok {
+		return fmt.Errorf("traffic log is already running (PID %d)", pid)
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		return err
+	}
+
+	if err := utils.StartDetachedProcess(exe, "netstat", "log", "run"); err != nil {
+		return fmt.Errorf("failed to start traffic log: %w", err)
+	}
+
+	utils.NotifyWithConfig(notifCfg, "Traffic Log Started", fmt.Sprintf("Sampling every %ds to:\n%s", cfg.logInterval(), logFile()))
+	return nil
+}
+
+// stopTrafficLog stops the sampler recorded in the PID file
+func stopTrafficLog(notifCfg *config.NotificationConfig) error {
+	pid, ok := trafficLogPID()
+	if !ok {
+		return fmt.Errorf("traffic log is not running")
+	}
+
+	if err := syscall.Kill(pid, syscall.SIGTERM); err != nil {
+		os.Remove(logPIDFile)
+		return fmt.Errorf("failed to stop traffic log: %w", err)
+	}
+
+	utils.NotifyWithConfig(notifCfg, "Traffic Log Stopped", logFile())
+	return nil
+}
+
+// trafficLogPID returns the sampler's PID if it is still alive
+func trafficLogPID() (int, bool) {
+	data, err := os.ReadFile(logPIDFile)
+	if err != nil {
+		return 0, false
+	}
+
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil {
+		os.Remove(logPIDFile)
+		return 0, false
+	}
+
+	if err := syscall.Kill(pid, 0); err != nil {
+		os.Remove(logPIDFile)
+		return 0, false
+	}
+
+	return pid, true
+}
+
+// runTrafficLog is the detached sampler: it appends every interface's
+// counters to the log at log_interval until it receives SIGTERM
+func runTrafficLog(cfg *Config) error {
+	if err := utils.EnsureDir(filepath.Dir(logFile())); err != nil {
+		return fmt.Errorf("failed to create log directory: %w", err)
+	}
+
+	if err := os.WriteFile(logPIDFile, []byte(strconv.Itoa(os.Getpid())), 0644); err != nil {
+		return fmt.Errorf("failed to write PID file: %w", err)
+	}
+	removePID := func() { os.Remove(logPIDFile) }
+	release := utils.OnCleanup(removePID)
+	defer func() {
+		release()
+		removePID()
+	}()
+
+	// 'ql netstat log stop' sends SIGTERM, which cancels it
+	ctx := utils.InterruptContext()
+
+	ticker := time.NewTicker(time.Duration(cfg.logInterval()) * time.Second)
+	defer ticker.Stop()
+
+	for {
+		if err := appendTrafficSample(time.Now()); err != nil {
+			utils.LogDebug("traffic log sample failed", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// appendTrafficSample writes one row per interface, adding the header to a new file
+func appendTrafficSample(now time.Time) error {
+	interfaces, err := getActiveInterfaces()
+	if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(logFile(), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	w := csv.NewWriter(file)
+
+	if info, err := file.Stat(); err == nil && info.Size() == 0 {
+		w.Write(logHeader)
+	}
+
+	for _, iface := range interfaces {
+		rx, tx := readInterfaceCounters(iface)
+		w.Write([]string{
+			now.Format(time.RFC3339),
+			iface,
+			strconv.FormatUint(rx, 10),
+			strconv.FormatUint(tx, 10),
+		})
+	}
+
+	w.Flush()
+	return w.Error()
+}
+
+// readTrafficLog parses the log, skipping the header and malformed rows
+func readTrafficLog(r io.Reader) ([]logSample, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read traffic log: %w", err)
+	}
+
+	var samples []logSample
+	for _, record := range records {
+		if len(record) != len(logHeader) {
+			continue
+		}
+
+		t, err := time.Parse(time.RFC3339, record[0])
+		if err != nil {
+			continue
+		}
+		rx, rxErr := strconv.ParseUint(record[2], 10, 64)
+		tx, txErr := strconv.ParseUint(record[3], 10, 64)
+		if rxErr != nil || txErr != nil {
+			continue
+		}
+
+		samples = append(samples, logSample{Time: t, Interface: record[1], Rx: rx, Tx: tx})
+	}
+
+	return samples, nil
+}
+
+// periodLayouts maps report granularities to the time layout naming a row
+var periodLayouts = map[string]string{
+	"hour":  "2006-01-02 15:00",
+	"day":   "2006-01-02",
+	"month": "2006-01",
+}
+
+// summarizeTrafficLog turns cumulative counters into traffic per period.
+// Each interval between two samples of an interface is credited to the
+// period of the later sample. Counters that went down were reset (reboot,
+// interface re-created), so the new value is the traffic since the reset.
+func summarizeTrafficLog(samples []logSample, granularity string) []LogPeriod {
+	layout := periodLayouts[granularity]
+
+	last := make(map[string]logSample)
+	totals := make(map[string]*LogPeriod)
+
+	for _, s := range samples {
+		prev, seen := last[s.Interface]
+		last[s.Interface] = s
+		if !seen {
+			continue
+		}
+
+		key := s.Time.Local().Format(layout)
+		period, ok := totals[key]
+		if !ok {
+			period = &LogPeriod{Period: key}
+			totals[key] = period
+		}
+
+		period.RxBytes += loggedDelta(prev.Rx, s.Rx)
+		period.TxBytes += loggedDelta(prev.Tx, s.Tx)
+	}
+
+	periods := make([]LogPeriod, 0, len(totals))
+	for _, p := range totals {
+		periods = append(periods, *p)
+	}
+	sort.Slice(periods, func(i, j int) bool {
+		return periods[i].Period < periods[j].Period
+	})
+
+	return periods
+}
+
+// loggedDelta is the traffic between two logged counters; unlike
+// counterDelta a reset counts its new value, since logs span reboots
+func loggedDelta(prev, cur uint64) uint64 {
+	if cur < prev {
+		return cur
+	}
+	return cur - prev
+}
+
+// trafficLogReport reads and summarizes the log for 'ql netstat log report'
+func trafficLogReport(granularity string) ([]LogPeriod, error) {
+	if _, ok := periodLayouts[granularity]; !ok {
+		return nil, fmt.Errorf("invalid report period: %s (use: hour, day, month)", granularity)
+	}
+
+	file, err := os.Open(logFile())
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("no traffic log yet (start one with 'ql netstat log start')")
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	samples, err := readTrafficLog(file)
+	if err != nil {
+		return nil, err
+	}
+
+	return summarizeTrafficLog(samples, granularity), nil
+}
+
+func formatTrafficLogReport(periods []LogPeriod, granularity string) string {
+	var output strings.Builder
+
+	fmt.Fprintf(&output, "Traffic log per %s (%s)\n\n", granularity, logFile())
+
+	if len(periods) == 0 {
+		output.WriteString("Not enough samples yet.\n")
+		return output.String()
+	}
+
+	fmt.Fprintf(&output, "%-18s %12s %12s %12s\n", "Period", "Download", "Upload", "Total")
+
+	var rx, tx uint64
+	for _, p := range periods {
+		fmt.Fprintf(&output, "%-18s %12s %12s %12s\n", p.Period, utils.FormatBytes(p.RxBytes), utils.FormatBytes(p.TxBytes), utils.FormatBytes(p.RxBytes+p.TxBytes))
+		rx += p.RxBytes
+		tx += p.TxBytes
+	}
+
+	fmt.Fprintf(&output, "\n%-18s %12s %12s %12s\n", "All", utils.FormatBytes(rx), utils.FormatBytes(tx), utils.FormatBytes(rx+tx))
+
+	return output.String()
+}
@@ -4,8 +4,8 @@ package netstat
 
 import (
 	"fmt"
-	"os"
-	"os/exec"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
@@ -15,14 +15,24 @@ import (
 	"github.com/mitchellh/mapstructure"
 )
 
+// commandTimeout bounds calls to vnstat/ss/netstat so a stalled tool can't hang ql
+const commandTimeout = 10 * time.Second
+
 func init() {
 	commands.Register(commands.Command{
 		Name:        "netstat",
 		Description: "Network statistics",
+		Config:      commands.ConfigOf(DefaultConfig),
+		Background:  isTrafficLogSampler,
 		Run:         Run,
 	})
 }
 
+// isTrafficLogSampler matches 'ql netstat log run', the detached sampler
+func isTrafficLogSampler(args []string) bool {
+	return len(args) >= 2 && args[0] == "log" && args[1] == "run"
+}
+
 func Run(ctx commands.LauncherContext) commands.CommandResult {
 	cfgInterface := ctx.Config().GetNetstatConfig()
 
@@ -51,7 +61,7 @@ func Run(ctx commands.LauncherContext) commands.CommandResult {
 	// Check for direct command
 	args := ctx.Args()
 	if len(args) > 0 {
-		return executeDirectCommand(args, &notifCfg)
+		return executeDirectCommand(ctx, args, &cfg, &notifCfg)
 	}
 
 	for {
@@ -63,8 +73,11 @@ func Run(ctx commands.LauncherContext) commands.CommandResult {
 
 		options = append(options,
 			"Current Traffic",
+			"Traffic Graph",
 			"Active Connections",
 			"Interface Info",
+			"Latency",
+			"Speed Test",
 		)
 
 		choice, err := ctx.Show(options, "Network Statistics")
@@ -83,10 +96,16 @@ func Run(ctx commands.LauncherContext) commands.CommandResult {
 		switch choice {
 		case "Current Traffic":
 			actionErr = showTrafficMenu(ctx, &cfg, &notifCfg)
+		case "Traffic Graph":
+			actionErr = showGraphMenu(ctx)
 		case "Active Connections":
-			actionErr = showConnections(&notifCfg)
+			actionErr = showConnections("", &notifCfg)
 		case "Interface Info":
 			actionErr = showInterfaceInfo(&notifCfg)
+		case "Latency":
+			actionErr = showLatency(cfg.MonitorHosts, &cfg, &notifCfg)
+		case "Speed Test":
+			actionErr = showSpeedTest(&cfg, &notifCfg)
 		default:
 			utils.ShowErrorNotificationWithConfig(&notifCfg, "Netstat Error", fmt.Sprintf("Unknown choice: %s", choice))
 			continue
@@ -104,7 +123,7 @@ func Run(ctx commands.LauncherContext) commands.CommandResult {
 	}
 }
 
-func executeDirectCommand(args []string, notifCfg *config.NotificationConfig) commands.CommandResult {
+func executeDirectCommand(ctx commands.LauncherContext, args []string, cfg *Config, notifCfg *config.NotificationConfig) commands.CommandResult {
 	action := strings.ToLower(args[0])
 
 	var err error
@@ -115,12 +134,76 @@ func executeDirectCommand(args []string, notifCfg *config.NotificationConfig) co
 		if len(args) > 1 {
 			period = args[1]
 		}
+		if ctx.IsJSONOutput() {
+			return trafficStatsResult(period)
+		}
 		err = showTrafficStats(period, "", notifCfg)
+	case "graph":
+		period := "today"
+		if len(args) > 1 {
+			period = args[1]
+		}
+		if ctx.IsJSONOutput() {
+			return trafficHistoryResult(period)
+		}
+		err = showTrafficGraph(period, "")
 	case "connections", "conn":
-		err = showConnections(notifCfg)
+		family := ""
+		if len(args) > 1 {
+			family, err = parseFamilyFilter(args[1])
+			if err != nil {
+				return commands.CommandResult{Success: false, Error: err}
+			}
+		}
+		err = showConnections(family, notifCfg)
 	case "info":
 		err = showInterfaceInfo(notifCfg)
+	case "top":
+		seconds := 10
+		if len(args) > 1 {
+			seconds, err = strconv.Atoi(args[1])
+			if err != nil || seconds <= 0 {
+				return commands.CommandResult{
+					Success: false,
+					Error:   fmt.Errorf("invalid sampling window: %s (use: ql netstat top <seconds>)", args[1]),
+				}
+			}
+		}
+		err = showTopTalkers(time.Duration(seconds)*time.Second, cfg, notifCfg)
+	case "speed":
+		speed, speedErr := getCurrentSpeed(cfg)
+		if speedErr != nil {
+			return commands.CommandResult{Success: false, Error: speedErr}
+		}
+		if ctx.IsJSONOutput() {
+			return commands.CommandResult{Success: true, Message: speed.String(), Data: speed}
+		}
+		utils.NotifyWithConfig(notifCfg, "Network Speed", speed.String())
+	case "speedtest", "speed-test":
+		if ctx.IsJSONOutput() {
+			return speedTestResult(cfg, notifCfg)
+		}
+		err = showSpeedTest(cfg, notifCfg)
+	case "ping", "latency":
+		// Hosts on the command line replace monitor_hosts
+		hosts := cfg.MonitorHosts
+		if len(args) > 1 {
+			hosts = args[1:]
+		}
+		if ctx.IsJSONOutput() {
+			results, pingErr := runPingWithNotification(hosts, cfg, notifCfg)
+			if pingErr != nil {
+				return commands.CommandResult{Success: false, Error: pingErr}
+			}
+			return commands.CommandResult{Success: true, Data: results}
+		}
+		err = showLatency(hosts, cfg, notifCfg)
+	case "log":
+		return trafficLogCommand(ctx, args[1:], cfg, notifCfg)
 	default:
+		if ctx.IsJSONOutput() {
+			return trafficStatsResult(action)
+		}
 		err = showTrafficStats(action, "", notifCfg)
 	}
 
@@ -130,7 +213,83 @@ func executeDirectCommand(args []string, notifCfg *config.NotificationConfig) co
 	return commands.CommandResult{Success: true}
 }
 
-func showTrafficMenu(ctx commands.LauncherContext, _ *Config, notifCfg *config.NotificationConfig) error {
+// trafficLogCommand handles 'ql netstat log start|stop|report [hour|day|month]'.
+// "run" is internal: it is the detached sampler that 'start' launches.
+func trafficLogCommand(ctx commands.LauncherContext, args []string, cfg *Config, notifCfg *config.NotificationConfig) commands.CommandResult {
+	if len(args) == 0 {
+		return commands.CommandResult{
+			Success: false,
+			Error:   fmt.Errorf("usage: ql netstat log <start|stop|report [hour|day|month]>"),
+		}
+	}
+
+	var err error
+
+	switch strings.ToLower(args[0]) {
+	case "start":
+		err = startTrafficLog(cfg, notifCfg)
+	case "stop":
+		err = stopTrafficLog(notifCfg)
+	case "run":
+		err = runTrafficLog(cfg)
+	case "report":
+		granularity := "day"
+		if len(args) > 1 {
+			granularity = strings.ToLower(args[1])
+		}
+		periods, reportErr := trafficLogReport(granularity)
+		if reportErr != nil {
+			return commands.CommandResult{Success: false, Error: reportErr}
+		}
+		if ctx.IsJSONOutput() {
+			return commands.CommandResult{Success: true, Data: periods}
+		}
+		output := formatTrafficLogReport(periods, granularity)
+		if utils.IsTerminal() {
+			fmt.Print(output)
+		} else {
+			err = displayStatsGUI(output, "Traffic Log")
+		}
+	default:
+		err = fmt.Errorf("unknown log action: %s (use: start, stop, report)", args[0])
+	}
+
+	if err != nil {
+		return commands.CommandResult{Success: false, Error: err}
+	}
+	return commands.CommandResult{Success: true}
+}
+
+// trafficStatsResult returns traffic statistics as structured command data
+func trafficStatsResult(period string) commands.CommandResult {
+	stats, err := GetNetworkStats(period, "")
+	if err != nil {
+		return commands.CommandResult{Success: false, Error: err}
+	}
+
+	return commands.CommandResult{
+		Success: true,
+		Message: fmt.Sprintf("Network statistics - %s", stats.Period),
+		Data:    stats,
+	}
+}
+
+// trafficHistoryResult returns per-hour/per-day traffic as structured command data
+func trafficHistoryResult(period string) commands.CommandResult {
+	history, err := GetTrafficHistory(period, "")
+	if err != nil {
+		return commands.CommandResult{Success: false, Error: err}
+	}
+
+	return commands.CommandResult{
+		Success: true,
+		Message: fmt.Sprintf("Traffic graph - %s", history.Period),
+		Data:    history,
+	}
+}
+
+// selectPeriod asks for one of the predefined traffic periods
+func selectPeriod(ctx commands.LauncherContext, prompt string) (string, error) {
 	options := []string{
 		"← Back",
 		"Today",
@@ -141,34 +300,62 @@ func showTrafficMenu(ctx commands.LauncherContext, _ *Config, notifCfg *config.N
 		"Last 30 Minutes",
 	}
 
-	choice, err := ctx.Show(options, "Traffic Period")
+	choice, err := ctx.Show(options, prompt)
 	if err != nil {
-		return fmt.Errorf("cancelled")
+		return "", fmt.Errorf("cancelled")
 	}
 
-	if choice == "← Back" {
-		return fmt.Errorf("cancelled")
-	}
-
-	var period string
 	switch choice {
 	case "Today":
-		period = "today"
+		return "today", nil
 	case "Yesterday":
-		period = "yesterday"
+		return "yesterday", nil
 	case "This Week":
-		period = "week"
+		return "week", nil
 	case "This Month":
-		period = "month"
+		return "month", nil
 	case "Last Hour":
-		period = "1hour"
+		return "1hour", nil
 	case "Last 30 Minutes":
-		period = "30min"
+		return "30min", nil
+	}
+
+	return "", fmt.Errorf("cancelled")
+}
+
+func showTrafficMenu(ctx commands.LauncherContext, _ *Config, notifCfg *config.NotificationConfig) error {
+	period, err := selectPeriod(ctx, "Traffic Period")
+	if err != nil {
+		return err
 	}
 
 	return showTrafficStats(period, "", notifCfg)
 }
 
+func showGraphMenu(ctx commands.LauncherContext) error {
+	period, err := selectPeriod(ctx, "Graph Period")
+	if err != nil {
+		return err
+	}
+
+	return showTrafficGraph(period, "")
+}
+
+func showTrafficGraph(period string, interfaceName string) error {
+	history, err := GetTrafficHistory(period, interfaceName)
+	if err != nil {
+		return err
+	}
+
+	if utils.IsTerminal() {
+		fmt.Print(formatTrafficGraph(history, graphWidth()))
+	} else {
+		displayStatsGUI(formatTrafficGraph(history, defaultGraphWidth), "Traffic Graph")
+	}
+
+	return nil
+}
+
 func showTrafficStats(period string, interfaceName string, _ *config.NotificationConfig) error {
 	stats, err := GetNetworkStats(period, interfaceName)
 	if err != nil {
@@ -186,12 +373,105 @@ func showTrafficStats(period string, interfaceName string, _ *config.Notificatio
 	return nil
 }
 
-func showConnections(_ *config.NotificationConfig) error {
+func showTopTalkers(window time.Duration, cfg *Config, notifCfg *config.NotificationConfig) error {
+	notifyID := utils.ShowPersistentNotificationWithConfig(notifCfg, "Netstat", fmt.Sprintf("Sampling traffic for %s...", window))
+
+	hosts, err := GetTopTalkers(window, cfg.TopK)
+
+	utils.ClosePersistentNotificationWithConfig(notifCfg, notifyID)
+
+	if err != nil {
+		return err
+	}
+
+	output := formatTopTalkersOutput(hosts, window)
+
+	if utils.IsTerminal() {
+		fmt.Println(output)
+	} else {
+		displayStatsGUI(output, "Top Talkers")
+	}
+
+	return nil
+}
+
+// runSpeedTestWithNotification runs a speed test behind a persistent notification
+// and appends the result to speedtest_log when configured
+func runSpeedTestWithNotification(cfg *Config, notifCfg *config.NotificationConfig) (*SpeedTestResult, error) {
+	notifyID := utils.ShowPersistentNotificationWithConfig(notifCfg, "Netstat", "Running speed test...")
+
+	result, err := RunSpeedTest()
+
+	utils.ClosePersistentNotificationWithConfig(notifCfg, notifyID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.SpeedTestLog != "" {
+		if logErr := appendSpeedTestLog(cfg.SpeedTestLog, result); logErr != nil {
+			utils.ShowErrorNotificationWithConfig(notifCfg, "Netstat Warning", logErr.Error())
+		}
+	}
+
+	return result, nil
+}
+
+func showSpeedTest(cfg *Config, notifCfg *config.NotificationConfig) error {
+	result, err := runSpeedTestWithNotification(cfg, notifCfg)
+	if err != nil {
+		return err
+	}
+
+	output := formatSpeedTestOutput(result)
+
+	if utils.IsTerminal() {
+		fmt.Print(output)
+	} else {
+		displayStatsGUI(output, "Speed Test")
+	}
+
+	return nil
+}
+
+// speedTestResult returns speed test results as structured command data
+func speedTestResult(cfg *Config, notifCfg *config.NotificationConfig) commands.CommandResult {
+	result, err := runSpeedTestWithNotification(cfg, notifCfg)
+	if err != nil {
+		return commands.CommandResult{Success: false, Error: err}
+	}
+
+	return commands.CommandResult{
+		Success: true,
+		Message: fmt.Sprintf("↓ %.2f Mbit/s  ↑ %.2f Mbit/s  ping %.1f ms", result.DownloadMbps, result.UploadMbps, result.PingMs),
+		Data:    result,
+	}
+}
+
+// parseFamilyFilter maps "ipv4"/"v4"/"4" and "ipv6"/"v6"/"6" to a family
+func parseFamilyFilter(arg string) (string, error) {
+	switch strings.ToLower(arg) {
+	case "ipv4", "v4", "4", "inet":
+		return familyIPv4, nil
+	case "ipv6", "v6", "6", "inet6":
+		return familyIPv6, nil
+	}
+	return "", fmt.Errorf("unknown address family: %s (use: ql netstat conn [ipv4|ipv6])", arg)
+}
+
+// showConnections lists active connections, only those of family when set
+func showConnections(family string, _ *config.NotificationConfig) error {
 	connections, err := getActiveConnections()
 	if err != nil {
 		return err
 	}
 
+	if family != "" {
+		connections = slices.DeleteFunc(connections, func(conn Connection) bool {
+			return conn.Family != family
+		})
+	}
+
 	output := formatConnectionsOutput(connections)
 
 	if utils.IsTerminal() {
@@ -215,23 +495,44 @@ func showInterfaceInfo(_ *config.NotificationConfig) error {
 	for _, iface := range interfaces {
 		ifaceType := detectInterfaceType(iface)
 		status := getInterfaceStatus(iface)
-		ip := getInterfaceIP(iface)
+		v4, v6 := getInterfaceAddrs(iface)
 
 		fmt.Fprintf(&output, "┌─ %s (%s - %s)\n", iface, ifaceType, status)
 
-		if ip != "" {
-			fmt.Fprintf(&output, "│  IP: %s\n", ip)
+		if len(v4) > 0 {
+			fmt.Fprintf(&output, "│  IPv4: %s\n", strings.Join(v4, ", "))
+		}
+
+		if len(v6) > 0 {
+			fmt.Fprintf(&output, "│  IPv6: %s\n", strings.Join(v6, ", "))
 		}
 
+		link := getLinkInfo(iface)
+		var wireless WirelessLink
 		if ifaceType == "wifi" {
-			if ssid := getWifiSSID(iface); ssid != "" {
-				fmt.Fprintf(&output, "│  SSID: %s\n", ssid)
+			wireless = getWirelessLink(iface)
+			link.Bitrate = wireless.TxBitrate
+		}
+
+		if linkStr := link.String(); linkStr != "" {
+			fmt.Fprintf(&output, "│  Link: %s\n", linkStr)
+		}
+
+		for _, line := range wireless.infoLines() {
+			fmt.Fprintf(&output, "│  %s\n", line)
+		}
+
+		if ifaceType == "vpn" {
+			if peer := getVPNPeer(iface); peer != "" {
+				fmt.Fprintf(&output, "│  Peer: %s\n", peer)
 			}
 		}
 
 		output.WriteString("\n")
 	}
 
+	output.WriteString(formatNetworkConfig(getDefaultGateways(), getResolverInfo()))
+
 	if utils.IsTerminal() {
 		fmt.Print(output.String())
 	} else {
@@ -241,48 +542,9 @@ func showInterfaceInfo(_ *config.NotificationConfig) error {
 	return nil
 }
 
-// displayStatsGUI shows statistics in GUI dialog (yad/zenity/terminal fallback)
+// displayStatsGUI shows statistics in a text window (see utils.ShowTextReport)
 func displayStatsGUI(data, title string) error {
-	if utils.CommandExists("yad") {
-		tmpFile := "/tmp/ql-netstat-data.txt"
-		if err := os.WriteFile(tmpFile, []byte(data), 0644); err == nil {
-			defer os.Remove(tmpFile)
-			cmd := exec.Command("yad",
-				"--text-info",
-				"--title="+title,
-				"--width=800",
-				"--height=600",
-				"--fontname=Monospace 10",
-				"--filename="+tmpFile)
-			cmd.Env = os.Environ()
-			return cmd.Run()
-		}
-	}
-	if utils.CommandExists("zenity") {
-		tmpFile := "/tmp/ql-netstat-data.txt"
-		if err := os.WriteFile(tmpFile, []byte(data), 0644); err == nil {
-			defer os.Remove(tmpFile)
-			cmd := exec.Command("zenity",
-				"--text-info",
-				"--title="+title,
-				"--width=800",
-				"--height=600",
-				"--filename="+tmpFile)
-			cmd.Env = os.Environ()
-			return cmd.Run()
-		}
-	}
-	terminal := utils.DetectTerminal()
-	if terminal != "" {
-		tmpScript := "/tmp/ql-netstat.sh"
-		script := fmt.Sprintf("#!/bin/sh\ncat << 'EOF'\n%s\nEOF\necho ''\necho 'Press Enter to close... '\nread\n", data)
-		if err := os.WriteFile(tmpScript, []byte(script), 0755); err == nil {
-			defer os.Remove(tmpScript)
-			return exec.Command(terminal, "-e", tmpScript).Run()
-		}
-	}
-	fmt.Println(data)
-	return nil
+	return utils.ShowTextReport(title, data)
 }
 
 func formatTrafficOutput(stats *NetworkStats) string {
@@ -295,6 +557,9 @@ func formatTrafficOutput(stats *NetworkStats) string {
 
 		if iface.Type == "wifi" && iface.SSID != "" {
 			statusStr = fmt.Sprintf("Connected to %s", iface.SSID)
+			if signal := formatSignal(iface.SignalDBm); signal != "" {
+				statusStr += ", " + signal
+			}
 		}
 
 		fmt.Fprintf(&output, "┌─ %s (%s - %s)\n", iface.Name, iface.Type, statusStr)
@@ -303,17 +568,17 @@ func formatTrafficOutput(stats *NetworkStats) string {
 			fmt.Fprintf(&output, "│  IP: %s\n", iface.IP)
 		}
 
-		fmt.Fprintf(&output, "│  ↓ Downloaded:     %s\n", FormatBytes(iface.RxBytes))
-		fmt.Fprintf(&output, "│  ↑ Uploaded:     %s\n", FormatBytes(iface.TxBytes))
-		fmt.Fprintf(&output, "│  Total:          %s\n", FormatBytes(iface.RxBytes+iface.TxBytes))
+		fmt.Fprintf(&output, "│  ↓ Downloaded:     %s\n", utils.FormatBytes(iface.RxBytes))
+		fmt.Fprintf(&output, "│  ↑ Uploaded:     %s\n", utils.FormatBytes(iface.TxBytes))
+		fmt.Fprintf(&output, "│  Total:          %s\n", utils.FormatBytes(iface.RxBytes+iface.TxBytes))
 
 		duration := stats.EndTime.Sub(stats.StartTime)
 		if duration.Seconds() > 0 {
 			avgDownSpeed := float64(iface.RxBytes) / duration.Seconds()
 			avgUpSpeed := float64(iface.TxBytes) / duration.Seconds()
-			fmt.Fprintf(&output, "│  Avg speed:      ↓ %s/s  ↑ %s/s\n",
-				FormatBytes(uint64(avgDownSpeed)),
-				FormatBytes(uint64(avgUpSpeed)))
+			fmt.Fprintf(&output, "│  Avg speed:      ↓ %s  ↑ %s\n",
+				utils.FormatRate(avgDownSpeed),
+				utils.FormatRate(avgUpSpeed))
 		}
 
 		output.WriteString("\n")
@@ -321,16 +586,23 @@ func formatTrafficOutput(stats *NetworkStats) string {
 
 	if len(stats.Interfaces) > 1 {
 		fmt.Fprintf(&output, "Total (all interfaces):\n")
-		fmt.Fprintf(&output, "  ↓ Downloaded:  %s\n", FormatBytes(stats.TotalRx))
-		fmt.Fprintf(&output, "  ↑ Uploaded:    %s\n", FormatBytes(stats.TotalTx))
-		fmt.Fprintf(&output, "  Total:         %s\n", FormatBytes(stats.TotalRx+stats.TotalTx))
+		fmt.Fprintf(&output, "  ↓ Downloaded:  %s\n", utils.FormatBytes(stats.TotalRx))
+		fmt.Fprintf(&output, "  ↑ Uploaded:    %s\n", utils.FormatBytes(stats.TotalTx))
+		fmt.Fprintf(&output, "  Total:         %s\n", utils.FormatBytes(stats.TotalRx+stats.TotalTx))
 	}
 
 	return output.String()
 }
 
+// Address families of a Connection
+const (
+	familyIPv4 = "IPv4"
+	familyIPv6 = "IPv6"
+)
+
 type Connection struct {
 	Protocol   string
+	Family     string
 	LocalAddr  string
 	RemoteAddr string
 	State      string
@@ -341,25 +613,19 @@ func getActiveConnections() ([]Connection, error) {
 	if !utils.CommandExists("ss") && !utils.CommandExists("netstat") {
 		return nil, fmt.Errorf("neither 'ss' nor 'netstat' command found")
 	}
-	var cmd *exec.Cmd
+	tool := "netstat"
 	if utils.CommandExists("ss") {
-		cmd = exec.Command("ss", "-tunap")
-	} else {
-		cmd = exec.Command("netstat", "-tunap")
+		tool = "ss"
 	}
-	output, err := cmd.Output()
+	output, err := utils.RunCommandTimeout(commandTimeout, tool, "-tunap")
 	if err != nil {
-		if utils.CommandExists("ss") {
-			cmd = exec.Command("ss", "-tuna")
-		} else {
-			cmd = exec.Command("netstat", "-tuna")
-		}
-		output, err = cmd.Output()
+		// -p needs privileges on some systems; retry without process info
+		output, err = utils.RunCommandTimeout(commandTimeout, tool, "-tuna")
 		if err != nil {
 			return nil, fmt.Errorf("failed to get connections: %w", err)
 		}
 	}
-	return parseConnections(string(output)), nil
+	return parseConnections(output), nil
 }
 
 func parseConnections(output string) []Connection {
@@ -391,12 +657,58 @@ func parseConnections(output string) []Connection {
 			}
 		}
 		if conn.Protocol != "" {
+			conn.Family = addressFamily(conn.LocalAddr, conn.RemoteAddr)
 			connections = append(connections, conn)
 		}
 	}
 	return connections
 }
 
+// splitHostPort splits an ss/netstat address into host and port. IPv6 hosts
+// come bracketed from ss ("[::1]:631", "[fe80::1]%wlan0:546") and bare from
+// netstat (":::22"); a "%iface" zone is dropped.
+func splitHostPort(addr string) (string, string) {
+	var host, port string
+
+	if rest, ok := strings.CutPrefix(addr, "["); ok {
+		end := strings.Index(rest, "]")
+		if end == -1 {
+			return addr, ""
+		}
+		host = rest[:end]
+		if idx := strings.LastIndex(rest[end:], ":"); idx != -1 {
+			port = rest[end+idx+1:]
+		}
+	} else if idx := strings.LastIndex(addr, ":"); idx != -1 {
+		host, port = addr[:idx], addr[idx+1:]
+	} else {
+		host = addr
+	}
+
+	if zone := strings.Index(host, "%"); zone != -1 {
+		host = host[:zone]
+	}
+
+	return host, port
+}
+
+// addressFamily tells IPv4 from IPv6 by the local address, or the remote one
+// when the local host is a wildcard. ss prints "*" for sockets bound to the
+// IPv6 any-address that also accept IPv4, so those count as IPv6.
+func addressFamily(local, remote string) string {
+	host, _ := splitHostPort(local)
+	if host == "*" || host == "" {
+		if remoteHost, _ := splitHostPort(remote); remoteHost != "*" && remoteHost != "" {
+			host = remoteHost
+		}
+	}
+
+	if host == "*" || strings.Contains(host, ":") {
+		return familyIPv6
+	}
+	return familyIPv4
+}
+
 func formatConnectionsOutput(connections []Connection) string {
 	var output strings.Builder
 
@@ -416,11 +728,21 @@ func formatConnectionsOutput(connections []Connection) string {
 			udpConns++
 		}
 	}
+	v4Conns := 0
+	v6Conns := 0
+	for _, conn := range connections {
+		if conn.Family == familyIPv6 {
+			v6Conns++
+		} else {
+			v4Conns++
+		}
+	}
 	fmt.Fprintf(&output, "TCP:  %d connections\n", tcpConns)
-	fmt.Fprintf(&output, "UDP: %d connections\n\n", udpConns)
+	fmt.Fprintf(&output, "UDP: %d connections\n", udpConns)
+	fmt.Fprintf(&output, "IPv4: %d, IPv6: %d\n\n", v4Conns, v6Conns)
 
 	for _, conn := range connections {
-		fmt.Fprintf(&output, "%-6s %-25s → %-25s", conn.Protocol, conn.LocalAddr, conn.RemoteAddr)
+		fmt.Fprintf(&output, "%-6s %-5s %-25s → %-25s", conn.Protocol, conn.Family, conn.LocalAddr, conn.RemoteAddr)
 		if conn.State != "" {
 			fmt.Fprintf(&output, " [%s]", conn.State)
 		}
@@ -0,0 +1,182 @@
+package netstat
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lvim-tech/ql/pkg/config"
+	"github.com/lvim-tech/ql/pkg/utils"
+)
+
+// HostLatency is the ping summary of one monitored host
+type HostLatency struct {
+	Host        string  `json:"host"`
+	Sent        int     `json:"sent"`
+	Received    int     `json:"received"`
+	LossPercent float64 `json:"loss_percent"`
+	MinMs       float64 `json:"min_ms"`
+	AvgMs       float64 `json:"avg_ms"`
+	MaxMs       float64 `json:"max_ms"`
+	Error       string  `json:"error,omitempty"`
+}
+
+var (
+	// "4 packets transmitted, 4 received, 0% packet loss, time 3004ms"
+	// (busybox: "4 packets transmitted, 4 packets received, 0% packet loss")
+	pingPacketsRe = regexp.MustCompile(`(\d+) packets transmitted, (\d+) (?:packets )?received.*?([\d.]+)% packet loss`)
+	// "rtt min/avg/max/mdev = 9.8/10.2/10.9/0.4 ms" (busybox: "round-trip min/avg/max = ...")
+	pingRttRe = regexp.MustCompile(`min/avg/max\S* = ([\d.]+)/([\d.]+)/([\d.]+)`)
+)
+
+// pingHosts pings every host at once, each with ping_count echoes waiting
+// ping_timeout seconds for a reply, and returns the results in host order
+func pingHosts(hosts []string, cfg *Config) ([]HostLatency, error) {
+	if !utils.CommandExists("ping") {
+		return nil, fmt.Errorf("ping command not found")
+	}
+	if len(hosts) == 0 {
+		return nil, fmt.Errorf("no hosts to ping (set monitor_hosts in [commands.netstat])")
+	}
+
+	count, wait := cfg.pingCount(), cfg.pingTimeout()
+
+	results := make([]HostLatency, len(hosts))
+	var wg sync.WaitGroup
+	for i, host := range hosts {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = pingHost(host, count, wait)
+		}()
+	}
+	wg.Wait()
+
+	return results, nil
+}
+
+// pingHost runs ping the way wifi's connection test does and parses its
+// statistics. A host without replies still gets its loss; one that cannot
+// be resolved gets ping's message as Error.
+func pingHost(host string, count, wait int) HostLatency {
+	result := HostLatency{Host: host}
+
+	// Replies come at one per second, the last may take the full wait
+	limit := time.Duration(count+wait+2) * time.Second
+	ctx, cancel := context.WithTimeout(context.Background(), limit)
+	defer cancel()
+
+	output, err := exec.CommandContext(ctx, "ping",
+		"-c", strconv.Itoa(count),
+		"-W", strconv.Itoa(wait),
+		host).CombinedOutput()
+
+	if parsePingStats(string(output), &result) {
+		return result
+	}
+
+	switch {
+	case ctx.Err() != nil:
+		result.Error = "timed out"
+	case err != nil:
+		result.Error = lastLine(string(output))
+		if result.Error == "" {
+			result.Error = err.Error()
+		}
+	default:
+		result.Error = "unexpected ping output"
+	}
+
+	return result
+}
+
+// parsePingStats fills the packet and round-trip figures from ping's
+// summary, reporting whether the packet line was found
+func parsePingStats(output string, result *HostLatency) bool {
+	packets := pingPacketsRe.FindStringSubmatch(output)
+	if packets == nil {
+		return false
+	}
+
+	result.Sent, _ = strconv.Atoi(packets[1])
+	result.Received, _ = strconv.Atoi(packets[2])
+	result.LossPercent, _ = strconv.ParseFloat(packets[3], 64)
+
+	if rtt := pingRttRe.FindStringSubmatch(output); rtt != nil {
+		result.MinMs, _ = strconv.ParseFloat(rtt[1], 64)
+		result.AvgMs, _ = strconv.ParseFloat(rtt[2], 64)
+		result.MaxMs, _ = strconv.ParseFloat(rtt[3], 64)
+	}
+
+	if result.Received == 0 {
+		result.Error = "unreachable"
+	}
+
+	return true
+}
+
+func lastLine(s string) string {
+	lines := strings.Split(strings.TrimSpace(s), "\n")
+	return strings.TrimSpace(lines[len(lines)-1])
+}
+
+func formatLatencyOutput(results []HostLatency) string {
+	var output strings.Builder
+
+	output.WriteString("Latency\n\n")
+	fmt.Fprintf(&output, "%-28s %6s %9s %9s %9s\n", "Host", "Loss", "Min", "Avg", "Max")
+
+	for _, r := range results {
+		host := r.Host
+		if len(host) > 28 {
+			host = host[:25] + "..."
+		}
+
+		if r.Received == 0 {
+			loss := "-"
+			if r.Sent > 0 {
+				loss = fmt.Sprintf("%.0f%%", r.LossPercent)
+			}
+			fmt.Fprintf(&output, "%-28s %6s  %s\n", host, loss, r.Error)
+			continue
+		}
+
+		fmt.Fprintf(&output, "%-28s %5.0f%% %6.1f ms %6.1f ms %6.1f ms\n",
+			host, r.LossPercent, r.MinMs, r.AvgMs, r.MaxMs)
+	}
+
+	return output.String()
+}
+
+// runPingWithNotification pings behind a persistent notification
+func runPingWithNotification(hosts []string, cfg *Config, notifCfg *config.NotificationConfig) ([]HostLatency, error) {
+	notifyID := utils.ShowPersistentNotificationWithConfig(notifCfg, "Netstat", fmt.Sprintf("Pinging %d hosts...", len(hosts)))
+
+	results, err := pingHosts(hosts, cfg)
+
+	utils.ClosePersistentNotificationWithConfig(notifCfg, notifyID)
+
+	return results, err
+}
+
+func showLatency(hosts []string, cfg *Config, notifCfg *config.NotificationConfig) error {
+	results, err := runPingWithNotification(hosts, cfg, notifCfg)
+	if err != nil {
+		return err
+	}
+
+	output := formatLatencyOutput(results)
+
+	if utils.IsTerminal() {
+		fmt.Print(output)
+	} else {
+		displayStatsGUI(output, "Latency")
+	}
+
+	return nil
+}
@@ -0,0 +1,180 @@
+package netstat
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/lvim-tech/ql/pkg/utils"
+)
+
+// Gateway is the next hop of a default route
+type Gateway struct {
+	Address   string
+	Interface string
+}
+
+func (g Gateway) String() string {
+	if g.Interface == "" {
+		return g.Address
+	}
+	return fmt.Sprintf("%s (%s)", g.Address, g.Interface)
+}
+
+// ResolverInfo is the DNS configuration and where it was read from
+type ResolverInfo struct {
+	Servers []string
+	Domains []string
+	Source  string // "resolvectl" or "/etc/resolv.conf"
+}
+
+// getDefaultGateways returns the IPv4 and IPv6 default routes
+func getDefaultGateways() []Gateway {
+	var gateways []Gateway
+
+	for _, family := range []string{"-4", "-6"} {
+		output, err := utils.RunCommandTimeout(commandTimeout, "ip", family, "route", "show", "default")
+		if err != nil {
+			continue
+		}
+		gateways = append(gateways, parseDefaultRoutes(output)...)
+	}
+
+	return gateways
+}
+
+// parseDefaultRoutes parses 'ip route show default':
+//
+//	default via 192.168.1.1 dev wlan0 proto dhcp src 192.168.1.20 metric 600
+//	default dev wg0 scope link
+func parseDefaultRoutes(output string) []Gateway {
+	var gateways []Gateway
+
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 || fields[0] != "default" {
+			continue
+		}
+
+		var g Gateway
+		for i := 1; i+1 < len(fields); i++ {
+			switch fields[i] {
+			case "via":
+				g.Address = fields[i+1]
+			case "dev":
+				g.Interface = fields[i+1]
+			}
+		}
+
+		// A route without "via" sends everything straight into the device (VPNs)
+		if g.Address == "" {
+			g.Address = "direct"
+		}
+
+		gateways = append(gateways, g)
+	}
+
+	return gateways
+}
+
+// getResolverInfo prefers resolvectl, since with systemd-resolved
+// /etc/resolv.conf only lists the local stub (127.0.0.53)
+func getResolverInfo() ResolverInfo {
+	if utils.CommandExists("resolvectl") {
+		if servers, err := utils.RunCommandTimeout(commandTimeout, "resolvectl", "dns"); err == nil {
+			info := ResolverInfo{Servers: parseResolvectl(servers), Source: "resolvectl"}
+			if domains, err := utils.RunCommandTimeout(commandTimeout, "resolvectl", "domain"); err == nil {
+				info.Domains = parseResolvectl(domains)
+			}
+			if len(info.Servers) > 0 {
+				return info
+			}
+		}
+	}
+
+	data, err := os.ReadFile("/etc/resolv.conf")
+	if err != nil {
+		return ResolverInfo{}
+	}
+
+	info := parseResolvConf(string(data))
+	info.Source = "/etc/resolv.conf"
+	return info
+}
+
+// parseResolvectl collects the values of 'resolvectl dns' or 'resolvectl
+// domain' across the global and per-link lines, without duplicates:
+//
+//	Global: 1.1.1.1
+//	Link 3 (wlan0): 192.168.1.1 fe80::1%3
+func parseResolvectl(output string) []string {
+	var values []string
+
+	for _, line := range strings.Split(output, "\n") {
+		_, rest, found := strings.Cut(line, ": ")
+		if !found {
+			continue
+		}
+		for _, value := range strings.Fields(rest) {
+			// "~." only routes all lookups to a link; it is not a search domain
+			if value == "~." || slices.Contains(values, value) {
+				continue
+			}
+			values = append(values, value)
+		}
+	}
+
+	return values
+}
+
+// parseResolvConf reads nameserver, search and domain lines
+func parseResolvConf(content string) ResolverInfo {
+	var info ResolverInfo
+
+	for _, line := range strings.Split(content, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || strings.HasPrefix(fields[0], "#") || strings.HasPrefix(fields[0], ";") {
+			continue
+		}
+
+		switch fields[0] {
+		case "nameserver":
+			info.Servers = append(info.Servers, fields[1])
+		case "search", "domain":
+			for _, domain := range fields[1:] {
+				if !slices.Contains(info.Domains, domain) {
+					info.Domains = append(info.Domains, domain)
+				}
+			}
+		}
+	}
+
+	return info
+}
+
+// formatNetworkConfig renders gateways and resolvers in the interface tree style
+func formatNetworkConfig(gateways []Gateway, resolver ResolverInfo) string {
+	var output strings.Builder
+
+	output.WriteString("┌─ Network Config\n")
+
+	if len(gateways) == 0 {
+		output.WriteString("│  Gateway: none (no default route)\n")
+	}
+	for _, g := range gateways {
+		fmt.Fprintf(&output, "│  Gateway: %s\n", g)
+	}
+
+	if len(resolver.Servers) > 0 {
+		fmt.Fprintf(&output, "│  DNS: %s (%s)\n", strings.Join(resolver.Servers, ", "), resolver.Source)
+	} else {
+		output.WriteString("│  DNS: none found\n")
+	}
+
+	if len(resolver.Domains) > 0 {
+		fmt.Fprintf(&output, "│  Search: %s\n", strings.Join(resolver.Domains, ", "))
+	}
+
+	return output.String()
+}
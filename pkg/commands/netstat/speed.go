@@ -0,0 +1,88 @@
+package netstat
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/lvim-tech/ql/pkg/utils"
+)
+
+// speedSampleInterval is the window used for instantaneous rates
+const speedSampleInterval = time.Second
+
+// InterfaceSpeed holds instantaneous transfer rates in bytes per second
+type InterfaceSpeed struct {
+	Interface string  `json:"interface"`
+	RxRate    float64 `json:"rx_bytes_per_sec"`
+	TxRate    float64 `json:"tx_bytes_per_sec"`
+}
+
+// String returns the rates formatted for a notification
+func (s *InterfaceSpeed) String() string {
+	return fmt.Sprintf("%s\n↓ %s   ↑ %s", s.Interface, utils.FormatRate(s.RxRate), utils.FormatRate(s.TxRate))
+}
+
+// primaryInterface returns primary_interface from config, or the first
+// interface that is up, preferring wifi/ethernet over virtual ones
+func primaryInterface(cfg *Config) (string, error) {
+	if cfg.PrimaryInterface != "" {
+		if !utils.FileExists("/sys/class/net/" + cfg.PrimaryInterface) {
+			return "", fmt.Errorf("interface %s not found", cfg.PrimaryInterface)
+		}
+		return cfg.PrimaryInterface, nil
+	}
+
+	interfaces, err := getActiveInterfaces()
+	if err != nil {
+		return "", err
+	}
+
+	var fallback string
+	for _, iface := range interfaces {
+		if getInterfaceStatus(iface) != "up" {
+			continue
+		}
+
+		switch detectInterfaceType(iface) {
+		case "wifi", "ethernet":
+			return iface, nil
+		}
+
+		if fallback == "" {
+			fallback = iface
+		}
+	}
+
+	if fallback == "" {
+		return "", fmt.Errorf("no active network interface found")
+	}
+
+	return fallback, nil
+}
+
+// measureSpeed samples interface counters twice and returns the rates between them
+func measureSpeed(iface string, interval time.Duration) *InterfaceSpeed {
+	rx1, tx1 := readInterfaceCounters(iface)
+	start := time.Now()
+
+	time.Sleep(interval)
+
+	rx2, tx2 := readInterfaceCounters(iface)
+	elapsed := time.Since(start).Seconds()
+
+	return &InterfaceSpeed{
+		Interface: iface,
+		RxRate:    float64(counterDelta(rx1, rx2)) / elapsed,
+		TxRate:    float64(counterDelta(tx1, tx2)) / elapsed,
+	}
+}
+
+// getCurrentSpeed measures the primary interface over speedSampleInterval
+func getCurrentSpeed(cfg *Config) (*InterfaceSpeed, error) {
+	iface, err := primaryInterface(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return measureSpeed(iface, speedSampleInterval), nil
+}
@@ -0,0 +1,204 @@
+package netstat
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/lvim-tech/ql/pkg/utils"
+)
+
+// SpeedTestResult holds the outcome of a single throughput test
+type SpeedTestResult struct {
+	Tool         string    `json:"tool"`
+	Server       string    `json:"server,omitempty"`
+	DownloadMbps float64   `json:"download_mbps"`
+	UploadMbps   float64   `json:"upload_mbps"`
+	PingMs       float64   `json:"ping_ms"`
+	Timestamp    time.Time `json:"timestamp"`
+}
+
+// speedTestTools lists supported CLIs in order of preference
+var speedTestTools = []string{"speedtest-cli", "speedtest", "librespeed-cli"}
+
+// detectSpeedTestTool returns the first installed speed test CLI
+func detectSpeedTestTool() (string, error) {
+	for _, tool := range speedTestTools {
+		if utils.CommandExists(tool) {
+			return tool, nil
+		}
+	}
+	return "", fmt.Errorf("no speed test tool found (install one of: %s)", strings.Join(speedTestTools, ", "))
+}
+
+// RunSpeedTest runs the installed speed test CLI and parses its JSON output.
+// This usually takes 20-60 seconds.
+func RunSpeedTest() (*SpeedTestResult, error) {
+	tool, err := detectSpeedTestTool()
+	if err != nil {
+		return nil, err
+	}
+
+	ookla := tool == "speedtest" && isOoklaSpeedtest()
+
+	var output []byte
+
+	switch {
+	case ookla:
+		output, err = exec.Command(tool, "--format=json", "--accept-license", "--accept-gdpr").Output()
+	default:
+		// speedtest-cli (and its 'speedtest' alias) and librespeed-cli share the flag
+		output, err = exec.Command(tool, "--json").Output()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("speed test failed: %w", err)
+	}
+
+	var result *SpeedTestResult
+	switch {
+	case tool == "librespeed-cli":
+		result, err = parseLibrespeedOutput(output)
+	case ookla:
+		result, err = parseOoklaOutput(output)
+	default:
+		result, err = parseSpeedtestCLIOutput(output)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result.Tool = tool
+	result.Timestamp = time.Now()
+
+	return result, nil
+}
+
+// isOoklaSpeedtest tells the official Ookla client apart from the speedtest-cli alias
+func isOoklaSpeedtest() bool {
+	output, err := exec.Command("speedtest", "--version").Output()
+	if err != nil {
+		return false
+	}
+	return strings.Contains(string(output), "Ookla")
+}
+
+// parseSpeedtestCLIOutput parses 'speedtest-cli --json' (speeds in bit/s)
+func parseSpeedtestCLIOutput(output []byte) (*SpeedTestResult, error) {
+	var data struct {
+		Download float64 `json:"download"`
+		Upload   float64 `json:"upload"`
+		Ping     float64 `json:"ping"`
+		Server   struct {
+			Sponsor string `json:"sponsor"`
+			Name    string `json:"name"`
+		} `json:"server"`
+	}
+
+	if err := json.Unmarshal(output, &data); err != nil {
+		return nil, fmt.Errorf("failed to parse speedtest-cli output: %w", err)
+	}
+
+	return &SpeedTestResult{
+		Server:       strings.TrimSpace(data.Server.Sponsor + " " + data.Server.Name),
+		DownloadMbps: data.Download / 1e6,
+		UploadMbps:   data.Upload / 1e6,
+		PingMs:       data.Ping,
+	}, nil
+}
+
+// parseOoklaOutput parses 'speedtest --format=json' (bandwidth in byte/s)
+func parseOoklaOutput(output []byte) (*SpeedTestResult, error) {
+	var data struct {
+		Ping struct {
+			Latency float64 `json:"latency"`
+		} `json:"ping"`
+		Download struct {
+			Bandwidth float64 `json:"bandwidth"`
+		} `json:"download"`
+		Upload struct {
+			Bandwidth float64 `json:"bandwidth"`
+		} `json:"upload"`
+		Server struct {
+			Name     string `json:"name"`
+			Location string `json:"location"`
+		} `json:"server"`
+	}
+
+	if err := json.Unmarshal(output, &data); err != nil {
+		return nil, fmt.Errorf("failed to parse speedtest output: %w", err)
+	}
+
+	return &SpeedTestResult{
+		Server:       strings.TrimSpace(data.Server.Name + " " + data.Server.Location),
+		DownloadMbps: data.Download.Bandwidth * 8 / 1e6,
+		UploadMbps:   data.Upload.Bandwidth * 8 / 1e6,
+		PingMs:       data.Ping.Latency,
+	}, nil
+}
+
+// parseLibrespeedOutput parses 'librespeed-cli --json' (speeds already in Mbit/s)
+func parseLibrespeedOutput(output []byte) (*SpeedTestResult, error) {
+	var data []struct {
+		Ping     float64 `json:"ping"`
+		Download float64 `json:"download"`
+		Upload   float64 `json:"upload"`
+		Server   struct {
+			Name string `json:"name"`
+		} `json:"server"`
+	}
+
+	if err := json.Unmarshal(output, &data); err != nil {
+		return nil, fmt.Errorf("failed to parse librespeed-cli output: %w", err)
+	}
+
+	if len(data) == 0 {
+		return nil, fmt.Errorf("librespeed-cli returned no results")
+	}
+
+	return &SpeedTestResult{
+		Server:       data[0].Server.Name,
+		DownloadMbps: data[0].Download,
+		UploadMbps:   data[0].Upload,
+		PingMs:       data[0].Ping,
+	}, nil
+}
+
+// appendSpeedTestLog appends a tab-separated result line to the log file
+func appendSpeedTestLog(path string, result *SpeedTestResult) error {
+	logPath := utils.ExpandPath(path)
+
+	f, err := os.OpenFile(logPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open speed test log: %w", err)
+	}
+	defer f.Close()
+
+	_, err = fmt.Fprintf(f, "%s\t%.2f\t%.2f\t%.1f\t%s\n",
+		result.Timestamp.Format("2006-01-02 15:04:05"),
+		result.DownloadMbps,
+		result.UploadMbps,
+		result.PingMs,
+		result.Server)
+
+	return err
+}
+
+func formatSpeedTestOutput(result *SpeedTestResult) string {
+	var output strings.Builder
+
+	output.WriteString("Speed Test\n\n")
+	fmt.Fprintf(&output, "↓ Download:  %.2f Mbit/s\n", result.DownloadMbps)
+	fmt.Fprintf(&output, "↑ Upload:    %.2f Mbit/s\n", result.UploadMbps)
+	fmt.Fprintf(&output, "  Ping:      %.1f ms\n", result.PingMs)
+
+	if result.Server != "" {
+		fmt.Fprintf(&output, "\nServer:     %s\n", result.Server)
+	}
+	fmt.Fprintf(&output, "Tool:       %s\n", result.Tool)
+	fmt.Fprintf(&output, "Generated:  %s\n", result.Timestamp.Format("2006-01-02 15:04:05"))
+
+	return output.String()
+}
@@ -7,6 +7,7 @@ import (
 	"os/exec"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -16,27 +17,28 @@ import (
 
 // InterfaceStats represents network statistics for an interface
 type InterfaceStats struct {
-	Name      string
-	Type      string // wifi, ethernet, vpn, loopback
-	Status    string // connected, disconnected
-	SSID      string // for WiFi
-	IP        string
-	RxBytes   uint64
-	TxBytes   uint64
-	RxPackets uint64
-	TxPackets uint64
-	StartTime time.Time
-	EndTime   time.Time
+	Name      string    `json:"name"`
+	Type      string    `json:"type"`                 // wifi, ethernet, vpn, loopback
+	Status    string    `json:"status"`               // connected, disconnected
+	SSID      string    `json:"ssid,omitempty"`       // for WiFi
+	SignalDBm int       `json:"signal_dbm,omitempty"` // for WiFi, 0 when unknown
+	IP        string    `json:"ip,omitempty"`
+	RxBytes   uint64    `json:"rx_bytes"`
+	TxBytes   uint64    `json:"tx_bytes"`
+	RxPackets uint64    `json:"rx_packets"`
+	TxPackets uint64    `json:"tx_packets"`
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
 }
 
 // NetworkStats represents statistics for all interfaces
 type NetworkStats struct {
-	Interfaces []InterfaceStats
-	TotalRx    uint64
-	TotalTx    uint64
-	Period     string
-	StartTime  time.Time
-	EndTime    time.Time
+	Interfaces []InterfaceStats `json:"interfaces"`
+	TotalRx    uint64           `json:"total_rx"`
+	TotalTx    uint64           `json:"total_tx"`
+	Period     string           `json:"period"`
+	StartTime  time.Time        `json:"start_time"`
+	EndTime    time.Time        `json:"end_time"`
 }
 
 // GetNetworkStats retrieves network statistics for the given period
@@ -173,14 +175,13 @@ func vnstatHasData() bool {
 		return false
 	}
 
-	cmd := exec.Command("vnstat", "--json", "h")
-	output, err := cmd.Output()
+	output, err := utils.RunCommandTimeout(commandTimeout, "vnstat", "--json", "h")
 	if err != nil {
 		return false
 	}
 
 	var data map[string]any
-	if err := json.Unmarshal(output, &data); err != nil {
+	if err := json.Unmarshal([]byte(output), &data); err != nil {
 		return false
 	}
 
@@ -200,8 +201,7 @@ func getVnstatStats(start, end time.Time, interfaceName string) (*NetworkStats,
 		args = append(args, "-i", interfaceName)
 	}
 
-	cmd := exec.Command("vnstat", args...)
-	output, err := cmd.Output()
+	output, err := utils.RunCommandTimeout(commandTimeout, "vnstat", args...)
 	if err != nil {
 		return nil, fmt.Errorf("vnstat query failed: %w", err)
 	}
@@ -226,7 +226,7 @@ func getVnstatStats(start, end time.Time, interfaceName string) (*NetworkStats,
 		} `json:"interfaces"`
 	}
 
-	if err := json.Unmarshal(output, &vnstatData); err != nil {
+	if err := json.Unmarshal([]byte(output), &vnstatData); err != nil {
 		return nil, fmt.Errorf("failed to parse vnstat data: %w", err)
 	}
 
@@ -246,7 +246,9 @@ func getVnstatStats(start, end time.Time, interfaceName string) (*NetworkStats,
 		}
 
 		if ifaceStats.Type == "wifi" {
-			ifaceStats.SSID = getWifiSSID(iface.Name)
+			wireless := getWirelessLink(iface.Name)
+			ifaceStats.SSID = wireless.SSID
+			ifaceStats.SignalDBm = wireless.SignalDBm
 		}
 
 		ifaceStats.IP = getInterfaceIP(iface.Name)
@@ -296,22 +298,14 @@ func getSysStats(start, end time.Time, interfaceName string) (*NetworkStats, err
 		}
 
 		if ifaceStats.Type == "wifi" {
-			ifaceStats.SSID = getWifiSSID(iface)
+			wireless := getWirelessLink(iface)
+			ifaceStats.SSID = wireless.SSID
+			ifaceStats.SignalDBm = wireless.SignalDBm
 		}
 
 		ifaceStats.IP = getInterfaceIP(iface)
 
-		// Read from /sys/class/net
-		rxPath := filepath.Join("/sys/class/net", iface, "statistics", "rx_bytes")
-		txPath := filepath.Join("/sys/class/net", iface, "statistics", "tx_bytes")
-
-		if rxData, err := os.ReadFile(rxPath); err == nil {
-			ifaceStats.RxBytes, _ = strconv.ParseUint(strings.TrimSpace(string(rxData)), 10, 64)
-		}
-
-		if txData, err := os.ReadFile(txPath); err == nil {
-			ifaceStats.TxBytes, _ = strconv.ParseUint(strings.TrimSpace(string(txData)), 10, 64)
-		}
+		ifaceStats.RxBytes, ifaceStats.TxBytes = readInterfaceCounters(iface)
 
 		stats.Interfaces = append(stats.Interfaces, ifaceStats)
 		stats.TotalRx += ifaceStats.RxBytes
@@ -321,6 +315,24 @@ func getSysStats(start, end time.Time, interfaceName string) (*NetworkStats, err
 	return stats, nil
 }
 
+// readInterfaceCounters reads cumulative rx/tx bytes from /sys/class/net
+func readInterfaceCounters(iface string) (uint64, uint64) {
+	var rx, tx uint64
+
+	rxPath := filepath.Join("/sys/class/net", iface, "statistics", "rx_bytes")
+	txPath := filepath.Join("/sys/class/net", iface, "statistics", "tx_bytes")
+
+	if rxData, err := os.ReadFile(rxPath); err == nil {
+		rx, _ = strconv.ParseUint(strings.TrimSpace(string(rxData)), 10, 64)
+	}
+
+	if txData, err := os.ReadFile(txPath); err == nil {
+		tx, _ = strconv.ParseUint(strings.TrimSpace(string(txData)), 10, 64)
+	}
+
+	return rx, tx
+}
+
 func getActiveInterfaces() ([]string, error) {
 	entries, err := os.ReadDir("/sys/class/net")
 	if err != nil {
@@ -339,15 +351,20 @@ func getActiveInterfaces() ([]string, error) {
 }
 
 func detectInterfaceType(name string) string {
+	if ifaceType := sysfsInterfaceType(name); ifaceType != "" {
+		return ifaceType
+	}
 	if strings.HasPrefix(name, "wl") || strings.HasPrefix(name, "wlan") {
 		return "wifi"
 	}
+	for _, prefix := range vpnPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return "vpn"
+		}
+	}
 	if strings.HasPrefix(name, "eth") || strings.HasPrefix(name, "en") {
 		return "ethernet"
 	}
-	if strings.HasPrefix(name, "tun") || strings.HasPrefix(name, "vpn") {
-		return "vpn"
-	}
 	if name == "lo" {
 		return "loopback"
 	}
@@ -382,31 +399,58 @@ func getWifiSSID(interfaceName string) string {
 	return strings.TrimSpace(string(output))
 }
 
+// getInterfaceIP returns the first IPv4 address, or the first IPv6 address
+// on v6-only interfaces
 func getInterfaceIP(name string) string {
+	v4, v6 := getInterfaceAddrs(name)
+	if len(v4) > 0 {
+		return v4[0]
+	}
+	if len(v6) > 0 {
+		return v6[0]
+	}
+	return ""
+}
+
+// getInterfaceAddrs returns the IPv4 and IPv6 addresses of an interface
+// without prefix length. Link-local IPv6 addresses (fe80::/10) are left out.
+func getInterfaceAddrs(name string) (v4, v6 []string) {
 	if !utils.CommandExists("ip") {
-		return ""
+		return nil, nil
 	}
 
-	cmd := exec.Command("ip", "-4", "addr", "show", name)
+	cmd := exec.Command("ip", "addr", "show", name)
 	output, err := cmd.Output()
 	if err != nil {
-		return ""
+		return nil, nil
 	}
 
-	// Parse IP from:  "inet 192.168.1.100/24 brd..."
-	for line := range strings.SplitSeq(string(output), "\n") {
-		line = strings.TrimSpace(line)
-		if strings.HasPrefix(line, "inet ") {
-			fields := strings.Fields(line)
-			if len(fields) >= 2 {
-				// Remove subnet mask
-				ip := strings.Split(fields[1], "/")[0]
-				return ip
+	return parseInterfaceAddrs(string(output))
+}
+
+// parseInterfaceAddrs parses 'ip addr show' lines such as
+// "inet 192.168.1.100/24 brd ..." and "inet6 2001:db8::5/64 scope global ..."
+func parseInterfaceAddrs(output string) (v4, v6 []string) {
+	for line := range strings.SplitSeq(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+
+		// Remove subnet mask
+		addr := strings.Split(fields[1], "/")[0]
+
+		switch fields[0] {
+		case "inet":
+			v4 = append(v4, addr)
+		case "inet6":
+			if !slices.Contains(fields, "link") {
+				v6 = append(v6, addr)
 			}
 		}
 	}
 
-	return ""
+	return v4, v6
 }
 
 func formatPeriod(start, end time.Time) string {
@@ -438,19 +482,3 @@ func formatDuration(d time.Duration) string {
 
 	return fmt.Sprintf("%dm", minutes)
 }
-
-// FormatBytes converts bytes to human-readable format
-func FormatBytes(bytes uint64) string {
-	const unit = 1024
-	if bytes < unit {
-		return fmt.Sprintf("%d B", bytes)
-	}
-
-	div, exp := uint64(unit), 0
-	for n := bytes / unit; n >= unit; n /= unit {
-		div *= unit
-		exp++
-	}
-
-	return fmt.Sprintf("%.1f %cB", float64(bytes)/float64(div), "KMGTPE"[exp])
-}
@@ -0,0 +1,193 @@
+package netstat
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lvim-tech/ql/pkg/utils"
+)
+
+// HostTraffic represents bytes exchanged with a single remote host
+type HostTraffic struct {
+	Host    string
+	RxBytes uint64
+	TxBytes uint64
+}
+
+// socketCounters holds the cumulative byte counters of a single TCP socket
+type socketCounters struct {
+	peer    string
+	rxBytes uint64
+	txBytes uint64
+}
+
+// GetTopTalkers samples TCP socket counters twice over the given window
+// and returns remote hosts ranked by total bytes exchanged
+func GetTopTalkers(window time.Duration, topK int) ([]HostTraffic, error) {
+	if !utils.CommandExists("ss") {
+		return nil, fmt.Errorf("'ss' command not found (required for top talkers)")
+	}
+
+	before, err := sampleSocketCounters()
+	if err != nil {
+		return nil, err
+	}
+
+	time.Sleep(window)
+
+	after, err := sampleSocketCounters()
+	if err != nil {
+		return nil, err
+	}
+
+	hosts := make(map[string]*HostTraffic)
+
+	for key, cur := range after {
+		rx, tx := cur.rxBytes, cur.txBytes
+
+		// Sockets present in both samples only count what changed during the window;
+		// sockets opened during the window count everything they transferred
+		if prev, ok := before[key]; ok {
+			rx = counterDelta(prev.rxBytes, cur.rxBytes)
+			tx = counterDelta(prev.txBytes, cur.txBytes)
+		}
+
+		if rx == 0 && tx == 0 {
+			continue
+		}
+
+		host := peerHost(cur.peer)
+		entry, ok := hosts[host]
+		if !ok {
+			entry = &HostTraffic{Host: host}
+			hosts[host] = entry
+		}
+		entry.RxBytes += rx
+		entry.TxBytes += tx
+	}
+
+	var result []HostTraffic
+	for _, entry := range hosts {
+		result = append(result, *entry)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		ti := result[i].RxBytes + result[i].TxBytes
+		tj := result[j].RxBytes + result[j].TxBytes
+		if ti != tj {
+			return ti > tj
+		}
+		return result[i].Host < result[j].Host
+	})
+
+	if topK > 0 && len(result) > topK {
+		result = result[:topK]
+	}
+
+	return result, nil
+}
+
+// sampleSocketCounters runs 'ss -tin' and returns byte counters keyed by local+peer address
+func sampleSocketCounters() (map[string]socketCounters, error) {
+	output, err := utils.RunCommandTimeout(commandTimeout, "ss", "-tin")
+	if err != nil {
+		return nil, fmt.Errorf("failed to sample sockets: %w", err)
+	}
+
+	return parseSocketCounters(output), nil
+}
+
+// parseSocketCounters parses 'ss -tin' output, where every socket line is
+// followed by an indented info line containing bytes_sent/bytes_received
+func parseSocketCounters(output string) map[string]socketCounters {
+	result := make(map[string]socketCounters)
+
+	var key, peer string
+
+	for i, line := range strings.Split(output, "\n") {
+		if i == 0 || strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		// Socket line: State Recv-Q Send-Q Local:Port Peer:Port
+		if line[0] != ' ' && line[0] != '\t' {
+			fields := strings.Fields(line)
+			if len(fields) < 5 {
+				key, peer = "", ""
+				continue
+			}
+			key = fields[3] + " " + fields[4]
+			peer = fields[4]
+			continue
+		}
+
+		if key == "" {
+			continue
+		}
+
+		counters := socketCounters{peer: peer}
+		for _, field := range strings.Fields(line) {
+			if val, found := strings.CutPrefix(field, "bytes_received:"); found {
+				counters.rxBytes, _ = strconv.ParseUint(val, 10, 64)
+			} else if val, found := strings.CutPrefix(field, "bytes_sent:"); found {
+				counters.txBytes, _ = strconv.ParseUint(val, 10, 64)
+			}
+		}
+
+		result[key] = counters
+		key, peer = "", ""
+	}
+
+	return result
+}
+
+// counterDelta returns the increase between two samples, treating a reset as zero
+func counterDelta(prev, cur uint64) uint64 {
+	if cur < prev {
+		return 0
+	}
+	return cur - prev
+}
+
+// peerHost strips the port (and IPv6 brackets / interface scope) from an ss peer address
+func peerHost(addr string) string {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+
+	if idx := strings.Index(host, "%"); idx != -1 {
+		host = host[:idx]
+	}
+
+	return host
+}
+
+func formatTopTalkersOutput(hosts []HostTraffic, window time.Duration) string {
+	var output strings.Builder
+
+	fmt.Fprintf(&output, "Top Talkers - last %s\n\n", window)
+
+	if len(hosts) == 0 {
+		output.WriteString("No traffic observed during the sampling window.\n")
+		return output.String()
+	}
+
+	fmt.Fprintf(&output, "%-3s %-40s %12s %12s %12s\n", "#", "Remote Host", "↓ Received", "↑ Sent", "Total")
+
+	for i, host := range hosts {
+		fmt.Fprintf(&output, "%-3d %-40s %12s %12s %12s\n",
+			i+1,
+			host.Host,
+			utils.FormatBytes(host.RxBytes),
+			utils.FormatBytes(host.TxBytes),
+			utils.FormatBytes(host.RxBytes+host.TxBytes))
+	}
+
+	fmt.Fprintf(&output, "\nGenerated:  %s\n", time.Now().Format("2006-01-02 15:04:05"))
+	return output.String()
+}
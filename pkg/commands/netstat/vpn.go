@@ -0,0 +1,88 @@
+package netstat
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/lvim-tech/ql/pkg/utils"
+)
+
+// vpnPrefixes are interface name prefixes used by common VPN software
+var vpnPrefixes = []string{"wg", "tailscale", "tun", "tap", "ppp", "vpn"}
+
+// sysfsInterfaceType classifies an interface from /sys/class/net, which also
+// catches renamed interfaces. Returns "" when sysfs gives no clear answer.
+func sysfsInterfaceType(name string) string {
+	base := filepath.Join("/sys/class/net", name)
+
+	if data, err := os.ReadFile(filepath.Join(base, "uevent")); err == nil {
+		for _, line := range strings.Split(string(data), "\n") {
+			devType, found := strings.CutPrefix(line, "DEVTYPE=")
+			if !found {
+				continue
+			}
+			switch devType {
+			case "wlan":
+				return "wifi"
+			case "wireguard", "ppp":
+				return "vpn"
+			}
+		}
+	}
+
+	// tun/tap devices expose their flags; WireGuard and tun report
+	// ARPHRD_NONE (65534), PPP reports ARPHRD_PPP (512)
+	if utils.FileExists(filepath.Join(base, "tun_flags")) {
+		return "vpn"
+	}
+
+	if data, err := os.ReadFile(filepath.Join(base, "type")); err == nil {
+		switch strings.TrimSpace(string(data)) {
+		case "65534", "512":
+			return "vpn"
+		case "772":
+			return "loopback"
+		}
+	}
+
+	if utils.IsDirectory(filepath.Join(base, "wireless")) {
+		return "wifi"
+	}
+
+	return ""
+}
+
+// getVPNPeer returns the remote endpoint of a VPN interface where available:
+// WireGuard peer endpoints via 'wg show', otherwise the point-to-point peer address
+func getVPNPeer(name string) string {
+	if utils.CommandExists("wg") {
+		// Output: <public key>\t<endpoint> per peer; usually needs root
+		if output, err := utils.RunCommandTimeout(commandTimeout, "wg", "show", name, "endpoints"); err == nil {
+			var endpoints []string
+			for _, line := range strings.Split(output, "\n") {
+				fields := strings.Fields(line)
+				if len(fields) == 2 && fields[1] != "(none)" {
+					endpoints = append(endpoints, fields[1])
+				}
+			}
+			if len(endpoints) > 0 {
+				return strings.Join(endpoints, ", ")
+			}
+		}
+	}
+
+	output, err := utils.RunCommandTimeout(commandTimeout, "ip", "-o", "addr", "show", "dev", name)
+	if err != nil {
+		return ""
+	}
+
+	fields := strings.Fields(output)
+	for i, field := range fields {
+		if field == "peer" && i+1 < len(fields) {
+			return fields[i+1]
+		}
+	}
+
+	return ""
+}
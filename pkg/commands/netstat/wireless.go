@@ -0,0 +1,255 @@
+package netstat
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lvim-tech/ql/pkg/utils"
+)
+
+// WirelessLink is the association of a wifi interface as reported by iw
+type WirelessLink struct {
+	SSID         string
+	BSSID        string
+	FrequencyMHz float64
+	SignalDBm    int // 0 when unknown
+	SignalAvgDBm int // from the station dump, 0 when unknown
+	TxBitrate    string
+	RxBitrate    string
+	Connected    time.Duration
+}
+
+// getWirelessLink reads the link from 'iw dev <if> link', filling what the
+// driver leaves out of it from 'iw dev <if> station dump'. Without iw only
+// the SSID from iwgetid is known.
+func getWirelessLink(name string) WirelessLink {
+	var link WirelessLink
+
+	if utils.CommandExists("iw") {
+		if output, err := utils.RunCommandTimeout(commandTimeout, "iw", "dev", name, "link"); err == nil {
+			link = parseIwLink(output)
+		}
+
+		if link.BSSID != "" {
+			if output, err := utils.RunCommandTimeout(commandTimeout, "iw", "dev", name, "station", "dump"); err == nil {
+				parseIwStationDump(output, &link)
+			}
+		}
+	}
+
+	if link.SSID == "" {
+		link.SSID = getWifiSSID(name)
+	}
+
+	return link
+}
+
+// parseIwLink reads the output of 'iw dev <if> link', which is
+// "Not connected." or
+//
+//	Connected to aa:bb:cc:dd:ee:ff (on wlan0)
+//		SSID: home
+//		freq: 5180
+//		signal: -52 dBm
+//		rx bitrate: 650.0 MBit/s VHT-MCS 7 80MHz VHT-NSS 2
+//		tx bitrate: 866.7 MBit/s VHT-MCS 9 80MHz short GI VHT-NSS 2
+func parseIwLink(output string) WirelessLink {
+	var link WirelessLink
+
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+
+		if rest, found := strings.CutPrefix(line, "Connected to "); found {
+			if fields := strings.Fields(rest); len(fields) > 0 {
+				link.BSSID = strings.ToLower(fields[0])
+			}
+			continue
+		}
+
+		key, value, found := strings.Cut(line, ":")
+		if !found {
+			continue
+		}
+		value = strings.TrimSpace(value)
+
+		switch key {
+		case "SSID":
+			link.SSID = unescapeIwSSID(value)
+		case "freq":
+			// Newer iw prints "5180.0"
+			link.FrequencyMHz, _ = strconv.ParseFloat(value, 64)
+		case "signal":
+			link.SignalDBm = parseDBm(value)
+		case "rx bitrate":
+			link.RxBitrate = parseIwBitrate(value)
+		case "tx bitrate":
+			link.TxBitrate = parseIwBitrate(value)
+		}
+	}
+
+	return link
+}
+
+// parseIwStationDump fills link from the station entry of its BSSID. In
+// managed mode that is the only entry, but an interface can also list
+// others (ad-hoc, mesh), so a mismatched BSSID is skipped.
+func parseIwStationDump(output string, link *WirelessLink) {
+	inStation := false
+
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+
+		if rest, found := strings.CutPrefix(line, "Station "); found {
+			fields := strings.Fields(rest)
+			inStation = len(fields) > 0 && strings.EqualFold(fields[0], link.BSSID)
+			continue
+		}
+		if !inStation {
+			continue
+		}
+
+		key, value, found := strings.Cut(line, ":")
+		if !found {
+			continue
+		}
+		value = strings.TrimSpace(value)
+
+		switch key {
+		case "signal":
+			if link.SignalDBm == 0 {
+				link.SignalDBm = parseDBm(value)
+			}
+		case "signal avg":
+			link.SignalAvgDBm = parseDBm(value)
+		case "rx bitrate":
+			if link.RxBitrate == "" {
+				link.RxBitrate = parseIwBitrate(value)
+			}
+		case "tx bitrate":
+			if link.TxBitrate == "" {
+				link.TxBitrate = parseIwBitrate(value)
+			}
+		case "connected time":
+			// "3600 seconds"
+			if fields := strings.Fields(value); len(fields) > 0 {
+				if seconds, err := strconv.Atoi(fields[0]); err == nil {
+					link.Connected = time.Duration(seconds) * time.Second
+				}
+			}
+		}
+	}
+}
+
+// parseDBm reads the first number of "-52 dBm" or, with one value per
+// antenna as the station dump has it, "-52 [-54, -55] dBm"
+func parseDBm(value string) int {
+	fields := strings.Fields(value)
+	if len(fields) == 0 {
+		return 0
+	}
+
+	dbm, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return 0
+	}
+	return dbm
+}
+
+// parseIwBitrate extracts "866.7 MBit/s" from a value like
+// "866.7 MBit/s VHT-MCS 9 80MHz short GI VHT-NSS 2"
+func parseIwBitrate(value string) string {
+	fields := strings.Fields(value)
+	if len(fields) >= 2 {
+		return fields[0] + " " + fields[1]
+	}
+	return ""
+}
+
+// unescapeIwSSID turns the \xNN escapes iw prints for non-printable bytes,
+// backslashes and leading or trailing spaces back into the SSID's bytes, so
+// UTF-8 names read as they do in the wifi menu
+func unescapeIwSSID(s string) string {
+	if !strings.Contains(s, `\x`) {
+		return s
+	}
+
+	var out []byte
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+3 < len(s) && s[i+1] == 'x' {
+			if b, err := strconv.ParseUint(s[i+2:i+4], 16, 8); err == nil {
+				out = append(out, byte(b))
+				i += 3
+				continue
+			}
+		}
+		out = append(out, s[i])
+	}
+
+	return string(out)
+}
+
+// signalQuality maps dBm to the 0-100% scale NetworkManager uses: -100 dBm
+// and below is 0%, -50 dBm and above 100%
+func signalQuality(dbm int) int {
+	return min(max(2*(dbm+100), 0), 100)
+}
+
+// formatSignal renders dBm as "-52 dBm (96%)", or "" when unknown
+func formatSignal(dbm int) string {
+	if dbm == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d dBm (%d%%)", dbm, signalQuality(dbm))
+}
+
+// band names the wifi band of a frequency
+func band(freqMHz float64) string {
+	switch {
+	case freqMHz <= 0:
+		return ""
+	case freqMHz < 2500:
+		return "2.4 GHz"
+	case freqMHz < 5925:
+		return "5 GHz"
+	case freqMHz < 7200:
+		return "6 GHz"
+	}
+	return ""
+}
+
+// infoLines describes the link for the interface view, leaving out what iw
+// did not report. The TX bitrate is shown as the interface's link speed.
+func (w WirelessLink) infoLines() []string {
+	var lines []string
+
+	if w.SSID != "" {
+		lines = append(lines, "SSID: "+w.SSID)
+	}
+
+	if signal := formatSignal(w.SignalDBm); signal != "" {
+		if w.SignalAvgDBm != 0 && w.SignalAvgDBm != w.SignalDBm {
+			signal += fmt.Sprintf(", average %d dBm", w.SignalAvgDBm)
+		}
+		lines = append(lines, "Signal: "+signal)
+	}
+
+	if w.FrequencyMHz > 0 {
+		freq := strconv.FormatFloat(w.FrequencyMHz, 'f', -1, 64) + " MHz"
+		if b := band(w.FrequencyMHz); b != "" {
+			freq += " (" + b + ")"
+		}
+		lines = append(lines, "Frequency: "+freq)
+	}
+
+	if w.RxBitrate != "" {
+		lines = append(lines, "RX bitrate: "+w.RxBitrate)
+	}
+
+	if w.Connected > 0 {
+		lines = append(lines, "Connected for: "+utils.FormatElapsed(w.Connected))
+	}
+
+	return lines
+}
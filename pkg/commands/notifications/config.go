@@ -0,0 +1,18 @@
+package notifications
+
+// Config represents notification history module configuration
+type Config struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Backend forces dunst or mako; "auto" picks the first installed
+	Backend  string `mapstructure:"backend"`
+	MaxItems int    `mapstructure:"max_items"`
+}
+
+// DefaultConfig returns default notification history configuration
+func DefaultConfig() Config {
+	return Config{
+		Enabled:  true,
+		Backend:  "auto",
+		MaxItems: 50,
+	}
+}
@@ -0,0 +1,233 @@
+package notifications
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lvim-tech/ql/pkg/utils"
+)
+
+// commandTimeout bounds dunstctl/makoctl calls
+const commandTimeout = 5 * time.Second
+
+// Entry is one notification from the daemon's history
+type Entry struct {
+	ID      int       `json:"id"`
+	AppName string    `json:"app_name,omitempty"`
+	Summary string    `json:"summary"`
+	Body    string    `json:"body,omitempty"`
+	Urgency string    `json:"urgency,omitempty"`
+	Time    time.Time `json:"time,omitzero"`
+}
+
+// Label is the entry's line in the history menu
+func (e Entry) Label() string {
+	var b strings.Builder
+
+	if !e.Time.IsZero() {
+		fmt.Fprintf(&b, "[%s] ", e.Time.Format("15:04"))
+	}
+	if e.AppName != "" {
+		b.WriteString(e.AppName + ": ")
+	}
+	b.WriteString(e.Summary)
+	if body := strings.Join(strings.Fields(e.Body), " "); body != "" {
+		b.WriteString(" — " + body)
+	}
+
+	label := b.String()
+	if runes := []rune(label); len(runes) > 120 {
+		label = string(runes[:119]) + "…"
+	}
+	return label
+}
+
+// detectBackend returns the notification daemon to talk to: the configured
+// one, or dunst/mako, whichever control tool is installed
+func detectBackend(configured string) (string, error) {
+	switch strings.ToLower(configured) {
+	case "dunst", "mako":
+		return strings.ToLower(configured), nil
+	case "", "auto":
+	default:
+		return "", fmt.Errorf("invalid backend: %s (must be 'auto', 'dunst' or 'mako')", configured)
+	}
+
+	switch {
+	case utils.CommandExists("dunstctl"):
+		return "dunst", nil
+	case utils.CommandExists("makoctl"):
+		return "mako", nil
+	}
+
+	return "", fmt.Errorf("no notification history found (install dunst or mako)")
+}
+
+// getHistory returns the history entries, newest first
+func getHistory(backend string, maxItems int) ([]Entry, error) {
+	var output string
+	var err error
+
+	switch backend {
+	case "dunst":
+		output, err = utils.RunCommandTimeout(commandTimeout, "dunstctl", "history")
+	case "mako":
+		output, err = utils.RunCommandTimeout(commandTimeout, "makoctl", "history")
+	default:
+		return nil, fmt.Errorf("unsupported backend: %s", backend)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read notification history: %w", err)
+	}
+
+	entries, err := parseHistory(output, bootTime())
+	if err != nil {
+		return nil, err
+	}
+
+	if maxItems > 0 && len(entries) > maxItems {
+		entries = entries[:maxItems]
+	}
+
+	return entries, nil
+}
+
+// variant is a D-Bus value as printed by dunstctl and makoctl: {"type": "s", "data": ...}
+type variant struct {
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
+
+// parseHistory parses the 'dunstctl history' / 'makoctl history' JSON, an
+// array of D-Bus dictionaries wrapped as {"type": "aa{sv}", "data": [[...]]}.
+// dunst timestamps are microseconds since boot and need boot to become wall
+// clock times; mako has none.
+func parseHistory(output string, boot time.Time) ([]Entry, error) {
+	var resp struct {
+		Data [][]map[string]variant `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(output), &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse notification history: %w", err)
+	}
+
+	var entries []Entry
+	for _, group := range resp.Data {
+		for _, fields := range group {
+			e := Entry{
+				ID:      variantInt(fields["id"]),
+				AppName: variantString(fields["appname"]),
+				Summary: variantString(fields["summary"]),
+				Body:    variantString(fields["body"]),
+				Urgency: strings.ToLower(variantString(fields["urgency"])),
+			}
+			if e.AppName == "" {
+				e.AppName = variantString(fields["app-name"])
+			}
+			// mako reports urgency as a byte: 0 low, 1 normal, 2 critical
+			if e.Urgency == "" {
+				e.Urgency = map[int]string{0: "low", 1: "normal", 2: "critical"}[variantInt(fields["urgency"])]
+			}
+			if usec := variantInt(fields["timestamp"]); usec > 0 && !boot.IsZero() {
+				e.Time = boot.Add(time.Duration(usec) * time.Microsecond)
+			}
+			entries = append(entries, e)
+		}
+	}
+
+	// Both daemons list newest first already; keep that when times are known
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Time.After(entries[j].Time)
+	})
+
+	return entries, nil
+}
+
+func variantString(v variant) string {
+	var s string
+	if json.Unmarshal(v.Data, &s) != nil {
+		return ""
+	}
+	return s
+}
+
+func variantInt(v variant) int {
+	var n int
+	if json.Unmarshal(v.Data, &n) != nil {
+		return 0
+	}
+	return n
+}
+
+// bootTime derives the boot moment from /proc/uptime
+func bootTime() time.Time {
+	data, err := os.ReadFile("/proc/uptime")
+	if err != nil {
+		return time.Time{}
+	}
+
+	fields := strings.Fields(string(data))
+	if len(fields) == 0 {
+		return time.Time{}
+	}
+
+	uptime, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return time.Time{}
+	}
+
+	return time.Now().Add(-time.Duration(uptime * float64(time.Second)))
+}
+
+// redisplay shows an entry again. dunst moves it from history back to the
+// screen; mako cannot restore a specific entry, so it is sent again.
+func redisplay(backend string, e Entry) error {
+	if backend == "dunst" {
+		if _, err := utils.RunCommandTimeout(commandTimeout, "dunstctl", "history-pop", strconv.Itoa(e.ID)); err != nil {
+			return fmt.Errorf("failed to show notification: %w", err)
+		}
+		return nil
+	}
+
+	args := []string{"--app-name", e.AppName}
+	if e.Urgency != "" {
+		args = append(args, "--urgency", e.Urgency)
+	}
+	args = append(args, e.Summary, e.Body)
+
+	if _, err := utils.RunCommandTimeout(commandTimeout, "notify-send", args...); err != nil {
+		return fmt.Errorf("failed to show notification: %w", err)
+	}
+	return nil
+}
+
+// canDismiss reports whether single entries can be removed from history
+func canDismiss(backend string) bool {
+	return backend == "dunst"
+}
+
+// dismiss removes an entry from the history
+func dismiss(backend string, e Entry) error {
+	if !canDismiss(backend) {
+		return fmt.Errorf("%s cannot remove single history entries", backend)
+	}
+	if _, err := utils.RunCommandTimeout(commandTimeout, "dunstctl", "history-rm", strconv.Itoa(e.ID)); err != nil {
+		return fmt.Errorf("failed to dismiss notification: %w", err)
+	}
+	return nil
+}
+
+// clearHistory empties the history (dunst only; mako keeps no clearable history)
+func clearHistory(backend string) error {
+	if backend != "dunst" {
+		return fmt.Errorf("%s cannot clear its history", backend)
+	}
+	if _, err := utils.RunCommandTimeout(commandTimeout, "dunstctl", "history-clear"); err != nil {
+		return fmt.Errorf("failed to clear history: %w", err)
+	}
+	return nil
+}
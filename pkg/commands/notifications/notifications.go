@@ -0,0 +1,272 @@
+// Package notifications provides a notification history viewer for ql.
+// It lists past notifications from dunst or mako and shows them again,
+// copies their text or dismisses them.
+package notifications
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/lvim-tech/ql/pkg/commands"
+	"github.com/lvim-tech/ql/pkg/config"
+	"github.com/lvim-tech/ql/pkg/utils"
+	"github.com/mitchellh/mapstructure"
+)
+
+func init() {
+	commands.Register(commands.Command{
+		Name:        "notifications",
+		Description: "Notification history",
+		Subcommands: directCommands.Info(),
+		Check:       check,
+		Config:      commands.ConfigOf(DefaultConfig),
+		Run:         Run,
+	})
+}
+
+// check verifies that dunst or mako is available
+func check(qlCfg *config.Config) error {
+	cfg := DefaultConfig()
+	if err := commands.DecodeConfig(qlCfg.GetNotificationsConfig(), &cfg); err != nil {
+		return err
+	}
+
+	_, err := detectBackend(cfg.Backend)
+	return err
+}
+
+const clearItem = "Clear History"
+
+func Run(ctx commands.LauncherContext) commands.CommandResult {
+	cfgInterface := ctx.Config().GetNotificationsConfig()
+
+	var cfg Config
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		WeaklyTypedInput: true,
+		Result:           &cfg,
+	})
+	if err != nil {
+		cfg = DefaultConfig()
+	} else {
+		if decodeErr := decoder.Decode(cfgInterface); decodeErr != nil {
+			cfg = DefaultConfig()
+		}
+	}
+
+	if !cfg.Enabled {
+		return commands.CommandResult{
+			Success: false,
+			Error:   fmt.Errorf("notifications module is disabled in config"),
+		}
+	}
+
+	notifCfg := ctx.Config().GetNotificationConfig()
+
+	backend, err := detectBackend(cfg.Backend)
+	if err != nil {
+		utils.ShowErrorNotificationWithConfig(&notifCfg, "Notifications Error", err.Error())
+		return commands.CommandResult{Success: false, Error: err}
+	}
+
+	if args := ctx.Args(); len(args) > 0 {
+		return directCommands.Dispatch("notifications", &directEnv{ctx: ctx, cfg: &cfg, notifCfg: &notifCfg, backend: backend}, args)
+	}
+
+	for {
+		entries, err := getHistory(backend, cfg.MaxItems)
+		if err != nil {
+			utils.ShowErrorNotificationWithConfig(&notifCfg, "Notifications Error", err.Error())
+			return commands.CommandResult{Success: false}
+		}
+
+		var options []string
+		if !ctx.IsDirectLaunch() {
+			options = append(options, "← Back")
+		}
+		if backend == "dunst" && len(entries) > 0 {
+			options = append(options, clearItem)
+		}
+
+		labels := entryLabels(entries)
+		if len(labels) == 0 {
+			options = append(options, "Notification history is empty")
+		}
+		options = append(options, labels...)
+
+		choice, err := ctx.Show(options, "Notifications")
+		if err != nil {
+			// ESC pressed - exit completely
+			return commands.CommandResult{Success: false}
+		}
+
+		if choice == "← Back" {
+			return commands.CommandResult{Success: false, Error: commands.ErrBack}
+		}
+
+		if choice == clearItem {
+			if err := clearHistory(backend); err != nil {
+				utils.ShowErrorNotificationWithConfig(&notifCfg, "Notifications Error", err.Error())
+				continue
+			}
+			return commands.CommandResult{Success: true}
+		}
+
+		index := slices.Index(labels, choice)
+		if index == -1 {
+			// The empty-history placeholder or text matching no entry
+			continue
+		}
+
+		err = entryActions(ctx, backend, entries[index], &notifCfg)
+		if err == commands.ErrBack {
+			continue
+		}
+		if err != nil {
+			if err.Error() == "cancelled" {
+				return commands.CommandResult{Success: false}
+			}
+			utils.ShowErrorNotificationWithConfig(&notifCfg, "Notifications Error", err.Error())
+			continue
+		}
+
+		return commands.CommandResult{Success: true}
+	}
+}
+
+// entryLabels returns the menu lines, numbered when two entries look the same
+func entryLabels(entries []Entry) []string {
+	labels := make([]string, 0, len(entries))
+	for _, e := range entries {
+		label := e.Label()
+		for n := 2; slices.Contains(labels, label); n++ {
+			label = fmt.Sprintf("%s (%d)", e.Label(), n)
+		}
+		labels = append(labels, label)
+	}
+	return labels
+}
+
+// entryActions asks what to do with one history entry
+func entryActions(ctx commands.LauncherContext, backend string, e Entry, notifCfg *config.NotificationConfig) error {
+	options := []string{"← Back", "Show Again", "Copy Text"}
+	if canDismiss(backend) {
+		options = append(options, "Dismiss")
+	}
+
+	choice, err := ctx.Show(options, e.Summary)
+	if err != nil {
+		return fmt.Errorf("cancelled")
+	}
+
+	switch choice {
+	case "Show Again":
+		return redisplay(backend, e)
+	case "Copy Text":
+		if err := utils.CopyToClipboard(entryText(e)); err != nil {
+			return err
+		}
+		utils.NotifyWithConfig(notifCfg, "Notifications", "Copied to clipboard")
+		return nil
+	case "Dismiss":
+		return dismiss(backend, e)
+	default:
+		return commands.ErrBack
+	}
+}
+
+// entryText is the summary followed by the body, as copied to the clipboard
+func entryText(e Entry) string {
+	if e.Body == "" {
+		return e.Summary
+	}
+	return e.Summary + "\n" + e.Body
+}
+
+// directEnv is the state shared by the direct subcommands
+type directEnv struct {
+	ctx      commands.LauncherContext
+	cfg      *Config
+	notifCfg *config.NotificationConfig
+	backend  string
+}
+
+// directCommands are the actions available as 'ql notifications <action>'
+var directCommands = commands.SubcommandTable[*directEnv]{
+	{
+		Name: "list",
+		Run: func(e *directEnv, args []string) commands.CommandResult {
+			entries, err := getHistory(e.backend, e.cfg.MaxItems)
+			if err != nil {
+				return commands.CommandResult{Success: false, Error: err}
+			}
+			if e.ctx.IsJSONOutput() {
+				return commands.CommandResult{Success: true, Data: entries}
+			}
+			for _, entry := range entries {
+				fmt.Printf("%-6d %s\n", entry.ID, entry.Label())
+			}
+			return commands.CommandResult{Success: true}
+		},
+	},
+	{
+		Name:    "pop",
+		Aliases: []string{"show"},
+		Usage:   "[id]",
+		Run: func(e *directEnv, args []string) commands.CommandResult {
+			entry, err := findEntry(e, args)
+			if err != nil {
+				return commands.CommandResult{Success: false, Error: err}
+			}
+			return commands.ResultOf(redisplay(e.backend, entry))
+		},
+	},
+	{
+		Name:  "dismiss",
+		Usage: "<id>",
+		Run: func(e *directEnv, args []string) commands.CommandResult {
+			if len(args) == 0 {
+				return commands.CommandResult{Success: false, Error: fmt.Errorf("usage: ql notifications dismiss <id>")}
+			}
+			entry, err := findEntry(e, args)
+			if err != nil {
+				return commands.CommandResult{Success: false, Error: err}
+			}
+			return commands.ResultOf(dismiss(e.backend, entry))
+		},
+	},
+	{
+		Name: "clear",
+		Run: func(e *directEnv, args []string) commands.CommandResult {
+			return commands.ResultOf(clearHistory(e.backend))
+		},
+	},
+}
+
+// findEntry returns the entry with the ID in args, or the newest one
+func findEntry(e *directEnv, args []string) (Entry, error) {
+	entries, err := getHistory(e.backend, 0)
+	if err != nil {
+		return Entry{}, err
+	}
+	if len(entries) == 0 {
+		return Entry{}, fmt.Errorf("notification history is empty")
+	}
+	if len(args) == 0 {
+		return entries[0], nil
+	}
+
+	id, err := strconv.Atoi(strings.TrimSpace(args[0]))
+	if err != nil {
+		return Entry{}, fmt.Errorf("invalid notification id: %s", args[0])
+	}
+
+	for _, entry := range entries {
+		if entry.ID == id {
+			return entry, nil
+		}
+	}
+
+	return Entry{}, fmt.Errorf("no notification with id %d in history", id)
+}
@@ -8,6 +8,7 @@ type Config struct {
 	ShowHibernate    bool   `toml:"show_hibernate" mapstructure:"show_hibernate"`
 	ShowReboot       bool   `toml:"show_reboot" mapstructure:"show_reboot"`
 	ShowShutdown     bool   `toml:"show_shutdown" mapstructure:"show_shutdown"`
+	NumberedMenu     bool   `toml:"numbered_menu" mapstructure:"numbered_menu"`
 	ConfirmLogout    bool   `toml:"confirm_logout" mapstructure:"confirm_logout"`
 	ConfirmSuspend   bool   `toml:"confirm_suspend" mapstructure:"confirm_suspend"`
 	ConfirmHibernate bool   `toml:"confirm_hibernate" mapstructure:"confirm_hibernate"`
@@ -18,6 +19,22 @@ type Config struct {
 	HibernateCommand string `toml:"hibernate_command" mapstructure:"hibernate_command"`
 	RebootCommand    string `toml:"reboot_command" mapstructure:"reboot_command"`
 	ShutdownCommand  string `toml:"shutdown_command" mapstructure:"shutdown_command"`
+	// CheckInhibitors warns before suspend/hibernate while a block
+	// inhibitor from systemd-inhibit holds sleep
+	CheckInhibitors bool `toml:"check_inhibitors" mapstructure:"check_inhibitors"`
+
+	// Hooks run via sh -c before/after each action. Post hooks for suspend and
+	// hibernate run after resume; for the others they run only if the session survives.
+	PreLogoutCommand     string `toml:"pre_logout_command" mapstructure:"pre_logout_command"`
+	PostLogoutCommand    string `toml:"post_logout_command" mapstructure:"post_logout_command"`
+	PreSuspendCommand    string `toml:"pre_suspend_command" mapstructure:"pre_suspend_command"`
+	PostSuspendCommand   string `toml:"post_suspend_command" mapstructure:"post_suspend_command"`
+	PreHibernateCommand  string `toml:"pre_hibernate_command" mapstructure:"pre_hibernate_command"`
+	PostHibernateCommand string `toml:"post_hibernate_command" mapstructure:"post_hibernate_command"`
+	PreRebootCommand     string `toml:"pre_reboot_command" mapstructure:"pre_reboot_command"`
+	PostRebootCommand    string `toml:"post_reboot_command" mapstructure:"post_reboot_command"`
+	PreShutdownCommand   string `toml:"pre_shutdown_command" mapstructure:"pre_shutdown_command"`
+	PostShutdownCommand  string `toml:"post_shutdown_command" mapstructure:"post_shutdown_command"`
 }
 
 // DefaultConfig връща default настройки
@@ -29,6 +46,7 @@ func DefaultConfig() Config {
 		ShowHibernate:    true,
 		ShowReboot:       true,
 		ShowShutdown:     true,
+		NumberedMenu:     false,
 		ConfirmLogout:    false,
 		ConfirmSuspend:   false,
 		ConfirmHibernate: true,
@@ -39,5 +57,6 @@ func DefaultConfig() Config {
 		HibernateCommand: "systemctl hibernate",
 		RebootCommand:    "systemctl reboot",
 		ShutdownCommand:  "systemctl poweroff",
+		CheckInhibitors:  true,
 	}
 }
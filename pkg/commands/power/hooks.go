@@ -0,0 +1,153 @@
+package power
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lvim-tech/ql/pkg/utils"
+)
+
+// resumeWaitLimit is how long the resume watcher waits for the system to sleep
+const resumeWaitLimit = 2 * time.Minute
+
+// sleepActions return to the session on resume, so their post hook runs after wake-up
+var sleepActions = map[string]bool{
+	"suspend":   true,
+	"hibernate": true,
+}
+
+// hooks returns the pre and post hook commands configured for an action
+func (c *Config) hooks(action string) (string, string) {
+	switch action {
+	case "logout":
+		return c.PreLogoutCommand, c.PostLogoutCommand
+	case "suspend":
+		return c.PreSuspendCommand, c.PostSuspendCommand
+	case "hibernate":
+		return c.PreHibernateCommand, c.PostHibernateCommand
+	case "reboot":
+		return c.PreRebootCommand, c.PostRebootCommand
+	case "shutdown":
+		return c.PreShutdownCommand, c.PostShutdownCommand
+	}
+	return "", ""
+}
+
+// runPowerCommand runs a power action wrapped in its pre/post hooks.
+// A failing pre hook aborts the action.
+func runPowerCommand(action, command string, cfg *Config) error {
+	pre, post := cfg.hooks(action)
+
+	if pre != "" {
+		if err := runHook(pre); err != nil {
+			return fmt.Errorf("pre-%s hook failed: %w", action, err)
+		}
+	}
+
+	// The sleep command returns before the system sleeps, so a detached
+	// watcher runs the post hook once the system has actually resumed
+	if post != "" && sleepActions[action] {
+		if err := startResumeWatcher(action); err != nil {
+			return fmt.Errorf("failed to start post-%s hook: %w", action, err)
+		}
+	}
+
+	cmd := exec.Command("sh", "-c", os.ExpandEnv(command))
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%s failed: %s", action, strings.TrimSpace(string(output)))
+	}
+
+	// Best effort: logout/reboot/shutdown usually end the session before this runs
+	if post != "" && !sleepActions[action] {
+		if err := runHook(post); err != nil {
+			return fmt.Errorf("post-%s hook failed: %w", action, err)
+		}
+	}
+
+	return nil
+}
+
+func runHook(command string) error {
+	cmd := exec.Command("sh", "-c", os.ExpandEnv(command))
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		if msg := strings.TrimSpace(string(output)); msg != "" {
+			return fmt.Errorf("%s", msg)
+		}
+		return err
+	}
+	return nil
+}
+
+// startResumeWatcher launches 'ql power resume-hook <action>' detached
+func startResumeWatcher(action string) error {
+	exe, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	return utils.StartDetachedProcess(exe, "power", "resume-hook", action)
+}
+
+// runResumeHook waits until the system has been asleep and then runs the
+// action's post hook. Gives up if no sleep happens within resumeWaitLimit.
+func runResumeHook(action string, cfg *Config) error {
+	_, post := cfg.hooks(action)
+	if post == "" {
+		return nil
+	}
+
+	if !waitForResume(resumeWaitLimit) {
+		return fmt.Errorf("system did not %s, post-%s hook skipped", action, action)
+	}
+
+	if err := runHook(post); err != nil {
+		return fmt.Errorf("post-%s hook failed: %w", action, err)
+	}
+
+	return nil
+}
+
+// waitForResume detects a sleep/resume cycle by comparing Go's monotonic
+// clock, which stops while suspended, with /proc/uptime, which keeps counting
+func waitForResume(limit time.Duration) bool {
+	start := time.Now()
+	startUptime, err := readUptime()
+	if err != nil {
+		return false
+	}
+
+	for time.Since(start) < limit {
+		time.Sleep(time.Second)
+
+		uptime, err := readUptime()
+		if err != nil {
+			return false
+		}
+
+		slept := (uptime - startUptime) - time.Since(start).Seconds()
+		if slept > 3 {
+			return true
+		}
+	}
+
+	return false
+}
+
+func readUptime() (float64, error) {
+	data, err := os.ReadFile("/proc/uptime")
+	if err != nil {
+		return 0, err
+	}
+
+	fields := strings.Fields(string(data))
+	if len(fields) == 0 {
+		return 0, fmt.Errorf("invalid /proc/uptime")
+	}
+
+	return strconv.ParseFloat(fields[0], 64)
+}
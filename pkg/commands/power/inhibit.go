@@ -0,0 +1,190 @@
+package power
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/lvim-tech/ql/pkg/config"
+	"github.com/lvim-tech/ql/pkg/utils"
+)
+
+// inhibitorTimeout bounds the systemd-inhibit query before suspend
+const inhibitorTimeout = 3 * time.Second
+
+// Inhibitor is one lock reported by systemd-inhibit --list
+type Inhibitor struct {
+	Who  string
+	What string // colon-separated, e.g. "shutdown:sleep"
+	Why  string
+	Mode string // block or delay
+}
+
+func (i Inhibitor) String() string {
+	if i.Why == "" {
+		return i.Who
+	}
+	return fmt.Sprintf("%s: %s", i.Who, i.Why)
+}
+
+// sleepBlockers returns the block inhibitors that hold sleep. Delay locks
+// are left out: NetworkManager, UPower and the desktop hold them all the
+// time, and they only postpone sleep for a moment.
+func sleepBlockers(cfg *Config) []Inhibitor {
+	if !cfg.CheckInhibitors || !utils.CommandExists("systemd-inhibit") {
+		return nil
+	}
+
+	output, err := utils.RunCommandTimeout(inhibitorTimeout, "systemd-inhibit", "--list", "--no-pager")
+	if err != nil {
+		utils.LogDebug("systemd-inhibit failed", "error", err)
+		return nil
+	}
+
+	var blockers []Inhibitor
+	for _, inhibitor := range parseInhibitors(output) {
+		if inhibitor.Mode != "block" {
+			continue
+		}
+		for _, what := range strings.Split(inhibitor.What, ":") {
+			if what == "sleep" {
+				blockers = append(blockers, inhibitor)
+				break
+			}
+		}
+	}
+
+	return blockers
+}
+
+// warnSleepBlockers notifies what holds sleep and returns the label used in
+// the confirmation prompt, e.g. "Suspend despite Firefox"
+func warnSleepBlockers(action string, blockers []Inhibitor, notifCfg *config.NotificationConfig) string {
+	lines := make([]string, len(blockers))
+	who := make([]string, len(blockers))
+	for i, b := range blockers {
+		lines[i] = b.String()
+		who[i] = b.Who
+	}
+
+	utils.NotifyWithConfig(notifCfg, "Sleep Is Inhibited", strings.Join(lines, "\n"))
+
+	return fmt.Sprintf("%s despite %s", action, strings.Join(who, ", "))
+}
+
+// parseInhibitors reads both layouts of systemd-inhibit --list: the table
+// of newer systemd, and the "Who:/What:" blocks of older releases
+func parseInhibitors(output string) []Inhibitor {
+	if strings.Contains(output, "Who: ") {
+		return parseInhibitorBlocks(output)
+	}
+	return parseInhibitorTable(output)
+}
+
+// parseInhibitorTable slices rows at the header's column offsets, since WHO
+// and WHY may contain spaces. Offsets count runes, as systemd aligns by them.
+func parseInhibitorTable(output string) []Inhibitor {
+	lines := strings.Split(output, "\n")
+
+	header := -1
+	for i, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), "WHO") && strings.Contains(line, "MODE") {
+			header = i
+			break
+		}
+	}
+	if header < 0 {
+		return nil
+	}
+
+	columns := []string{"WHO", "UID", "USER", "PID", "COMM", "WHAT", "WHY", "MODE"}
+	offsets := make(map[string]int, len(columns))
+	var starts []int
+	for _, name := range columns {
+		offset := strings.Index(lines[header], name)
+		if offset < 0 {
+			return nil
+		}
+		offset = utf8.RuneCountInString(lines[header][:offset])
+		offsets[name] = offset
+		starts = append(starts, offset)
+	}
+
+	field := func(line []rune, name string) string {
+		start := offsets[name]
+		if start >= len(line) {
+			return ""
+		}
+		end := len(line)
+		for _, s := range starts {
+			if s > start && s < end {
+				end = s
+			}
+		}
+		return strings.TrimSpace(string(line[start:end]))
+	}
+
+	var inhibitors []Inhibitor
+	for _, line := range lines[header+1:] {
+		if strings.TrimSpace(line) == "" || strings.Contains(line, "inhibitors listed") {
+			continue
+		}
+
+		row := []rune(line)
+		inhibitor := Inhibitor{
+			Who:  field(row, "WHO"),
+			What: field(row, "WHAT"),
+			Why:  field(row, "WHY"),
+			Mode: field(row, "MODE"),
+		}
+		if inhibitor.What == "" || inhibitor.Mode == "" {
+			continue
+		}
+		inhibitors = append(inhibitors, inhibitor)
+	}
+
+	return inhibitors
+}
+
+// parseInhibitorBlocks reads the older layout:
+//
+//	 Who: Firefox (UID 1000/user, PID 4242/firefox)
+//	What: sleep
+//	 Why: Downloading
+//	Mode: block
+func parseInhibitorBlocks(output string) []Inhibitor {
+	var inhibitors []Inhibitor
+	var current *Inhibitor
+
+	for _, line := range strings.Split(output, "\n") {
+		key, value, found := strings.Cut(strings.TrimSpace(line), ":")
+		if !found {
+			continue
+		}
+		value = strings.TrimSpace(value)
+
+		switch key {
+		case "Who":
+			if who, _, ok := strings.Cut(value, " (UID"); ok {
+				value = who
+			}
+			inhibitors = append(inhibitors, Inhibitor{Who: value})
+			current = &inhibitors[len(inhibitors)-1]
+		case "What":
+			if current != nil {
+				current.What = value
+			}
+		case "Why":
+			if current != nil {
+				current.Why = value
+			}
+		case "Mode":
+			if current != nil {
+				current.Mode = value
+			}
+		}
+	}
+
+	return inhibitors
+}
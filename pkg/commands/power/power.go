@@ -3,8 +3,7 @@ package power
 
 import (
 	"fmt"
-	"os"
-	"os/exec"
+	"strconv"
 	"strings"
 
 	"github.com/lvim-tech/ql/pkg/commands"
@@ -17,6 +16,7 @@ func init() {
 	commands.Register(commands.Command{
 		Name:        "power",
 		Description: "Power management",
+		Config:      commands.ConfigOf(DefaultConfig),
 		Run:         Run,
 	})
 }
@@ -48,7 +48,7 @@ func Run(ctx commands.LauncherContext) commands.CommandResult {
 
 	args := ctx.Args()
 	if len(args) > 0 {
-		return executeDirectCommand(args[0], &cfg, &notifCfg)
+		return executeDirectCommand(args, &cfg, &notifCfg)
 	}
 
 	for {
@@ -81,20 +81,42 @@ func Run(ctx commands.LauncherContext) commands.CommandResult {
 	}
 }
 
-func executeDirectCommand(action string, cfg *Config, notifCfg *config.NotificationConfig) commands.CommandResult {
+func executeDirectCommand(args []string, cfg *Config, notifCfg *config.NotificationConfig) commands.CommandResult {
+	// ql power 2 runs the second action of the menu
+	action := resolveMenuChoice(args[0], visibleActions(cfg))
+
 	var err error
 
+	// A direct suspend has no menu to ask in, so active block inhibitors
+	// stop it unless --force is given
+	force := len(args) > 1 && args[1] == "--force"
+
 	switch strings.ToLower(action) {
 	case "logout":
 		err = executeLogout(cfg)
-	case "suspend":
-		err = executeSuspend(cfg)
-	case "hibernate":
-		err = executeHibernate(cfg)
+	case "suspend", "hibernate":
+		if blockers := sleepBlockers(cfg); len(blockers) > 0 && !force {
+			warnSleepBlockers(action, blockers, notifCfg)
+			return commands.CommandResult{
+				Success: false,
+				Error:   fmt.Errorf("sleep is inhibited by %s (use 'ql power %s --force' to proceed)", blockers[0], strings.ToLower(action)),
+			}
+		}
+		if strings.ToLower(action) == "suspend" {
+			err = executeSuspend(cfg)
+		} else {
+			err = executeHibernate(cfg)
+		}
 	case "reboot":
 		err = executeReboot(cfg)
 	case "shutdown":
 		err = executeShutdown(cfg)
+	case "resume-hook":
+		// Internal: spawned detached before suspend/hibernate
+		if len(args) < 2 {
+			return commands.CommandResult{Success: false, Error: fmt.Errorf("usage: ql power resume-hook <suspend|hibernate>")}
+		}
+		err = runResumeHook(strings.ToLower(args[1]), cfg)
 	default:
 		return commands.CommandResult{
 			Success: false,
@@ -117,23 +139,63 @@ func showPowerMainMenu(ctx commands.LauncherContext, cfg *Config) (string, error
 		options = append(options, "← Back")
 	}
 
+	actions := visibleActions(cfg)
+	for i, action := range actions {
+		if cfg.NumberedMenu {
+			action = fmt.Sprintf("%d. %s", i+1, action)
+		}
+		options = append(options, action)
+	}
+
+	choice, err := ctx.ShowOrdered(options, "Power")
+	if err != nil {
+		return "", err
+	}
+
+	return resolveMenuChoice(choice, actions), nil
+}
+
+// visibleActions returns the power actions shown in the menu, in menu order
+func visibleActions(cfg *Config) []string {
+	var actions []string
+
 	if cfg.ShowLogout {
-		options = append(options, "Logout")
+		actions = append(actions, "Logout")
 	}
 	if cfg.ShowSuspend {
-		options = append(options, "Suspend")
+		actions = append(actions, "Suspend")
 	}
 	if cfg.ShowHibernate {
-		options = append(options, "Hibernate")
+		actions = append(actions, "Hibernate")
 	}
 	if cfg.ShowReboot {
-		options = append(options, "Reboot")
+		actions = append(actions, "Reboot")
 	}
 	if cfg.ShowShutdown {
-		options = append(options, "Shutdown")
+		actions = append(actions, "Shutdown")
+	}
+
+	return actions
+}
+
+// resolveMenuChoice maps a numbered label ("2. Suspend") or a bare number
+// ("2") back to its action. Anything else is returned unchanged.
+func resolveMenuChoice(choice string, actions []string) string {
+	number, label, found := strings.Cut(choice, ". ")
+	if !found {
+		number, label = choice, ""
+	}
+
+	n, err := strconv.Atoi(strings.TrimSpace(number))
+	if err != nil || n < 1 || n > len(actions) {
+		return choice
 	}
 
-	return ctx.Show(options, "Power")
+	if label != "" && label != actions[n-1] {
+		return choice
+	}
+
+	return actions[n-1]
 }
 
 func executePowerAction(ctx commands.LauncherContext, cfg *Config, action string) commands.CommandResult {
@@ -162,8 +224,13 @@ func executePowerAction(ctx commands.LauncherContext, cfg *Config, action string
 		return commands.CommandResult{Success: true}
 
 	case "Suspend":
-		if cfg.ConfirmSuspend {
-			choice, err := confirmAction(ctx, "Suspend")
+		confirm, prompt := cfg.ConfirmSuspend, "Suspend"
+		if blockers := sleepBlockers(cfg); len(blockers) > 0 {
+			notifCfg := ctx.Config().GetNotificationConfig()
+			confirm, prompt = true, warnSleepBlockers(prompt, blockers, &notifCfg)
+		}
+		if confirm {
+			choice, err := confirmAction(ctx, prompt)
 			if err != nil {
 				return commands.CommandResult{Success: false, Error: fmt.Errorf("ESC")}
 			}
@@ -185,8 +252,13 @@ func executePowerAction(ctx commands.LauncherContext, cfg *Config, action string
 		return commands.CommandResult{Success: true}
 
 	case "Hibernate":
-		if cfg.ConfirmHibernate {
-			choice, err := confirmAction(ctx, "Hibernate")
+		confirm, prompt := cfg.ConfirmHibernate, "Hibernate"
+		if blockers := sleepBlockers(cfg); len(blockers) > 0 {
+			notifCfg := ctx.Config().GetNotificationConfig()
+			confirm, prompt = true, warnSleepBlockers(prompt, blockers, &notifCfg)
+		}
+		if confirm {
+			choice, err := confirmAction(ctx, prompt)
 			if err != nil {
 				return commands.CommandResult{Success: false, Error: fmt.Errorf("ESC")}
 			}
@@ -262,7 +334,7 @@ func executePowerAction(ctx commands.LauncherContext, cfg *Config, action string
 }
 
 func confirmAction(ctx commands.LauncherContext, action string) (string, error) {
-	options := []string{"← Back", "No", "Yes"}
+	options := commands.ConfirmOptions(ctx)
 	choice, err := ctx.Show(options, fmt.Sprintf("Confirm %s?", action))
 	if err != nil {
 		return "", err
@@ -271,46 +343,21 @@ func confirmAction(ctx commands.LauncherContext, action string) (string, error)
 }
 
 func executeLogout(cfg *Config) error {
-	cmd := exec.Command("sh", "-c", os.ExpandEnv(cfg.LogoutCommand))
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		return fmt.Errorf("logout failed: %s", strings.TrimSpace(string(output)))
-	}
-	return nil
+	return runPowerCommand("logout", cfg.LogoutCommand, cfg)
 }
 
 func executeSuspend(cfg *Config) error {
-	cmd := exec.Command("sh", "-c", os.ExpandEnv(cfg.SuspendCommand))
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		return fmt.Errorf("suspend failed: %s", strings.TrimSpace(string(output)))
-	}
-	return nil
+	return runPowerCommand("suspend", cfg.SuspendCommand, cfg)
 }
 
 func executeHibernate(cfg *Config) error {
-	cmd := exec.Command("sh", "-c", os.ExpandEnv(cfg.HibernateCommand))
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		return fmt.Errorf("hibernate failed: %s", strings.TrimSpace(string(output)))
-	}
-	return nil
+	return runPowerCommand("hibernate", cfg.HibernateCommand, cfg)
 }
 
 func executeReboot(cfg *Config) error {
-	cmd := exec.Command("sh", "-c", os.ExpandEnv(cfg.RebootCommand))
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		return fmt.Errorf("reboot failed: %s", strings.TrimSpace(string(output)))
-	}
-	return nil
+	return runPowerCommand("reboot", cfg.RebootCommand, cfg)
 }
 
 func executeShutdown(cfg *Config) error {
-	cmd := exec.Command("sh", "-c", os.ExpandEnv(cfg.ShutdownCommand))
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		return fmt.Errorf("shutdown failed: %s", strings.TrimSpace(string(output)))
-	}
-	return nil
+	return runPowerCommand("shutdown", cfg.ShutdownCommand, cfg)
 }
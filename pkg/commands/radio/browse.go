@@ -0,0 +1,307 @@
+package radio
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/lvim-tech/ql/pkg/commands"
+	"github.com/lvim-tech/ql/pkg/config"
+	"github.com/lvim-tech/ql/pkg/utils"
+)
+
+// browseTimeout bounds a single radio-browser request
+const browseTimeout = 10 * time.Second
+
+// browseCacheTTL is how long search results are reused
+const browseCacheTTL = time.Hour
+
+// browseFallbackServer round-robins over the radio-browser mirrors, for when
+// their SRV record cannot be looked up
+const browseFallbackServer = "all.api.radio-browser.info"
+
+// browseFields maps the Browse Online entries to radio-browser search fields
+var browseFields = map[string]string{
+	"By Name":    "name",
+	"By Tag":     "tag",
+	"By Country": "country",
+}
+
+// onlineStation is a radio-browser result reduced to what ql plays
+type onlineStation struct {
+	Name   string `json:"name"`
+	URL    string `json:"url"`
+	Detail string `json:"detail"` // country, codec and bitrate for the menu
+}
+
+// browserStation is the subset of radio-browser's station object ql reads
+type browserStation struct {
+	Name        string `json:"name"`
+	URL         string `json:"url"`
+	URLResolved string `json:"url_resolved"`
+	CountryCode string `json:"countrycode"`
+	Codec       string `json:"codec"`
+	Bitrate     int    `json:"bitrate"`
+}
+
+// browseOnline searches radio-browser.info, plays the chosen station and
+// offers to save it with the configured ones
+func browseOnline(ctx commands.LauncherContext, cfg *Config, notifCfg *config.NotificationConfig) error {
+	fieldChoice, err := ctx.Show([]string{"← Back", "By Name", "By Tag", "By Country"}, "Browse Online")
+	if err != nil {
+		return fmt.Errorf("cancelled")
+	}
+	field, ok := browseFields[fieldChoice]
+	if !ok {
+		return fmt.Errorf("back")
+	}
+
+	query, err := ctx.ShowAllowCustom([]string{"← Back"}, fmt.Sprintf("Search %s", field))
+	if err != nil {
+		return fmt.Errorf("cancelled")
+	}
+	query = strings.TrimSpace(query)
+	if query == "← Back" || query == "" {
+		return fmt.Errorf("back")
+	}
+
+	notifyID := utils.ShowPersistentNotificationWithConfig(notifCfg, "Radio", fmt.Sprintf("Searching radio-browser.info for %s...", query))
+	stations, err := searchOnline(field, query, cfg.browseLimit())
+	utils.ClosePersistentNotificationWithConfig(notifCfg, notifyID)
+	if err != nil {
+		return err
+	}
+	if len(stations) == 0 {
+		return fmt.Errorf("no stations found for %s %q", field, query)
+	}
+
+	options := []string{"← Back"}
+	byLabel := make(map[string]onlineStation, len(stations))
+	for _, station := range stations {
+		label := station.Name
+		if station.Detail != "" {
+			label += "  [" + station.Detail + "]"
+		}
+		if _, taken := byLabel[label]; taken {
+			continue
+		}
+		byLabel[label] = station
+		options = append(options, label)
+	}
+
+	choice, err := ctx.ShowOrdered(options, fmt.Sprintf("Stations (%d)", len(byLabel)))
+	if err != nil {
+		return fmt.Errorf("cancelled")
+	}
+	station, ok := byLabel[choice]
+	if !ok {
+		return fmt.Errorf("back")
+	}
+
+	if err := playURL(station.Name, station.URL, cfg, notifCfg); err != nil {
+		return err
+	}
+
+	if _, known := cfg.RadioStations[station.Name]; known {
+		return nil
+	}
+
+	save, err := ctx.Show([]string{"Not Now", "Save to Stations"}, fmt.Sprintf("Save %s?", station.Name))
+	if err != nil || save != "Save to Stations" {
+		return nil
+	}
+
+	return saveStation(station.Name, station.URL, cfg, notifCfg)
+}
+
+// searchOnline returns up to limit stations matching query, most listened
+// first, skipping streams radio-browser found broken. Results are cached
+// for browseCacheTTL.
+func searchOnline(field, query string, limit int) ([]onlineStation, error) {
+	cacheKey := strings.ToLower(fmt.Sprintf("%s|%s|%d", field, query, limit))
+	if stations, ok := readBrowseCache(cacheKey); ok {
+		return stations, nil
+	}
+
+	params := url.Values{}
+	switch {
+	case field == "country" && len(query) == 2:
+		params.Set("countrycode", query)
+	case field == "tag":
+		params.Set("tag", strings.ToLower(query))
+	default:
+		params.Set(field, query)
+	}
+	params.Set("limit", fmt.Sprint(limit))
+	params.Set("hidebroken", "true")
+	params.Set("order", "clickcount")
+	params.Set("reverse", "true")
+
+	var failures []string
+	for _, server := range browseServers() {
+		stations, err := fetchStations("https://"+server+"/json/stations/search?"+params.Encode(), limit)
+		if err != nil {
+			utils.LogDebug("radio-browser server failed", "server", server, "error", err)
+			failures = append(failures, fmt.Sprintf("%s: %v", server, err))
+			continue
+		}
+		writeBrowseCache(cacheKey, stations)
+		return stations, nil
+	}
+
+	return nil, fmt.Errorf("radio-browser.info is unreachable (%s)", strings.Join(failures, "; "))
+}
+
+// browseServers lists the API mirrors from radio-browser's SRV record, as
+// its documentation asks clients to, ending with the round-robin name
+func browseServers() []string {
+	var servers []string
+	if _, records, err := net.LookupSRV("api", "tcp", "radio-browser.info"); err == nil {
+		for _, record := range records {
+			servers = append(servers, strings.TrimSuffix(record.Target, "."))
+		}
+	}
+	return append(servers, browseFallbackServer)
+}
+
+func fetchStations(searchURL string, limit int) ([]onlineStation, error) {
+	req, err := http.NewRequest("GET", searchURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "ql")
+
+	client := &http.Client{Timeout: browseTimeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("server returned status %d", resp.StatusCode)
+	}
+
+	var results []browserStation
+	if err := json.NewDecoder(resp.Body).Decode(&results); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return normalizeStations(results, limit), nil
+}
+
+// normalizeStations turns radio-browser results into name and stream URL,
+// preferring the resolved URL (playlists already followed) and dropping
+// entries without either
+func normalizeStations(results []browserStation, limit int) []onlineStation {
+	var stations []onlineStation
+	for _, r := range results {
+		name := strings.Join(strings.Fields(r.Name), " ")
+		streamURL := strings.TrimSpace(r.URLResolved)
+		if streamURL == "" {
+			streamURL = strings.TrimSpace(r.URL)
+		}
+		if name == "" || streamURL == "" {
+			continue
+		}
+
+		var detail []string
+		if r.CountryCode != "" {
+			detail = append(detail, r.CountryCode)
+		}
+		if r.Codec != "" {
+			detail = append(detail, r.Codec)
+		}
+		if r.Bitrate > 0 {
+			detail = append(detail, fmt.Sprintf("%dk", r.Bitrate))
+		}
+
+		stations = append(stations, onlineStation{Name: name, URL: streamURL, Detail: strings.Join(detail, " ")})
+		if len(stations) == limit {
+			break
+		}
+	}
+	return stations
+}
+
+// browseCacheEntry is one cached search
+type browseCacheEntry struct {
+	Fetched  time.Time       `json:"fetched"`
+	Stations []onlineStation `json:"stations"`
+}
+
+func browseCachePath() string {
+	return filepath.Join(utils.GetCacheDir(), "ql", "radio_browser.json")
+}
+
+func loadBrowseCache() map[string]browseCacheEntry {
+	cache := make(map[string]browseCacheEntry)
+	if data, err := os.ReadFile(browseCachePath()); err == nil {
+		json.Unmarshal(data, &cache)
+	}
+	return cache
+}
+
+func readBrowseCache(key string) ([]onlineStation, bool) {
+	entry, ok := loadBrowseCache()[key]
+	if !ok || time.Since(entry.Fetched) > browseCacheTTL {
+		return nil, false
+	}
+	return entry.Stations, true
+}
+
+// writeBrowseCache stores a search and drops the expired ones, so the file
+// only ever holds the last hour of searches
+func writeBrowseCache(key string, stations []onlineStation) {
+	cache := loadBrowseCache()
+	for k, entry := range cache {
+		if time.Since(entry.Fetched) > browseCacheTTL {
+			delete(cache, k)
+		}
+	}
+	cache[key] = browseCacheEntry{Fetched: time.Now(), Stations: stations}
+
+	data, err := json.Marshal(cache)
+	if err != nil {
+		return
+	}
+	if utils.EnsureDir(filepath.Dir(browseCachePath())) == nil {
+		os.WriteFile(browseCachePath(), data, 0644)
+	}
+}
+
+// stationsTable is the config table saved stations are written to
+const stationsTable = "commands.radio.stations"
+
+// saveStation adds a station to [commands.radio.stations] in config.toml,
+// where it can be renamed or removed like the others, and to cfg so the
+// running menu lists it
+func saveStation(name, streamURL string, cfg *Config, notifCfg *config.NotificationConfig) error {
+	if err := config.SetUserConfigValue(stationsTable, name, streamURL); err != nil {
+		return fmt.Errorf("failed to save %s: %w", name, err)
+	}
+
+	if cfg.RadioStations == nil {
+		cfg.RadioStations = make(map[string]string)
+	}
+	cfg.RadioStations[name] = streamURL
+
+	utils.NotifyWithConfig(notifCfg, "Radio", fmt.Sprintf("Saved %s to your stations", name))
+	return nil
+}
+
+// forgetStation removes a station from [commands.radio.stations] in config.toml
+func forgetStation(name string, notifCfg *config.NotificationConfig) error {
+	if err := config.DeleteUserConfigValue(stationsTable, name); err != nil {
+		return err
+	}
+
+	utils.NotifyWithConfig(notifCfg, "Radio", fmt.Sprintf("Removed %s from your stations", name))
+	return nil
+}
@@ -0,0 +1,160 @@
+package radio
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lvim-tech/ql/pkg/config"
+	"github.com/lvim-tech/ql/pkg/utils"
+)
+
+// probeTimeout bounds a single reachability probe
+const probeTimeout = 5 * time.Second
+
+// maxConcurrentProbes limits how many stations are probed at once
+const maxConcurrentProbes = 8
+
+// StationStatus is the result of probing one station URL
+type StationStatus struct {
+	Name      string
+	URL       string
+	Reachable bool
+	Latency   time.Duration
+	Error     string
+}
+
+// probeStation checks that url answers with a non-error status. Streams are
+// requested with GET (many servers reject HEAD) and closed right after the
+// headers arrive.
+func probeStation(url string) (time.Duration, error) {
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return 0, fmt.Errorf("invalid URL: %w", err)
+	}
+	req.Header.Set("User-Agent", "ql")
+
+	client := &http.Client{Timeout: probeTimeout}
+
+	start := time.Now()
+	resp, err := client.Do(req)
+	if err != nil {
+		// SHOUTcast v1 servers answer "ICY 200 OK", which net/http rejects
+		// as a malformed response even though the stream is up
+		if strings.Contains(err.Error(), `malformed HTTP version "ICY"`) {
+			return time.Since(start), nil
+		}
+		return 0, err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return 0, fmt.Errorf("server returned status %d", resp.StatusCode)
+	}
+
+	return time.Since(start), nil
+}
+
+// checkStations probes all stations concurrently and returns the results
+// sorted by name
+func checkStations(stations map[string]string) []StationStatus {
+	results := make([]StationStatus, 0, len(stations))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, maxConcurrentProbes)
+
+	for name, url := range stations {
+		wg.Add(1)
+		go func(name, url string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			status := StationStatus{Name: name, URL: url}
+			latency, err := probeStation(url)
+			if err != nil {
+				status.Error = err.Error()
+			} else {
+				status.Reachable = true
+				status.Latency = latency
+			}
+
+			mu.Lock()
+			results = append(results, status)
+			mu.Unlock()
+		}(name, url)
+	}
+
+	wg.Wait()
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Name < results[j].Name
+	})
+
+	return results
+}
+
+func formatCheckResults(results []StationStatus) string {
+	var output strings.Builder
+
+	reachable := 0
+	for _, r := range results {
+		if r.Reachable {
+			reachable++
+		}
+	}
+
+	fmt.Fprintf(&output, "Radio Stations - %d/%d reachable\n\n", reachable, len(results))
+
+	for _, r := range results {
+		if r.Reachable {
+			fmt.Fprintf(&output, "✓ %-40s %dms\n", r.Name, r.Latency.Milliseconds())
+		} else {
+			fmt.Fprintf(&output, "✗ %-40s %s\n", r.Name, r.Error)
+		}
+	}
+
+	return output.String()
+}
+
+// showStationCheck probes every configured station and displays the results
+func showStationCheck(cfg *Config, notifCfg *config.NotificationConfig) error {
+	if len(cfg.RadioStations) == 0 {
+		return fmt.Errorf("no radio stations configured")
+	}
+
+	notifyID := utils.ShowPersistentNotificationWithConfig(notifCfg, "Radio", fmt.Sprintf("Checking %d stations...", len(cfg.RadioStations)))
+	results := checkStations(cfg.RadioStations)
+	utils.ClosePersistentNotificationWithConfig(notifCfg, notifyID)
+
+	output := formatCheckResults(results)
+
+	if utils.IsTerminal() {
+		fmt.Print(output)
+		return nil
+	}
+
+	return displayCheckGUI(output)
+}
+
+// displayCheckGUI shows the check results in a text window (see utils.ShowTextReport)
+func displayCheckGUI(data string) error {
+	return utils.ShowTextReport("Radio Stations", data)
+}
+
+// ensureReachable probes a station before playback when probe_before_play
+// is set, so a dead stream is reported instead of a detached mpv dying silently
+func ensureReachable(name, url string, cfg *Config) error {
+	if !cfg.ProbeBeforePlay {
+		return nil
+	}
+
+	if _, err := probeStation(url); err != nil {
+		return fmt.Errorf("%s seems to be down: %w", name, err)
+	}
+
+	return nil
+}
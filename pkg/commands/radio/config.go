@@ -1,17 +1,31 @@
 package radio
 
+import (
+	"path/filepath"
+
+	"github.com/lvim-tech/ql/pkg/utils"
+)
+
 // Config за radio
 type Config struct {
-	Enabled       bool              `toml:"enabled" mapstructure:"enabled"`
-	Volume        int64             `toml:"volume" mapstructure:"volume"`
-	RadioStations map[string]string `toml:"stations" mapstructure:"stations"`
+	Enabled   bool   `toml:"enabled" mapstructure:"enabled"`
+	Volume    int64  `toml:"volume" mapstructure:"volume"`
+	RecordDir string `toml:"record_dir" mapstructure:"record_dir"`
+	// ProbeBeforePlay checks the stream answers before starting mpv
+	ProbeBeforePlay bool              `toml:"probe_before_play" mapstructure:"probe_before_play"`
+	RadioStations   map[string]string `toml:"stations" mapstructure:"stations"`
+	// BrowseLimit caps the stations listed by Browse Online
+	BrowseLimit int `toml:"browse_limit" mapstructure:"browse_limit"`
 }
 
 // DefaultConfig връща default настройки
 func DefaultConfig() Config {
 	return Config{
-		Enabled: true,
-		Volume:  70,
+		Enabled:         true,
+		Volume:          70,
+		RecordDir:       "",
+		ProbeBeforePlay: true,
+		BrowseLimit:     50,
 		RadioStations: map[string]string{
 			"Jazz FM":    "http://live.musictradio.com/JazzFMHigh",
 			"Classic FM": "http://media-ice.musicradio. com/ClassicFMMP3",
@@ -19,3 +33,20 @@ func DefaultConfig() Config {
 		},
 	}
 }
+
+// recordDir returns record_dir, or Radio under the XDG music directory
+// (~/Music/Radio without user-dirs.dirs) when it is unset
+func (c *Config) recordDir() string {
+	if c.RecordDir != "" {
+		return c.RecordDir
+	}
+	return filepath.Join(utils.GetUserDir("MUSIC", "~/Music"), "Radio")
+}
+
+// browseLimit returns browse_limit, defaulting to 50 and capped at 500
+func (c *Config) browseLimit() int {
+	if c.BrowseLimit <= 0 {
+		return 50
+	}
+	return min(c.BrowseLimit, 500)
+}
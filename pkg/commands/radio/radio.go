@@ -16,6 +16,9 @@ func init() {
 	commands.Register(commands.Command{
 		Name:        "radio",
 		Description: "Internet radio player",
+		Requires:    []string{"mpv"},
+		Config:      commands.ConfigOf(DefaultConfig),
+		Background:  isSleepTimer,
 		Run:         Run,
 	})
 }
@@ -43,13 +46,6 @@ func Run(ctx commands.LauncherContext) commands.CommandResult {
 		}
 	}
 
-	if !utils.CommandExists("mpv") {
-		return commands.CommandResult{
-			Success: false,
-			Error:   fmt.Errorf("mpv is not installed"),
-		}
-	}
-
 	notifCfg := ctx.Config().GetNotificationConfig()
 
 	// Check for direct command
@@ -65,7 +61,11 @@ func Run(ctx commands.LauncherContext) commands.CommandResult {
 			options = append(options, "← Back")
 		}
 
-		options = append(options, "Play Station", "Stop Radio")
+		options = append(options, "Play Station", "Browse Online", "Record Stream", "Check Stations")
+		if isRecording() {
+			options = append(options, "Stop Recording")
+		}
+		options = append(options, "Sleep Timer", "Stop Radio")
 
 		choice, err := ctx.Show(options, "Radio")
 		if err != nil {
@@ -84,6 +84,16 @@ func Run(ctx commands.LauncherContext) commands.CommandResult {
 		switch choice {
 		case "Play Station":
 			actionErr = playStation(ctx, &cfg, &notifCfg)
+		case "Browse Online":
+			actionErr = browseOnline(ctx, &cfg, &notifCfg)
+		case "Record Stream":
+			actionErr = recordStation(ctx, &cfg, &notifCfg)
+		case "Check Stations":
+			actionErr = showStationCheck(&cfg, &notifCfg)
+		case "Stop Recording":
+			actionErr = stopRecordingStation(&notifCfg)
+		case "Sleep Timer":
+			actionErr = selectSleepTimer(ctx, &notifCfg)
 		case "Stop Radio":
 			actionErr = stopRadio(&notifCfg)
 		default:
@@ -96,6 +106,9 @@ func Run(ctx commands.LauncherContext) commands.CommandResult {
 			if actionErr.Error() == "cancelled" {
 				return commands.CommandResult{Success: false}
 			}
+			if actionErr.Error() == "back" {
+				continue
+			}
 			// Other error - show and loop back
 			utils.ShowErrorNotificationWithConfig(&notifCfg, "Radio Error", actionErr.Error())
 			continue
@@ -127,10 +140,62 @@ func executeDirectCommand(args []string, cfg *Config, notifCfg *config.Notificat
 			}
 		}
 
+	case "record":
+		if len(args) < 2 {
+			return commands.CommandResult{
+				Success: false,
+				Error:   fmt.Errorf("usage: ql radio record <station name> | ql radio record stop"),
+			}
+		}
+		if len(args) == 2 && strings.ToLower(args[1]) == "stop" {
+			err = stopRecordingStation(notifCfg)
+		} else {
+			stationName, stationURL, findErr := findStation(strings.Join(args[1:], " "), cfg)
+			if findErr != nil {
+				return commands.CommandResult{Success: false, Error: findErr}
+			}
+			err = startRecordingStation(stationName, stationURL, cfg, notifCfg)
+		}
+
+	case "check":
+		err = showStationCheck(cfg, notifCfg)
+
+	case "forget":
+		if len(args) < 2 {
+			return commands.CommandResult{
+				Success: false,
+				Error:   fmt.Errorf("usage: ql radio forget <station name>"),
+			}
+		}
+		err = forgetStation(strings.Join(args[1:], " "), notifCfg)
+
+	case "sleep":
+		if len(args) < 2 {
+			if deadline, ok := sleepTimerDeadline(); ok {
+				fmt.Printf("Radio stops at %s\n", deadline.Format("15:04"))
+				return commands.CommandResult{Success: true}
+			}
+			return commands.CommandResult{
+				Success: false,
+				Error:   fmt.Errorf("usage: ql radio sleep <minutes> | ql radio sleep cancel"),
+			}
+		}
+		switch strings.ToLower(args[1]) {
+		case "cancel":
+			err = cancelSleepTimer(notifCfg)
+		case "run":
+			if len(args) < 3 {
+				return commands.CommandResult{Success: false, Error: fmt.Errorf("usage: ql radio sleep run <minutes>")}
+			}
+			err = runSleepTimer(args[2], notifCfg)
+		default:
+			err = startSleepTimer(args[1], notifCfg)
+		}
+
 	default:
 		return commands.CommandResult{
 			Success: false,
-			Error:   fmt.Errorf("unknown radio action: %s (use:  play, stop)", action),
+			Error:   fmt.Errorf("unknown radio action: %s (use:  play, record, stop, check, sleep, forget)", action),
 		}
 	}
 
@@ -141,24 +206,33 @@ func executeDirectCommand(args []string, cfg *Config, notifCfg *config.Notificat
 	return commands.CommandResult{Success: true}
 }
 
-func playStationDirect(stationName string, cfg *Config, notifCfg *config.NotificationConfig) error {
-	// Find station by name (case-insensitive partial match)
-	var matchedStation string
-	var matchedURL string
-
+// findStation finds a configured station by name (case-insensitive partial match)
+func findStation(stationName string, cfg *Config) (string, string, error) {
 	stationNameLower := strings.ToLower(stationName)
 
 	for name, url := range cfg.RadioStations {
 		nameLower := strings.ToLower(name)
 		if nameLower == stationNameLower || strings.Contains(nameLower, stationNameLower) {
-			matchedStation = name
-			matchedURL = url
-			break
+			return name, url, nil
 		}
 	}
 
-	if matchedURL == "" {
-		return fmt.Errorf("station not found:  %s", stationName)
+	return "", "", fmt.Errorf("station not found:  %s", stationName)
+}
+
+func playStationDirect(stationName string, cfg *Config, notifCfg *config.NotificationConfig) error {
+	matchedStation, matchedURL, err := findStation(stationName, cfg)
+	if err != nil {
+		return err
+	}
+
+	return playURL(matchedStation, matchedURL, cfg, notifCfg)
+}
+
+// playURL replaces whatever radio is playing with the stream at url
+func playURL(name, url string, cfg *Config, notifCfg *config.NotificationConfig) error {
+	if err := ensureReachable(name, url, cfg); err != nil {
+		return err
 	}
 
 	// Stop any playing radio first
@@ -167,14 +241,14 @@ func playStationDirect(stationName string, cfg *Config, notifCfg *config.Notific
 	args := []string{
 		"--no-video",
 		fmt.Sprintf("--volume=%d", cfg.Volume),
-		matchedURL,
+		url,
 	}
 
 	if err := utils.StartDetachedProcess("mpv", args...); err != nil {
-		return fmt.Errorf("failed to start radio:  %w", err)
+		return fmt.Errorf("failed to start radio: %w", err)
 	}
 
-	utils.NotifyWithConfig(notifCfg, "Radio", fmt.Sprintf("Playing: %s", matchedStation))
+	utils.NotifyWithConfig(notifCfg, "Radio", fmt.Sprintf("Playing: %s", name))
 
 	return nil
 }
@@ -210,21 +284,37 @@ func playStation(ctx commands.LauncherContext, cfg *Config, notifCfg *config.Not
 		return fmt.Errorf("station not found:      %s", choice)
 	}
 
-	stopRadio(notifCfg)
+	return playURL(choice, url, cfg, notifCfg)
+}
 
-	args := []string{
-		"--no-video",
-		fmt.Sprintf("--volume=%d", cfg.Volume),
-		url,
+func recordStation(ctx commands.LauncherContext, cfg *Config, notifCfg *config.NotificationConfig) error {
+	var stations []string
+	for name := range cfg.RadioStations {
+		stations = append(stations, name)
 	}
 
-	if err := utils.StartDetachedProcess("mpv", args...); err != nil {
-		return fmt.Errorf("failed to start radio:    %w", err)
+	if len(stations) == 0 {
+		return fmt.Errorf("no radio stations configured")
 	}
 
-	utils.NotifyWithConfig(notifCfg, "Radio", fmt.Sprintf("Playing: %s", choice))
+	stations = append([]string{"← Back"}, stations...)
 
-	return nil
+	choice, err := ctx.Show(stations, "Record Station")
+	if err != nil {
+		// ESC pressed - return "cancelled" to exit completely
+		return fmt.Errorf("cancelled")
+	}
+
+	if choice == "← Back" {
+		return fmt.Errorf("cancelled")
+	}
+
+	url, ok := cfg.RadioStations[choice]
+	if !ok {
+		return fmt.Errorf("station not found: %s", choice)
+	}
+
+	return startRecordingStation(choice, url, cfg, notifCfg)
 }
 
 func stopRadio(notifCfg *config.NotificationConfig) error {
@@ -0,0 +1,162 @@
+package radio
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/lvim-tech/ql/pkg/config"
+	"github.com/lvim-tech/ql/pkg/utils"
+)
+
+const recordPIDFile = "/tmp/ql_radio_record.pid"
+
+// startRecordingStation plays a station through mpv while dumping the stream to record_dir
+func startRecordingStation(stationName, stationURL string, cfg *Config, notifCfg *config.NotificationConfig) error {
+	if isRecording() {
+		return fmt.Errorf("a radio recording is already in progress")
+	}
+
+	recordDir := utils.ExpandPath(cfg.recordDir())
+	if err := utils.EnsureDir(recordDir); err != nil {
+		return fmt.Errorf("failed to create record directory: %w", err)
+	}
+
+	filename := fmt.Sprintf("%s_%s.%s", sanitizeFilename(stationName), utils.GetTimestamp(), streamExtension(stationURL))
+	outputPath := filepath.Join(recordDir, filename)
+
+	// Stop plain playback so only the recording instance is audible
+	stopRadio(notifCfg)
+
+	args := []string{
+		"--no-video",
+		fmt.Sprintf("--volume=%d", cfg.Volume),
+		"--stream-record=" + outputPath,
+		stationURL,
+	}
+
+	cmd := exec.Command("mpv", args...)
+	cmd.Stdin = nil
+	cmd.Stdout = nil
+	cmd.Stderr = nil
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		Setpgid: true,
+		Pgid:    0,
+	}
+
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to start recording: %w", err)
+	}
+
+	pidData := fmt.Sprintf("%d\n%s", cmd.Process.Pid, outputPath)
+	if err := os.WriteFile(recordPIDFile, []byte(pidData), 0644); err != nil {
+		cmd.Process.Kill()
+		return fmt.Errorf("failed to write PID file: %w", err)
+	}
+
+	cmd.Process.Release()
+
+	utils.NotifyWithConfig(notifCfg, "Radio Recording Started", fmt.Sprintf("%s\n%s", stationName, outputPath))
+
+	return nil
+}
+
+// stopRecordingStation stops the mpv instance recorded in the PID file
+func stopRecordingStation(notifCfg *config.NotificationConfig) error {
+	pid, outputPath, err := readRecordPIDFile()
+	if err != nil {
+		return fmt.Errorf("no radio recording in progress")
+	}
+
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		os.Remove(recordPIDFile)
+		return fmt.Errorf("recording process not found")
+	}
+
+	if err := process.Signal(syscall.SIGTERM); err != nil {
+		os.Remove(recordPIDFile)
+		return fmt.Errorf("failed to stop recording: %w", err)
+	}
+
+	// Give mpv a moment to flush the stream dump
+	time.Sleep(500 * time.Millisecond)
+
+	os.Remove(recordPIDFile)
+
+	utils.NotifyWithConfig(notifCfg, "Radio Recording Stopped", fmt.Sprintf("Saved to:\n%s", outputPath))
+
+	return nil
+}
+
+// isRecording reports whether the recorded mpv process is still alive
+func isRecording() bool {
+	pid, _, err := readRecordPIDFile()
+	if err != nil {
+		return false
+	}
+
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+
+	if err := process.Signal(syscall.Signal(0)); err != nil {
+		os.Remove(recordPIDFile)
+		return false
+	}
+
+	return true
+}
+
+func readRecordPIDFile() (int, string, error) {
+	data, err := os.ReadFile(recordPIDFile)
+	if err != nil {
+		return 0, "", err
+	}
+
+	lines := strings.Split(string(data), "\n")
+	if len(lines) < 2 {
+		return 0, "", fmt.Errorf("invalid PID file")
+	}
+
+	var pid int
+	if _, err := fmt.Sscanf(lines[0], "%d", &pid); err != nil {
+		return 0, "", fmt.Errorf("invalid PID file")
+	}
+
+	return pid, strings.TrimSpace(lines[1]), nil
+}
+
+// streamExtension guesses the file extension from the stream URL, defaulting to mp3
+func streamExtension(streamURL string) string {
+	parsed, err := url.Parse(streamURL)
+	if err != nil {
+		return "mp3"
+	}
+
+	ext := strings.TrimPrefix(strings.ToLower(path.Ext(parsed.Path)), ".")
+	switch ext {
+	case "mp3", "aac", "ogg", "opus", "flac", "m4a":
+		return ext
+	}
+
+	return "mp3"
+}
+
+// sanitizeFilename replaces characters that are awkward in file names
+func sanitizeFilename(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '/', '\\', ':', '*', '?', '"', '<', '>', '|', ' ':
+			return '_'
+		}
+		return r
+	}, name)
+}
@@ -0,0 +1,181 @@
+package radio
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/lvim-tech/ql/pkg/commands"
+	"github.com/lvim-tech/ql/pkg/config"
+	"github.com/lvim-tech/ql/pkg/utils"
+)
+
+// sleepPIDFile holds the timer's PID and the time it fires
+const sleepPIDFile = "/tmp/ql_radio_sleep.pid"
+
+// sleepPresets are the durations offered by the Sleep Timer menu, in minutes
+var sleepPresets = []int{15, 30, 45, 60, 90}
+
+// isSleepTimer matches 'ql radio sleep run <minutes>', the detached timer
+func isSleepTimer(args []string) bool {
+	return len(args) >= 2 && args[0] == "sleep" && args[1] == "run"
+}
+
+// selectSleepTimer offers the presets, a typed number of minutes, and
+// cancelling a running timer
+func selectSleepTimer(ctx commands.LauncherContext, notifCfg *config.NotificationConfig) error {
+	options := []string{"← Back"}
+
+	cancelItem := ""
+	if deadline, ok := sleepTimerDeadline(); ok {
+		cancelItem = fmt.Sprintf("Cancel Timer (stops at %s)", deadline.Format("15:04"))
+		options = append(options, cancelItem)
+	}
+
+	for _, minutes := range sleepPresets {
+		options = append(options, fmt.Sprintf("%d minutes", minutes))
+	}
+
+	choice, err := ctx.ShowAllowCustom(options, "Sleep Timer (or type minutes)")
+	if err != nil {
+		return fmt.Errorf("cancelled")
+	}
+
+	switch choice {
+	case "← Back":
+		return fmt.Errorf("back")
+	case cancelItem:
+		return cancelSleepTimer(notifCfg)
+	}
+
+	return startSleepTimer(strings.TrimSuffix(strings.TrimSpace(choice), " minutes"), notifCfg)
+}
+
+// startSleepTimer launches 'ql radio sleep run' detached, replacing a
+// timer that is already running
+func startSleepTimer(arg string, notifCfg *config.NotificationConfig) error {
+	minutes, err := strconv.Atoi(arg)
+	if err != nil || minutes <= 0 {
+		return fmt.Errorf("invalid sleep time: %s (use minutes, e.g. ql radio sleep 45)", arg)
+	}
+
+	if pid, _, err := readSleepPIDFile(); err == nil {
+		syscall.Kill(pid, syscall.SIGTERM)
+		os.Remove(sleepPIDFile)
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		return err
+	}
+
+	if err := utils.StartDetachedProcess(exe, "radio", "sleep", "run", strconv.Itoa(minutes)); err != nil {
+		return fmt.Errorf("failed to start sleep timer: %w", err)
+	}
+
+	deadline := time.Now().Add(time.Duration(minutes) * time.Minute)
+	utils.NotifyWithConfig(notifCfg, "Sleep Timer", fmt.Sprintf("Radio stops in %d min (at %s)", minutes, deadline.Format("15:04")))
+	return nil
+}
+
+// cancelSleepTimer stops the running timer without touching playback
+func cancelSleepTimer(notifCfg *config.NotificationConfig) error {
+	pid, _, err := readSleepPIDFile()
+	if err != nil {
+		return fmt.Errorf("no sleep timer is set")
+	}
+
+	os.Remove(sleepPIDFile)
+	if err := syscall.Kill(pid, syscall.SIGTERM); err != nil {
+		return fmt.Errorf("no sleep timer is set")
+	}
+
+	utils.NotifyWithConfig(notifCfg, "Sleep Timer", "Cancelled")
+	return nil
+}
+
+// runSleepTimer is the detached timer: it waits, then stops the radio,
+// unless it receives SIGTERM first
+func runSleepTimer(arg string, notifCfg *config.NotificationConfig) error {
+	minutes, err := strconv.Atoi(arg)
+	if err != nil || minutes <= 0 {
+		return fmt.Errorf("invalid sleep time: %s", arg)
+	}
+
+	deadline := time.Now().Add(time.Duration(minutes) * time.Minute)
+	pidData := fmt.Sprintf("%d\n%s", os.Getpid(), deadline.Format(time.RFC3339))
+	if err := os.WriteFile(sleepPIDFile, []byte(pidData), 0644); err != nil {
+		return fmt.Errorf("failed to write PID file: %w", err)
+	}
+
+	// A newer timer may have replaced this one's file in the meantime
+	removePID := func() {
+		if pid, _, err := readSleepPIDFile(); err == nil && pid == os.Getpid() {
+			os.Remove(sleepPIDFile)
+		}
+	}
+	release := utils.OnCleanup(removePID)
+	defer func() {
+		release()
+		removePID()
+	}()
+
+	timer := time.NewTimer(time.Until(deadline))
+	defer timer.Stop()
+
+	// Cancelling the timer sends SIGTERM, which cancels InterruptContext
+	select {
+	case <-utils.InterruptContext().Done():
+		return nil
+	case <-timer.C:
+	}
+
+	if err := utils.KillProcessByName("mpv"); err != nil {
+		return nil
+	}
+
+	utils.NotifyWithConfig(notifCfg, "Sleep Timer", "Radio stopped. Good night!")
+	return nil
+}
+
+// sleepTimerDeadline returns when the running timer fires
+func sleepTimerDeadline() (time.Time, bool) {
+	pid, deadline, err := readSleepPIDFile()
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	if err := syscall.Kill(pid, 0); err != nil {
+		os.Remove(sleepPIDFile)
+		return time.Time{}, false
+	}
+
+	return deadline, true
+}
+
+func readSleepPIDFile() (int, time.Time, error) {
+	data, err := os.ReadFile(sleepPIDFile)
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+
+	pidLine, deadlineLine, found := strings.Cut(string(data), "\n")
+	if !found {
+		return 0, time.Time{}, fmt.Errorf("invalid PID file")
+	}
+
+	pid, err := strconv.Atoi(strings.TrimSpace(pidLine))
+	if err != nil {
+		return 0, time.Time{}, fmt.Errorf("invalid PID file")
+	}
+
+	deadline, err := time.Parse(time.RFC3339, strings.TrimSpace(deadlineLine))
+	if err != nil {
+		return 0, time.Time{}, fmt.Errorf("invalid PID file")
+	}
+
+	return pid, deadline, nil
+}
@@ -0,0 +1,116 @@
+package screenshot
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/lvim-tech/ql/pkg/utils"
+)
+
+// annotateTools lists supported editors in auto-detection priority
+var annotateTools = []string{"swappy", "satty", "ksnip"}
+
+// detectAnnotateTool returns the configured editor, or the first installed one for "auto"
+func detectAnnotateTool(preference string) (string, error) {
+	preference = strings.ToLower(strings.TrimSpace(preference))
+
+	if preference == "" || preference == "auto" {
+		for _, tool := range annotateTools {
+			if utils.CommandExists(tool) {
+				return tool, nil
+			}
+		}
+		return "", fmt.Errorf("no annotation tool found (install one of: %s)", strings.Join(annotateTools, ", "))
+	}
+
+	if !utils.CommandExists(preference) {
+		return "", fmt.Errorf("annotation tool %s is configured but not installed", preference)
+	}
+
+	return preference, nil
+}
+
+// buildAnnotateCommand opens inputPath in the editor so that saving writes outputPath.
+// Editors without an output option edit inputPath in place.
+func buildAnnotateCommand(tool, inputPath, outputPath string) *exec.Cmd {
+	switch tool {
+	case "swappy":
+		return exec.Command("swappy", "-f", inputPath, "-o", outputPath)
+	case "satty":
+		return exec.Command("satty", "--filename", inputPath, "--output-filename", outputPath, "--early-exit")
+	case "ksnip":
+		return exec.Command("ksnip", "--edit", inputPath)
+	default:
+		return exec.Command(tool, inputPath)
+	}
+}
+
+// captureAndAnnotate captures to a temp file, opens it in the annotation tool and
+// moves the edited result to outputPath. Returns false if the edit was cancelled.
+func captureAndAnnotate(mode, outputPath string, cfg *Config) (bool, error) {
+	tool, err := detectAnnotateTool(cfg.AnnotateTool)
+	if err != nil {
+		return false, err
+	}
+
+	// Capture into a fresh directory; some tools refuse to overwrite existing files
+	tmpDir, err := os.MkdirTemp("", "ql_screenshot_")
+	if err != nil {
+		return false, fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	tmpPath := filepath.Join(tmpDir, "capture.png")
+
+	if err := takeScreenshot(mode, tmpPath); err != nil {
+		return false, err
+	}
+
+	captured, err := os.Stat(tmpPath)
+	if err != nil {
+		return false, fmt.Errorf("screenshot failed: %w", err)
+	}
+
+	if err := buildAnnotateCommand(tool, tmpPath, outputPath).Run(); err != nil {
+		// Editors exit non-zero when closed without saving
+		os.Remove(outputPath)
+		return false, nil
+	}
+
+	if utils.FileExists(outputPath) {
+		return true, nil
+	}
+
+	// In-place editors: keep the temp file only if it was saved
+	edited, err := os.Stat(tmpPath)
+	if err != nil || !edited.ModTime().After(captured.ModTime()) {
+		return false, nil
+	}
+
+	if err := moveFile(tmpPath, outputPath); err != nil {
+		return false, fmt.Errorf("failed to save annotated screenshot: %w", err)
+	}
+
+	return true, nil
+}
+
+// moveFile renames src to dst, copying when they are on different filesystems
+func moveFile(src, dst string) error {
+	if err := os.Rename(src, dst); err == nil {
+		return nil
+	}
+
+	data, err := os.ReadFile(src)
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(dst, data, 0644); err != nil {
+		return err
+	}
+
+	return os.Remove(src)
+}
@@ -0,0 +1,156 @@
+package screenshot
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/lvim-tech/ql/pkg/config"
+	"github.com/lvim-tech/ql/pkg/utils"
+)
+
+// hexColorRe finds the "#rrggbb" that hyprpicker, xcolor and grabc print
+var hexColorRe = regexp.MustCompile(`#[0-9a-fA-F]{6}\b`)
+
+// pickColor lets the user click a pixel and returns its color, using the
+// first picker available for the display server
+func pickColor() (color.RGBA, error) {
+	if utils.DetectDisplayServer().IsWayland() {
+		switch {
+		case utils.CommandExists("hyprpicker"):
+			return runHexPicker("hyprpicker", "--format=hex", "--no-fancy")
+		case utils.CommandExists("grim") && utils.CommandExists("slurp"):
+			return pickWithGrim()
+		}
+		return color.RGBA{}, fmt.Errorf("no color picker found (install hyprpicker, or grim and slurp)")
+	}
+
+	switch {
+	case utils.CommandExists("xcolor"):
+		return runHexPicker("xcolor", "--format", "hex")
+	case utils.CommandExists("grabc"):
+		return runHexPicker("grabc")
+	}
+	return color.RGBA{}, fmt.Errorf("no color picker found (install xcolor or grabc)")
+}
+
+// runHexPicker runs a picker that prints the chosen color as #rrggbb
+func runHexPicker(name string, args ...string) (color.RGBA, error) {
+	output, err := exec.Command(name, args...).Output()
+	if err != nil {
+		return color.RGBA{}, fmt.Errorf("color picking cancelled")
+	}
+
+	match := hexColorRe.FindString(string(output))
+	if match == "" {
+		return color.RGBA{}, fmt.Errorf("%s returned no color: %s", name, strings.TrimSpace(string(output)))
+	}
+
+	return parseHexColor(match)
+}
+
+// pickWithGrim captures the pixel chosen with 'slurp -p' and reads its color
+// from the PNG. On scaled outputs grim returns a few pixels for the one
+// point; they are all the same color.
+func pickWithGrim() (color.RGBA, error) {
+	geometry, err := exec.Command("slurp", "-p").Output()
+	if err != nil {
+		return color.RGBA{}, fmt.Errorf("color picking cancelled")
+	}
+
+	data, err := exec.Command("grim", "-g", strings.TrimSpace(string(geometry)), "-t", "png", "-").Output()
+	if err != nil {
+		return color.RGBA{}, fmt.Errorf("grim failed: %w", err)
+	}
+
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		return color.RGBA{}, fmt.Errorf("failed to read captured pixel: %w", err)
+	}
+
+	bounds := img.Bounds()
+	return color.RGBAModel.Convert(img.At(bounds.Min.X, bounds.Min.Y)).(color.RGBA), nil
+}
+
+func parseHexColor(hex string) (color.RGBA, error) {
+	value, err := strconv.ParseUint(strings.TrimPrefix(hex, "#"), 16, 32)
+	if err != nil || len(strings.TrimPrefix(hex, "#")) != 6 {
+		return color.RGBA{}, fmt.Errorf("invalid color: %s", hex)
+	}
+	return color.RGBA{R: uint8(value >> 16), G: uint8(value >> 8), B: uint8(value), A: 0xff}, nil
+}
+
+func hexString(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+func rgbString(c color.RGBA) string {
+	return fmt.Sprintf("rgb(%d, %d, %d)", c.R, c.G, c.B)
+}
+
+// formatColor renders c in color_format: "hex" (#1e90ff) or "rgb"
+// (rgb(30, 144, 255))
+func formatColor(c color.RGBA, format string) string {
+	if strings.ToLower(format) == "rgb" {
+		return rgbString(c)
+	}
+	return hexString(c)
+}
+
+// pickAndCopyColor picks a color, copies it in color_format and notifies
+// both notations with a swatch of the color as the icon
+func pickAndCopyColor(cfg *Config, notifCfg *config.NotificationConfig) (string, error) {
+	picked, err := pickColor()
+	if err != nil {
+		return "", err
+	}
+
+	value := formatColor(picked, cfg.ColorFormat)
+	message := fmt.Sprintf("%s\n%s", hexString(picked), rgbString(picked))
+	if err := utils.CopyToClipboard(value); err == nil {
+		message += fmt.Sprintf("\n%s copied to clipboard", value)
+	}
+
+	swatchCfg := *notifCfg
+	if swatch, err := writeSwatch(picked); err == nil {
+		swatchCfg.IconNormal = swatch
+	}
+	utils.NotifyWithConfig(&swatchCfg, "Color Picked", message)
+
+	return value, nil
+}
+
+// writeSwatch saves a square of the color for the notification icon. The
+// file is named after the color and left in place, as the notification
+// daemon reads it after ql has exited.
+func writeSwatch(c color.RGBA) (string, error) {
+	const size = 64
+
+	img := image.NewRGBA(image.Rect(0, 0, size, size))
+	for y := range size {
+		for x := range size {
+			img.SetRGBA(x, y, c)
+		}
+	}
+
+	path := filepath.Join(os.TempDir(), fmt.Sprintf("ql-color-%s.png", strings.TrimPrefix(hexString(c), "#")))
+	file, err := os.Create(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	if err := png.Encode(file, img); err != nil {
+		return "", err
+	}
+
+	return path, nil
+}
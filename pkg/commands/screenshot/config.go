@@ -1,17 +1,52 @@
 package screenshot
 
+import (
+	"path/filepath"
+
+	"github.com/lvim-tech/ql/pkg/utils"
+)
+
 // Config за screenshot
 type Config struct {
-	Enabled    bool   `toml:"enabled" mapstructure:"enabled"`
-	SaveDir    string `toml:"save_dir" mapstructure:"save_dir"`
-	FilePrefix string `toml:"file_prefix" mapstructure:"file_prefix"`
+	Enabled bool   `toml:"enabled" mapstructure:"enabled"`
+	SaveDir string `toml:"save_dir" mapstructure:"save_dir"`
+	// SaveDirLayout is "flat" or "date" (save_dir/YYYY/MM)
+	SaveDirLayout string `toml:"save_dir_layout" mapstructure:"save_dir_layout"`
+	FilePrefix    string `toml:"file_prefix" mapstructure:"file_prefix"`
+	// AnnotateTool is swappy, satty, ksnip or "auto" (first installed)
+	AnnotateTool string `toml:"annotate_tool" mapstructure:"annotate_tool"`
+	// EnableUpload allows '--upload' and the "Upload" menu entry; nothing is
+	// uploaded otherwise
+	EnableUpload bool `toml:"enable_upload" mapstructure:"enable_upload"`
+	// UploadURL receives a multipart POST with the PNG in UploadField
+	UploadURL   string `toml:"upload_url" mapstructure:"upload_url"`
+	UploadField string `toml:"upload_field" mapstructure:"upload_field"`
+	// UploadHeaders are sent with the upload, e.g. an imgur Authorization
+	UploadHeaders map[string]string `toml:"upload_headers" mapstructure:"upload_headers"`
+	// ColorFormat is what "Pick Color" copies: "hex" (#1e90ff) or "rgb"
+	ColorFormat string `toml:"color_format" mapstructure:"color_format"`
 }
 
 // DefaultConfig връща default настройки
 func DefaultConfig() Config {
 	return Config{
-		Enabled:    true,
-		SaveDir:    "~/Pictures/Screenshots",
-		FilePrefix: "screenshot",
+		Enabled:       true,
+		SaveDir:       "",
+		SaveDirLayout: "flat",
+		FilePrefix:    "screenshot",
+		AnnotateTool:  "auto",
+		EnableUpload:  false,
+		UploadURL:     "https://0x0.st",
+		UploadField:   "file",
+		ColorFormat:   "hex",
+	}
+}
+
+// saveDir returns save_dir, or Screenshots under the XDG pictures directory
+// (~/Pictures/Screenshots without user-dirs.dirs) when it is unset
+func (c *Config) saveDir() string {
+	if c.SaveDir != "" {
+		return c.SaveDir
 	}
+	return filepath.Join(utils.GetUserDir("PICTURES", "~/Pictures"), "Screenshots")
 }
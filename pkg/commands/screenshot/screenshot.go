@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os/exec"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/lvim-tech/ql/pkg/commands"
@@ -18,6 +19,7 @@ func init() {
 	commands.Register(commands.Command{
 		Name:        "screenshot",
 		Description: "Take screenshot",
+		Config:      commands.ConfigOf(DefaultConfig),
 		Run:         Run,
 	})
 }
@@ -45,14 +47,6 @@ func Run(ctx commands.LauncherContext) commands.CommandResult {
 		}
 	}
 
-	saveDir := utils.ExpandHomeDir(cfg.SaveDir)
-	if err := utils.EnsureDir(saveDir); err != nil {
-		return commands.CommandResult{
-			Success: false,
-			Error:   fmt.Errorf("failed to create save directory: %w", err),
-		}
-	}
-
 	notifCfg := ctx.Config().GetNotificationConfig()
 
 	// Check for direct command
@@ -72,7 +66,12 @@ func Run(ctx commands.LauncherContext) commands.CommandResult {
 			"Fullscreen",
 			"Active Window",
 			"Select Region",
+			"Annotate",
 		)
+		if cfg.EnableUpload {
+			options = append(options, "Upload")
+		}
+		options = append(options, "Pick Color")
 
 		choice, err := ctx.Show(options, "Screenshot")
 		if err != nil {
@@ -87,29 +86,65 @@ func Run(ctx commands.LauncherContext) commands.CommandResult {
 			}
 		}
 
+		if choice == "Pick Color" {
+			if _, err := pickAndCopyColor(&cfg, &notifCfg); err != nil {
+				utils.ShowErrorNotificationWithConfig(&notifCfg, "Color Picker Error", err.Error())
+				continue
+			}
+			return commands.CommandResult{Success: true}
+		}
+
+		annotate, upload := false, false
+		if choice == "Annotate" || choice == "Upload" {
+			modeChoice, err := ctx.Show([]string{"← Back", "Fullscreen", "Active Window", "Select Region"}, choice+" Screenshot")
+			if err != nil {
+				return commands.CommandResult{Success: false}
+			}
+			if modeChoice == "← Back" {
+				continue
+			}
+			annotate, upload = choice == "Annotate", choice == "Upload"
+			choice = modeChoice
+		}
+
+		saveDir, err := utils.SaveDir(cfg.saveDir(), cfg.SaveDirLayout)
+		if err != nil {
+			return commands.CommandResult{
+				Success: false,
+				Error:   fmt.Errorf("failed to create save directory: %w", err),
+			}
+		}
+
 		timestamp := utils.GetTimestamp()
 		filename := fmt.Sprintf("%s_%s.png", cfg.FilePrefix, timestamp)
 		outputPath := filepath.Join(saveDir, filename)
 
-		server := utils.DetectDisplayServer()
-
-		var cmd *exec.Cmd
-		if server.IsWayland() {
-			cmd, err = buildWaylandCommand(choice, outputPath)
-		} else {
-			cmd, err = buildX11Command(choice, outputPath)
+		if annotate {
+			saved, err := captureAndAnnotate(choice, outputPath, &cfg)
+			if err != nil {
+				utils.ShowErrorNotificationWithConfig(&notifCfg, "Screenshot Error", err.Error())
+				continue
+			}
+			if !saved {
+				utils.NotifyWithConfig(&notifCfg, "Screenshot", "Annotation cancelled, screenshot discarded")
+				return commands.CommandResult{Success: false}
+			}
+			utils.NotifyWithConfig(&notifCfg, "Screenshot saved", filename)
+			return commands.CommandResult{Success: true}
 		}
 
-		if err != nil {
-			// Error building command - show notification and loop back
+		if err := takeScreenshot(choice, outputPath); err != nil {
+			// Screenshot failed - show notification and loop back
 			utils.ShowErrorNotificationWithConfig(&notifCfg, "Screenshot Error", err.Error())
 			continue
 		}
 
-		if err := cmd.Run(); err != nil {
-			// Screenshot failed - show notification and loop back
-			utils.ShowErrorNotificationWithConfig(&notifCfg, "Screenshot Error", fmt.Sprintf("Screenshot failed: %v", err))
-			continue
+		if upload {
+			if err := uploadAndCopy(outputPath, &cfg, &notifCfg); err != nil {
+				utils.ShowErrorNotificationWithConfig(&notifCfg, "Screenshot Error", fmt.Sprintf("Saved %s, but %v", filename, err))
+				return commands.CommandResult{Success: false}
+			}
+			return commands.CommandResult{Success: true}
 		}
 
 		// Screenshot succeeded - show notification and exit
@@ -122,6 +157,32 @@ func Run(ctx commands.LauncherContext) commands.CommandResult {
 func executeDirectCommand(args []string, cfg *Config, notifCfg *config.NotificationConfig) commands.CommandResult {
 	mode := strings.ToLower(args[0])
 
+	if mode == "color" || mode == "colour" {
+		value, err := pickAndCopyColor(cfg, notifCfg)
+		if err != nil {
+			return commands.CommandResult{Success: false, Error: err}
+		}
+		return commands.CommandResult{Success: true, Message: value}
+	}
+
+	// ql screenshot annotate [mode] is shorthand for ql screenshot <mode> --annotate
+	annotate := slices.Contains(args[1:], "--annotate")
+	// Uploading only ever happens on request: --upload here or "Upload" in the menu
+	upload := slices.Contains(args[1:], "--upload")
+	if upload && !cfg.EnableUpload {
+		return commands.CommandResult{
+			Success: false,
+			Error:   fmt.Errorf("upload is disabled (set enable_upload = true in [commands.screenshot])"),
+		}
+	}
+	if mode == "annotate" {
+		annotate = true
+		mode = "region"
+		if len(args) > 1 && !strings.HasPrefix(args[1], "--") {
+			mode = strings.ToLower(args[1])
+		}
+	}
+
 	var screenshotMode string
 
 	switch mode {
@@ -137,12 +198,12 @@ func executeDirectCommand(args []string, cfg *Config, notifCfg *config.Notificat
 	default:
 		return commands.CommandResult{
 			Success: false,
-			Error:   fmt.Errorf("unknown screenshot mode: %s (use:  full, window, region)", mode),
+			Error:   fmt.Errorf("unknown screenshot mode: %s (use:  full, window, region, annotate, color)", mode),
 		}
 	}
 
-	saveDir := utils.ExpandHomeDir(cfg.SaveDir)
-	if err := utils.EnsureDir(saveDir); err != nil {
+	saveDir, err := utils.SaveDir(cfg.saveDir(), cfg.SaveDirLayout)
+	if err != nil {
 		return commands.CommandResult{
 			Success: false,
 			Error:   fmt.Errorf("failed to create save directory:  %w", err),
@@ -153,40 +214,60 @@ func executeDirectCommand(args []string, cfg *Config, notifCfg *config.Notificat
 	filename := fmt.Sprintf("%s_%s.png", cfg.FilePrefix, timestamp)
 	outputPath := filepath.Join(saveDir, filename)
 
-	server := utils.DetectDisplayServer()
+	if annotate {
+		saved, err := captureAndAnnotate(screenshotMode, outputPath, cfg)
+		if err != nil {
+			return commands.CommandResult{Success: false, Error: err}
+		}
+		if !saved {
+			utils.NotifyWithConfig(notifCfg, "Screenshot", "Annotation cancelled, screenshot discarded")
+			return commands.CommandResult{Success: false}
+		}
+	} else if err := takeScreenshot(screenshotMode, outputPath); err != nil {
+		return commands.CommandResult{Success: false, Error: err}
+	}
+
+	if upload {
+		if err := uploadAndCopy(outputPath, cfg, notifCfg); err != nil {
+			return commands.CommandResult{Success: false, Error: fmt.Errorf("saved %s, but %w", filename, err)}
+		}
+		return commands.CommandResult{Success: true}
+	}
+
+	utils.NotifyWithConfig(notifCfg, "Screenshot saved", filename)
+
+	return commands.CommandResult{Success: true}
+}
 
+// takeScreenshot captures the given mode to outputPath with the display server's tool
+func takeScreenshot(mode, outputPath string) error {
 	var cmd *exec.Cmd
 	var err error
 
-	if server.IsWayland() {
-		cmd, err = buildWaylandCommand(screenshotMode, outputPath)
+	if utils.DetectDisplayServer().IsWayland() {
+		cmd, err = buildWaylandCommand(mode, outputPath)
 	} else {
-		cmd, err = buildX11Command(screenshotMode, outputPath)
+		cmd, err = buildX11Command(mode, outputPath)
 	}
 
 	if err != nil {
-		return commands.CommandResult{Success: false, Error: err}
+		return err
 	}
 
 	if err := cmd.Run(); err != nil {
-		return commands.CommandResult{
-			Success: false,
-			Error:   fmt.Errorf("screenshot failed: %w", err),
-		}
+		return fmt.Errorf("screenshot failed: %w", err)
 	}
 
-	utils.NotifyWithConfig(notifCfg, "Screenshot saved", filename)
-
-	return commands.CommandResult{Success: true}
+	return nil
 }
 
 func buildWaylandCommand(mode, outputPath string) (*exec.Cmd, error) {
-	compositor := detectCompositor()
+	compositor := utils.DetectCompositor()
 
-	if compositor == "gnome" {
+	switch compositor {
+	case utils.CompositorGNOME:
 		return buildGNOMECommand(mode, outputPath)
-	}
-	if compositor == "kde" {
+	case utils.CompositorKDE:
 		return buildKDECommand(mode, outputPath)
 	}
 
@@ -199,8 +280,16 @@ func buildWaylandCommand(mode, outputPath string) (*exec.Cmd, error) {
 		return exec.Command("grim", outputPath), nil
 
 	case "Active Window":
-		return exec.Command("sh", "-c",
-			fmt.Sprintf("grim -g \"$(swaymsg -t get_tree | jq -r '..  | select(.focused?) | .rect | \"\\(.x),\\(.y) \\(.width)x\\(.height)\"')\" %s", outputPath)), nil
+		switch compositor := utils.WindowCompositor(); compositor {
+		case utils.CompositorSway:
+			return exec.Command("sh", "-c",
+				fmt.Sprintf("grim -g \"$(swaymsg -t get_tree | jq -r '..  | select(.focused?) | .rect | \"\\(.x),\\(.y) \\(.width)x\\(.height)\"')\" %s", outputPath)), nil
+		case utils.CompositorHyprland:
+			return exec.Command("sh", "-c",
+				fmt.Sprintf("grim -g \"$(hyprctl activewindow -j | jq -r '\"\\(.at[0]),\\(.at[1]) \\(.size[0])x\\(.size[1])\"')\" %s", outputPath)), nil
+		default:
+			return nil, fmt.Errorf("active window capture is not supported on %s", compositor)
+		}
 
 	case "Select Region":
 		if !utils.CommandExists("slurp") {
@@ -277,14 +366,3 @@ func buildKDECommand(mode, outputPath string) (*exec.Cmd, error) {
 		return nil, fmt.Errorf("unknown mode: %s", mode)
 	}
 }
-
-func detectCompositor() string {
-	desktop := utils.GetCurrentDesktop()
-	if desktop == "GNOME" {
-		return "gnome"
-	}
-	if desktop == "KDE" {
-		return "kde"
-	}
-	return ""
-}
@@ -0,0 +1,140 @@
+package screenshot
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/lvim-tech/ql/pkg/config"
+	"github.com/lvim-tech/ql/pkg/utils"
+)
+
+// uploadTimeout bounds the whole upload, including reading the response
+const uploadTimeout = 30 * time.Second
+
+// uploadFile POSTs path as a multipart form to upload_url, with the file in
+// upload_field, and returns the URL the host answered with
+func uploadFile(path string, cfg *Config) (string, error) {
+	if !cfg.EnableUpload {
+		return "", fmt.Errorf("upload is disabled (set enable_upload = true in [commands.screenshot])")
+	}
+	if cfg.UploadURL == "" {
+		return "", fmt.Errorf("upload_url is not set")
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to open screenshot: %w", err)
+	}
+	defer file.Close()
+
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+
+	field := cfg.UploadField
+	if field == "" {
+		field = "file"
+	}
+
+	part, err := form.CreateFormFile(field, filepath.Base(path))
+	if err != nil {
+		return "", err
+	}
+	if _, err := io.Copy(part, file); err != nil {
+		return "", fmt.Errorf("failed to read screenshot: %w", err)
+	}
+	if err := form.Close(); err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, cfg.UploadURL, &body)
+	if err != nil {
+		return "", fmt.Errorf("invalid upload_url: %w", err)
+	}
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	req.Header.Set("User-Agent", "ql")
+	for name, value := range cfg.UploadHeaders {
+		req.Header.Set(name, value)
+	}
+
+	client := &http.Client{Timeout: uploadTimeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("upload failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read upload response: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", fmt.Errorf("upload failed: %s: %s", resp.Status, firstLine(string(data)))
+	}
+
+	return parseUploadResponse(data)
+}
+
+// parseUploadResponse reads the URL from the host's answer: plain text as
+// from 0x0.st, or JSON with "url"/"link", nested under "data" for imgur
+func parseUploadResponse(data []byte) (string, error) {
+	text := strings.TrimSpace(string(data))
+
+	var resp struct {
+		URL  string `json:"url"`
+		Link string `json:"link"`
+		Data struct {
+			URL  string `json:"url"`
+			Link string `json:"link"`
+		} `json:"data"`
+	}
+	if json.Unmarshal(data, &resp) == nil {
+		for _, url := range []string{resp.Data.Link, resp.Data.URL, resp.Link, resp.URL} {
+			if url != "" {
+				return url, nil
+			}
+		}
+		return "", fmt.Errorf("no URL in upload response: %s", firstLine(text))
+	}
+
+	if !strings.HasPrefix(text, "http://") && !strings.HasPrefix(text, "https://") {
+		return "", fmt.Errorf("unexpected upload response: %s", firstLine(text))
+	}
+
+	return firstLine(text), nil
+}
+
+// uploadAndCopy uploads a saved screenshot, puts the URL on the clipboard and
+// notifies it. The local file is kept either way.
+func uploadAndCopy(path string, cfg *Config, notifCfg *config.NotificationConfig) error {
+	notifyID := utils.ShowPersistentNotificationWithConfig(notifCfg, "Screenshot", "Uploading...")
+	url, err := uploadFile(path, cfg)
+	utils.ClosePersistentNotificationWithConfig(notifCfg, notifyID)
+	if err != nil {
+		return err
+	}
+
+	if err := utils.CopyToClipboard(url); err != nil {
+		utils.NotifyWithConfig(notifCfg, "Screenshot uploaded", url)
+		return nil
+	}
+
+	utils.NotifyWithConfig(notifCfg, "Screenshot uploaded", url+"\nURL copied to clipboard")
+	return nil
+}
+
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(s), "\n")
+	if runes := []rune(line); len(runes) > 200 {
+		line = string(runes[:200]) + "…"
+	}
+	return line
+}
@@ -0,0 +1,87 @@
+package commands
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// SubcommandInfo describes a direct module action for help and completion
+type SubcommandInfo struct {
+	Name    string
+	Aliases []string
+	// Usage is the argument synopsis, e.g. "[seconds]"
+	Usage string
+}
+
+// Subcommand declares a direct module action, e.g. "next" in 'ql mpc next'.
+// E is the module's own state (context, config) handed to Run.
+type Subcommand[E any] struct {
+	Name    string
+	Aliases []string
+	Usage   string
+	// Run gets the arguments following the subcommand name
+	Run func(env E, args []string) CommandResult
+}
+
+// SubcommandTable is a module's list of direct actions, declared once and
+// used both to dispatch 'ql <module> <action>' and to describe the module
+type SubcommandTable[E any] []Subcommand[E]
+
+// Find returns the subcommand whose name or alias matches (case-insensitive)
+func (t SubcommandTable[E]) Find(name string) (Subcommand[E], bool) {
+	name = strings.ToLower(name)
+	for _, sub := range t {
+		if sub.Name == name || slices.Contains(sub.Aliases, name) {
+			return sub, true
+		}
+	}
+	return Subcommand[E]{}, false
+}
+
+// Names returns the primary subcommand names in declaration order
+func (t SubcommandTable[E]) Names() []string {
+	names := make([]string, 0, len(t))
+	for _, sub := range t {
+		names = append(names, sub.Name)
+	}
+	return names
+}
+
+// Info returns the table without handlers, for Command.Subcommands
+func (t SubcommandTable[E]) Info() []SubcommandInfo {
+	info := make([]SubcommandInfo, 0, len(t))
+	for _, sub := range t {
+		info = append(info, SubcommandInfo{Name: sub.Name, Aliases: sub.Aliases, Usage: sub.Usage})
+	}
+	return info
+}
+
+// Dispatch runs the subcommand named by args[0] with the remaining arguments.
+// Unknown names fail with the list of available subcommands.
+func (t SubcommandTable[E]) Dispatch(module string, env E, args []string) CommandResult {
+	if len(args) == 0 {
+		return CommandResult{
+			Success: false,
+			Error:   fmt.Errorf("missing %s subcommand (available: %s)", module, strings.Join(t.Names(), ", ")),
+		}
+	}
+
+	sub, ok := t.Find(args[0])
+	if !ok {
+		return CommandResult{
+			Success: false,
+			Error:   fmt.Errorf("unknown %s subcommand: %s (available: %s)", module, args[0], strings.Join(t.Names(), ", ")),
+		}
+	}
+
+	return sub.Run(env, args[1:])
+}
+
+// ResultOf turns an action error into a CommandResult
+func ResultOf(err error) CommandResult {
+	if err != nil {
+		return CommandResult{Success: false, Error: err}
+	}
+	return CommandResult{Success: true}
+}
@@ -0,0 +1,126 @@
+package videorecord
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/lvim-tech/ql/pkg/utils"
+)
+
+// Encoders offered as alternatives when the configured codec is missing,
+// in order of preference
+var (
+	preferredVideoEncoders = []string{"libx264", "libx265", "libvpx-vp9", "h264_vaapi", "mpeg4"}
+	preferredAudioEncoders = []string{"aac", "libopus", "libmp3lame", "libvorbis"}
+)
+
+// checkCodecs verifies that the configured encoders exist in the installed
+// ffmpeg build. wf-recorder uses the same libav encoders, so it is checked the
+// same way. Without ffmpeg the encoder list is unknown and the check is skipped.
+func checkCodecs(videoCodec, audioCodec string, recordAudio bool) error {
+	encoders, err := availableEncoders()
+	if err != nil {
+		return nil
+	}
+
+	if err := checkEncoder("video", videoCodec, 'V', encoders, preferredVideoEncoders); err != nil {
+		return err
+	}
+
+	if recordAudio {
+		return checkEncoder("audio", audioCodec, 'A', encoders, preferredAudioEncoders)
+	}
+
+	return nil
+}
+
+func checkEncoder(kind, codec string, flag byte, encoders map[string]byte, preferred []string) error {
+	if codec == "" || encoders[codec] == flag {
+		return nil
+	}
+
+	var alternatives []string
+	for _, name := range preferred {
+		if name != codec && encoders[name] == flag {
+			alternatives = append(alternatives, name)
+		}
+		if len(alternatives) == 2 {
+			break
+		}
+	}
+
+	if len(alternatives) == 0 {
+		return fmt.Errorf("%s codec %s is not available in this ffmpeg build", kind, codec)
+	}
+
+	return fmt.Errorf("%s codec %s is not available in this ffmpeg build, try %s",
+		kind, codec, strings.Join(alternatives, " or "))
+}
+
+// availableEncoders returns encoder name -> type (V, A or S) from
+// 'ffmpeg -encoders'. The list is cached per ffmpeg binary, since the call
+// is slow and only changes when ffmpeg is upgraded.
+func availableEncoders() (map[string]byte, error) {
+	ffmpegPath, err := exec.LookPath("ffmpeg")
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := os.Stat(ffmpegPath)
+	if err != nil {
+		return nil, err
+	}
+
+	cachePath := filepath.Join(utils.GetCacheDir(), "ql", "ffmpeg_encoders.txt")
+	cacheKey := fmt.Sprintf("%s %d", ffmpegPath, info.ModTime().Unix())
+
+	if data, err := os.ReadFile(cachePath); err == nil {
+		if key, output, found := strings.Cut(string(data), "\n"); found && key == cacheKey {
+			return parseEncoders(output), nil
+		}
+	}
+
+	output, err := utils.RunCommand("ffmpeg", "-hide_banner", "-encoders")
+	if err != nil {
+		return nil, err
+	}
+
+	if utils.EnsureDir(filepath.Dir(cachePath)) == nil {
+		os.WriteFile(cachePath, []byte(cacheKey+"\n"+output), 0644)
+	}
+
+	return parseEncoders(output), nil
+}
+
+// parseEncoders parses 'ffmpeg -encoders'. A legend precedes a " ------"
+// separator; each following line is " V....D libx264   description".
+func parseEncoders(output string) map[string]byte {
+	encoders := make(map[string]byte)
+	listing := false
+
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		if !listing {
+			listing = strings.HasPrefix(fields[0], "---")
+			continue
+		}
+
+		if len(fields) < 2 || len(fields[0]) != 6 {
+			continue
+		}
+
+		if kind := fields[0][0]; slices.Contains([]byte("VAS"), kind) {
+			encoders[fields[1]] = kind
+		}
+	}
+
+	return encoders
+}
@@ -1,16 +1,25 @@
 package videorecord
 
+import (
+	"path/filepath"
+
+	"github.com/lvim-tech/ql/pkg/utils"
+)
+
 // Config за video recording
 type Config struct {
-	Enabled     bool          `toml:"enabled" mapstructure:"enabled"`
-	SaveDir     string        `toml:"save_dir" mapstructure:"save_dir"`
-	FilePrefix  string        `toml:"file_prefix" mapstructure:"file_prefix"`
-	Format      string        `toml:"format" mapstructure:"format"`
-	Quality     string        `toml:"quality" mapstructure:"quality"`
-	RecordAudio bool          `toml:"record_audio" mapstructure:"record_audio"`
-	ShowNotify  bool          `toml:"show_notify" mapstructure:"show_notify"`
-	X11         X11Config     `toml:"x11" mapstructure:"x11"`
-	Wayland     WaylandConfig `toml:"wayland" mapstructure:"wayland"`
+	Enabled bool   `toml:"enabled" mapstructure:"enabled"`
+	SaveDir string `toml:"save_dir" mapstructure:"save_dir"`
+	// SaveDirLayout is "flat" or "date" (save_dir/YYYY/MM)
+	SaveDirLayout string        `toml:"save_dir_layout" mapstructure:"save_dir_layout"`
+	FilePrefix    string        `toml:"file_prefix" mapstructure:"file_prefix"`
+	Format        string        `toml:"format" mapstructure:"format"`
+	Quality       string        `toml:"quality" mapstructure:"quality"`
+	RecordAudio   bool          `toml:"record_audio" mapstructure:"record_audio"`
+	ShowNotify    bool          `toml:"show_notify" mapstructure:"show_notify"`
+	StartDelay    int           `toml:"start_delay" mapstructure:"start_delay"`
+	X11           X11Config     `toml:"x11" mapstructure:"x11"`
+	Wayland       WaylandConfig `toml:"wayland" mapstructure:"wayland"`
 }
 
 type X11Config struct {
@@ -32,13 +41,15 @@ type WaylandConfig struct {
 // DefaultConfig връща default настройки
 func DefaultConfig() Config {
 	return Config{
-		Enabled:     true,
-		SaveDir:     "~/Videos/Recordings",
-		FilePrefix:  "screencast",
-		Format:      "mp4",
-		Quality:     "23",
-		RecordAudio: true,
-		ShowNotify:  true,
+		Enabled:       true,
+		SaveDir:       "",
+		SaveDirLayout: "flat",
+		FilePrefix:    "screencast",
+		Format:        "mp4",
+		Quality:       "23",
+		RecordAudio:   true,
+		ShowNotify:    true,
+		StartDelay:    0,
 		X11: X11Config{
 			Framerate:  60,
 			OutputFPS:  30,
@@ -55,3 +66,12 @@ func DefaultConfig() Config {
 		},
 	}
 }
+
+// saveDir returns save_dir, or Recordings under the XDG videos directory
+// (~/Videos/Recordings without user-dirs.dirs) when it is unset
+func (c *Config) saveDir() string {
+	if c.SaveDir != "" {
+		return c.SaveDir
+	}
+	return filepath.Join(utils.GetUserDir("VIDEOS", "~/Videos"), "Recordings")
+}
@@ -0,0 +1,49 @@
+package videorecord
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/lvim-tech/ql/pkg/config"
+	"github.com/lvim-tech/ql/pkg/utils"
+)
+
+// countdownMarker replaces the output path in the PID file while the
+// countdown runs, so 'ql videorecord stop' knows nothing is recording yet
+const countdownMarker = "countdown"
+
+// waitForStartDelay shows a start_delay countdown before recording begins.
+// The countdown is aborted by Ctrl+C or by 'ql videorecord stop' (e.g. from the
+// keybinding that normally stops recordings), in which case nothing is recorded:
+// the interrupt handler in main runs the cleanup registered here and exits.
+func waitForStartDelay(cfg *Config, notifCfg *config.NotificationConfig) error {
+	if cfg.StartDelay <= 0 {
+		return nil
+	}
+
+	// Register this process so 'ql videorecord stop' can interrupt the countdown
+	pidData := fmt.Sprintf("%d\n%s", os.Getpid(), countdownMarker)
+	if err := os.WriteFile(pidFile, []byte(pidData), 0644); err != nil {
+		return fmt.Errorf("failed to write PID file: %w", err)
+	}
+	defer os.Remove(pidFile)
+
+	release := utils.OnCleanup(func() {
+		os.Remove(pidFile)
+		utils.NotifyWithConfig(notifCfg, "Video recording", "Recording cancelled")
+	})
+	defer release()
+
+	// Each step replaces the previous one on screen by expiring after a second
+	stepCfg := *notifCfg
+	stepCfg.Timeout = 1000
+
+	for remaining := cfg.StartDelay; remaining > 0; remaining-- {
+		utils.NotifyWithConfig(&stepCfg, "Video recording", fmt.Sprintf("Starting in %d...", remaining))
+
+		time.Sleep(time.Second)
+	}
+
+	return nil
+}
@@ -3,6 +3,7 @@
 package videorecord
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"os/exec"
@@ -17,10 +18,13 @@ import (
 	"github.com/mitchellh/mapstructure"
 )
 
+const pidFile = "/tmp/ql_videorecord.pid"
+
 func init() {
 	commands.Register(commands.Command{
 		Name:        "videorecord",
 		Description: "Record screen video",
+		Config:      commands.ConfigOf(DefaultConfig),
 		Run:         Run,
 	})
 }
@@ -68,7 +72,7 @@ func Run(ctx commands.LauncherContext) commands.CommandResult {
 			"Stop Recording",
 		)
 
-		choice, err := ctx.Show(options, "Video Record")
+		choice, err := ctx.ShowWithMessage(options, "Video Record", recordingStatus())
 		if err != nil {
 			// ESC pressed at main menu - exit completely
 			return commands.CommandResult{Success: false}
@@ -120,7 +124,7 @@ func executeDirectCommand(ctx commands.LauncherContext, args []string, cfg *Conf
 		// If region is provided, start recording directly with that region
 		if len(args) > 1 {
 			region := strings.ToLower(args[1])
-			err = startRecordingDirect(region, cfg, notifCfg)
+			err = startRecordingDirect(region, args[2:], cfg, notifCfg)
 		} else {
 			// Otherwise show region selection menu
 			err = startRecording(ctx, cfg, notifCfg)
@@ -140,8 +144,9 @@ func executeDirectCommand(ctx commands.LauncherContext, args []string, cfg *Conf
 	return commands.CommandResult{Success: true}
 }
 
-func startRecordingDirect(regionArg string, cfg *Config, notifCfg *config.NotificationConfig) error {
+func startRecordingDirect(regionArg string, regionArgs []string, cfg *Config, notifCfg *config.NotificationConfig) error {
 	var region string
+	var monitor utils.Monitor
 
 	switch regionArg {
 	case "full", "fullscreen":
@@ -150,12 +155,22 @@ func startRecordingDirect(regionArg string, cfg *Config, notifCfg *config.Notifi
 		region = "Active Window"
 	case "region", "area", "select":
 		region = "Select Region"
+	case "output", "monitor":
+		if len(regionArgs) == 0 {
+			return fmt.Errorf("usage: ql videorecord start output <name>")
+		}
+		found, err := utils.FindMonitor(regionArgs[0])
+		if err != nil {
+			return err
+		}
+		region = "Select Output"
+		monitor = found
 	default:
-		return fmt.Errorf("unknown region: %s (use: full, window, region)", regionArg)
+		return fmt.Errorf("unknown region: %s (use: full, window, region, output)", regionArg)
 	}
 
-	saveDir := utils.ExpandHomeDir(cfg.SaveDir)
-	if err := utils.EnsureDir(saveDir); err != nil {
+	saveDir, err := utils.SaveDir(cfg.saveDir(), cfg.SaveDirLayout)
+	if err != nil {
 		return fmt.Errorf("failed to create save directory: %w", err)
 	}
 
@@ -166,15 +181,14 @@ func startRecordingDirect(regionArg string, cfg *Config, notifCfg *config.Notifi
 	isWayland := os.Getenv("WAYLAND_DISPLAY") != ""
 
 	var cmd *exec.Cmd
-	var err error
 
 	if isWayland {
-		cmd, err = buildWaylandCommand(region, outputPath, cfg, notifCfg)
+		cmd, err = buildWaylandCommand(region, monitor, outputPath, cfg, notifCfg)
 		if err != nil {
 			return err
 		}
 	} else {
-		cmd, err = buildX11Command(region, outputPath, cfg)
+		cmd, err = buildX11Command(region, monitor, outputPath, cfg)
 		if err != nil {
 			return err
 		}
@@ -189,7 +203,9 @@ func startRecordingDirect(regionArg string, cfg *Config, notifCfg *config.Notifi
 		Pgid:    0,
 	}
 
-	pidFile := "/tmp/ql_videorecord.pid"
+	if err := waitForStartDelay(cfg, notifCfg); err != nil {
+		return err
+	}
 
 	if err := cmd.Start(); err != nil {
 		return fmt.Errorf("failed to start recording: %w", err)
@@ -211,8 +227,8 @@ func startRecordingDirect(regionArg string, cfg *Config, notifCfg *config.Notifi
 }
 
 func startRecording(ctx commands.LauncherContext, cfg *Config, notifCfg *config.NotificationConfig) error {
-	saveDir := utils.ExpandHomeDir(cfg.SaveDir)
-	if err := utils.EnsureDir(saveDir); err != nil {
+	saveDir, err := utils.SaveDir(cfg.saveDir(), cfg.SaveDirLayout)
+	if err != nil {
 		return fmt.Errorf("failed to create save directory:    %w", err)
 	}
 
@@ -227,6 +243,7 @@ func startRecording(ctx commands.LauncherContext, cfg *Config, notifCfg *config.
 		"Fullscreen",
 		"Active Window",
 		"Select Region",
+		"Select Output",
 	}
 
 	regionChoice, err := ctx.Show(regionOptions, "Recording Region")
@@ -240,15 +257,23 @@ func startRecording(ctx commands.LauncherContext, cfg *Config, notifCfg *config.
 		return fmt.Errorf("cancelled")
 	}
 
+	var monitor utils.Monitor
+	if regionChoice == "Select Output" {
+		monitor, err = selectMonitor(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
 	var cmd *exec.Cmd
 
 	if isWayland {
-		cmd, err = buildWaylandCommand(regionChoice, outputPath, cfg, notifCfg)
+		cmd, err = buildWaylandCommand(regionChoice, monitor, outputPath, cfg, notifCfg)
 		if err != nil {
 			return err
 		}
 	} else {
-		cmd, err = buildX11Command(regionChoice, outputPath, cfg)
+		cmd, err = buildX11Command(regionChoice, monitor, outputPath, cfg)
 		if err != nil {
 			return err
 		}
@@ -263,7 +288,9 @@ func startRecording(ctx commands.LauncherContext, cfg *Config, notifCfg *config.
 		Pgid:    0,
 	}
 
-	pidFile := "/tmp/ql_videorecord. pid"
+	if err := waitForStartDelay(cfg, notifCfg); err != nil {
+		return err
+	}
 
 	if err := cmd.Start(); err != nil {
 		return fmt.Errorf("failed to start recording:      %w", err)
@@ -284,11 +311,15 @@ func startRecording(ctx commands.LauncherContext, cfg *Config, notifCfg *config.
 	return nil
 }
 
-func buildWaylandCommand(region, outputPath string, cfg *Config, notifCfg *config.NotificationConfig) (*exec.Cmd, error) {
+func buildWaylandCommand(region string, monitor utils.Monitor, outputPath string, cfg *Config, notifCfg *config.NotificationConfig) (*exec.Cmd, error) {
 	if !utils.CommandExists("wf-recorder") {
 		return nil, fmt.Errorf("wf-recorder is not installed (required for Wayland)")
 	}
 
+	if err := checkCodecs(cfg.Wayland.VideoCodec, cfg.Wayland.AudioCodec, cfg.RecordAudio); err != nil {
+		return nil, err
+	}
+
 	args := []string{
 		"-f", outputPath,
 		"-c", cfg.Wayland.VideoCodec,
@@ -309,7 +340,7 @@ func buildWaylandCommand(region, outputPath string, cfg *Config, notifCfg *confi
 		windowGeometry, err := getWaylandActiveWindow()
 		if err != nil {
 			if cfg.ShowNotify {
-				utils.NotifyWithConfig(notifCfg, "Warning", "Active window not supported, using fullscreen")
+				utils.NotifyWithConfig(notifCfg, "Warning", fmt.Sprintf("Active window unavailable (%v), using fullscreen", err))
 			}
 		} else {
 			args = append(args, "-g", windowGeometry)
@@ -327,16 +358,23 @@ func buildWaylandCommand(region, outputPath string, cfg *Config, notifCfg *confi
 		}
 
 		args = append(args, "-g", strings.TrimSpace(string(geometry)))
+
+	case "Select Output":
+		args = append(args, "-o", monitor.Name)
 	}
 
 	return exec.Command("wf-recorder", args...), nil
 }
 
-func buildX11Command(region, outputPath string, cfg *Config) (*exec.Cmd, error) {
+func buildX11Command(region string, monitor utils.Monitor, outputPath string, cfg *Config) (*exec.Cmd, error) {
 	if !utils.CommandExists("ffmpeg") {
 		return nil, fmt.Errorf("ffmpeg is not installed")
 	}
 
+	if err := checkCodecs(cfg.X11.VideoCodec, cfg.X11.AudioCodec, cfg.RecordAudio); err != nil {
+		return nil, err
+	}
+
 	args := []string{
 		"-f", "x11grab",
 		"-framerate", fmt.Sprintf("%d", cfg.X11.Framerate),
@@ -376,6 +414,11 @@ func buildX11Command(region, outputPath string, cfg *Config) (*exec.Cmd, error)
 		} else {
 			return nil, fmt.Errorf("invalid geometry from slop")
 		}
+
+	case "Select Output":
+		// x11grab captures the virtual screen; the monitor is a window into it
+		args = append(args, "-video_size", monitor.Geometry())
+		args = append(args, "-i", fmt.Sprintf(":0.0+%s", monitor.Offset()))
 	}
 
 	if cfg.RecordAudio {
@@ -387,7 +430,7 @@ func buildX11Command(region, outputPath string, cfg *Config) (*exec.Cmd, error)
 
 	args = append(args,
 		"-r", fmt.Sprintf("%d", cfg.X11.OutputFPS),
-		"-c: v", cfg.X11.VideoCodec,
+		"-c:v", cfg.X11.VideoCodec,
 		"-crf", cfg.Quality,
 		"-preset", cfg.X11.Preset,
 	)
@@ -401,24 +444,130 @@ func buildX11Command(region, outputPath string, cfg *Config) (*exec.Cmd, error)
 	return exec.Command("ffmpeg", args...), nil
 }
 
+// selectMonitor lets the user pick one of the connected outputs
+func selectMonitor(ctx commands.LauncherContext) (utils.Monitor, error) {
+	monitors, err := utils.ListMonitors()
+	if err != nil {
+		return utils.Monitor{}, err
+	}
+
+	if len(monitors) == 0 {
+		return utils.Monitor{}, fmt.Errorf("no monitors found")
+	}
+
+	options := []string{"← Back"}
+	byLabel := make(map[string]utils.Monitor)
+	for _, m := range monitors {
+		label := fmt.Sprintf("%s  %s+%d+%d", m.Name, m.Geometry(), m.X, m.Y)
+		if m.Primary {
+			label += " (primary)"
+		}
+		options = append(options, label)
+		byLabel[label] = m
+	}
+
+	choice, err := ctx.Show(options, "Select Output")
+	if err != nil || choice == "← Back" {
+		return utils.Monitor{}, fmt.Errorf("cancelled")
+	}
+
+	monitor, ok := byLabel[choice]
+	if !ok {
+		return utils.Monitor{}, fmt.Errorf("unknown output: %s", choice)
+	}
+
+	return monitor, nil
+}
+
+// windowQueryTimeout bounds compositor IPC calls for the active window
+const windowQueryTimeout = 5 * time.Second
+
+// getWaylandActiveWindow returns the focused window as "X,Y WxH" (wf-recorder -g)
 func getWaylandActiveWindow() (string, error) {
-	if utils.CommandExists("swaymsg") {
-		cmd := exec.Command("swaymsg", "-t", "get_tree")
-		output, err := cmd.Output()
-		if err == nil {
-			_ = output
+	switch compositor := utils.WindowCompositor(); compositor {
+	case utils.CompositorSway:
+		if !utils.CommandExists("swaymsg") {
+			return "", fmt.Errorf("swaymsg is not installed")
+		}
+		output, err := utils.RunCommandTimeout(windowQueryTimeout, "swaymsg", "-t", "get_tree", "-r")
+		if err != nil {
+			return "", fmt.Errorf("failed to query sway: %w", err)
+		}
+		return parseSwayFocusedWindow(output)
+
+	case utils.CompositorHyprland:
+		if !utils.CommandExists("hyprctl") {
+			return "", fmt.Errorf("hyprctl is not installed")
+		}
+		output, err := utils.RunCommandTimeout(windowQueryTimeout, "hyprctl", "activewindow", "-j")
+		if err != nil {
+			return "", fmt.Errorf("failed to query hyprland: %w", err)
 		}
+		return parseHyprlandActiveWindow(output)
+
+	default:
+		return "", fmt.Errorf("active window is not supported on %s", compositor)
+	}
+}
+
+// swayNode is the part of a 'swaymsg -t get_tree' node needed to find focus
+type swayNode struct {
+	Focused bool `json:"focused"`
+	Rect    struct {
+		X      int `json:"x"`
+		Y      int `json:"y"`
+		Width  int `json:"width"`
+		Height int `json:"height"`
+	} `json:"rect"`
+	Nodes         []swayNode `json:"nodes"`
+	FloatingNodes []swayNode `json:"floating_nodes"`
+}
+
+// parseSwayFocusedWindow finds the focused node in a sway tree
+func parseSwayFocusedWindow(output string) (string, error) {
+	var root swayNode
+	if err := json.Unmarshal([]byte(output), &root); err != nil {
+		return "", fmt.Errorf("failed to parse sway tree: %w", err)
 	}
 
-	if utils.CommandExists("hyprctl") {
-		cmd := exec.Command("hyprctl", "activewindow", "-j")
-		output, err := cmd.Output()
-		if err == nil {
-			_ = output
+	node := findFocusedNode(&root)
+	if node == nil || node.Rect.Width <= 0 || node.Rect.Height <= 0 {
+		return "", fmt.Errorf("no focused window")
+	}
+
+	return fmt.Sprintf("%d,%d %dx%d", node.Rect.X, node.Rect.Y, node.Rect.Width, node.Rect.Height), nil
+}
+
+func findFocusedNode(node *swayNode) *swayNode {
+	if node.Focused {
+		return node
+	}
+	for _, children := range [][]swayNode{node.Nodes, node.FloatingNodes} {
+		for i := range children {
+			if found := findFocusedNode(&children[i]); found != nil {
+				return found
+			}
 		}
 	}
+	return nil
+}
 
-	return "", fmt.Errorf("unable to get active window on Wayland")
+// parseHyprlandActiveWindow reads "at" and "size" from 'hyprctl activewindow -j',
+// which prints "{}" when no window has focus
+func parseHyprlandActiveWindow(output string) (string, error) {
+	var window struct {
+		At   []int `json:"at"`
+		Size []int `json:"size"`
+	}
+	if err := json.Unmarshal([]byte(output), &window); err != nil {
+		return "", fmt.Errorf("failed to parse hyprctl output: %w", err)
+	}
+
+	if len(window.At) != 2 || len(window.Size) != 2 || window.Size[0] <= 0 || window.Size[1] <= 0 {
+		return "", fmt.Errorf("no focused window")
+	}
+
+	return fmt.Sprintf("%d,%d %dx%d", window.At[0], window.At[1], window.Size[0], window.Size[1]), nil
 }
 
 func getActiveWindowGeometry() (string, string, error) {
@@ -461,9 +610,32 @@ func getActiveWindowGeometry() (string, string, error) {
 	return geometry, offset, nil
 }
 
-func stopRecording(cfg *Config, notifCfg *config.NotificationConfig) error {
-	pidFile := "/tmp/ql_videorecord.pid"
+// recordingStatus describes the running recording or countdown for the
+// menu, e.g. "● Recording 04:37 — recording_20250101_120000.mp4"
+func recordingStatus() string {
+	info, err := os.Stat(pidFile)
+	if err != nil {
+		return ""
+	}
+	data, err := os.ReadFile(pidFile)
+	if err != nil {
+		return ""
+	}
 
+	pidLine, outputPath, _ := strings.Cut(string(data), "\n")
+	var pid int
+	if _, err := fmt.Sscanf(pidLine, "%d", &pid); err != nil || syscall.Kill(pid, 0) != nil {
+		return ""
+	}
+
+	outputPath = strings.TrimSpace(outputPath)
+	if outputPath == countdownMarker {
+		return "Recording starts after the countdown"
+	}
+	return fmt.Sprintf("● Recording %s — %s", utils.FormatElapsed(time.Since(info.ModTime())), filepath.Base(outputPath))
+}
+
+func stopRecording(cfg *Config, notifCfg *config.NotificationConfig) error {
 	data, err := os.ReadFile(pidFile)
 	if err != nil {
 		return fmt.Errorf("no recording in progress")
@@ -482,6 +654,15 @@ func stopRecording(cfg *Config, notifCfg *config.NotificationConfig) error {
 	outputPath := strings.TrimSpace(lines[1])
 
 	process, err := os.FindProcess(pid)
+	if err == nil && outputPath == countdownMarker {
+		// Recording has not started yet - interrupt the waiting countdown instead
+		if err := process.Signal(syscall.SIGINT); err != nil {
+			os.Remove(pidFile)
+			return fmt.Errorf("failed to cancel countdown: %w", err)
+		}
+		return nil
+	}
+
 	if err != nil {
 		os.Remove(pidFile)
 		return fmt.Errorf("recording process not found")
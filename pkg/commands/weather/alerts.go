@@ -0,0 +1,240 @@
+package weather
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/lvim-tech/ql/pkg/config"
+	"github.com/lvim-tech/ql/pkg/utils"
+)
+
+// alertStateKey is where fired alerts are remembered in the state file
+const alertStateKey = "weather_alerts"
+
+// DayForecast is one day of a location's forecast
+type DayForecast struct {
+	Date    string  `json:"date"` // YYYY-MM-DD
+	TempMin float64 `json:"temp_min"`
+	TempMax float64 `json:"temp_max"`
+	Precip  float64 `json:"precip"` // mm
+}
+
+// AlertRule fires a notification when its condition holds for a location,
+// e.g. {location = "Sofia", when = "temp_min < 0"}
+type AlertRule struct {
+	Location string `toml:"location" mapstructure:"location"`
+	When     string `toml:"when" mapstructure:"when"`
+	// Day is "today" or "tomorrow" (default)
+	Day string `toml:"day" mapstructure:"day"`
+}
+
+// FiredAlert is a rule whose condition held
+type FiredAlert struct {
+	Location string  `json:"location"`
+	Rule     string  `json:"rule"`
+	Date     string  `json:"date"`
+	Value    float64 `json:"value"`
+}
+
+func (a FiredAlert) String() string {
+	return fmt.Sprintf("%s on %s: %s (now %s)", a.Location, a.Date, a.Rule, strconv.FormatFloat(a.Value, 'f', -1, 64))
+}
+
+// condition is a parsed 'when': a field compared against a number
+type condition struct {
+	field    string
+	operator string
+	value    float64
+}
+
+// alertFields are the forecast values a rule can test
+var alertFields = map[string]func(DayForecast) float64{
+	"temp_min": func(d DayForecast) float64 { return d.TempMin },
+	"temp_max": func(d DayForecast) float64 { return d.TempMax },
+	"precip":   func(d DayForecast) float64 { return d.Precip },
+}
+
+// parseCondition parses "<field> <op> <number>", spaces optional, where op
+// is one of <, <=, >, >=, ==, !=
+func parseCondition(expr string) (condition, error) {
+	expr = strings.TrimSpace(expr)
+
+	// Two-character operators first, so "<=" is not read as "<"
+	for _, op := range []string{"<=", ">=", "==", "!=", "<", ">"} {
+		field, value, found := strings.Cut(expr, op)
+		if !found {
+			continue
+		}
+
+		field = strings.TrimSpace(field)
+		if _, ok := alertFields[field]; !ok {
+			return condition{}, fmt.Errorf("unknown field in %q (use: temp_min, temp_max, precip)", expr)
+		}
+
+		number, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			return condition{}, fmt.Errorf("invalid number in %q", expr)
+		}
+
+		return condition{field: field, operator: op, value: number}, nil
+	}
+
+	return condition{}, fmt.Errorf("no comparison in %q (use e.g. temp_min < 0)", expr)
+}
+
+// eval reports whether the condition holds for day, and the tested value
+func (c condition) eval(day DayForecast) (bool, float64) {
+	v := alertFields[c.field](day)
+
+	switch c.operator {
+	case "<":
+		return v < c.value, v
+	case "<=":
+		return v <= c.value, v
+	case ">":
+		return v > c.value, v
+	case ">=":
+		return v >= c.value, v
+	case "==":
+		return v == c.value, v
+	default:
+		return v != c.value, v
+	}
+}
+
+// dayIndex maps a rule's day to the forecast index
+func dayIndex(day string) (int, error) {
+	switch strings.ToLower(day) {
+	case "", "tomorrow":
+		return 1, nil
+	case "today":
+		return 0, nil
+	default:
+		return 0, fmt.Errorf("invalid alert day: %s (use: today, tomorrow)", day)
+	}
+}
+
+// checkAlerts evaluates every rule, fetching each location's forecast once.
+// Rule errors are returned before anything is fetched.
+func checkAlerts(cfg *Config) ([]FiredAlert, error) {
+	if len(cfg.Alerts) == 0 {
+		return nil, fmt.Errorf("no alerts configured (add alerts = [{location = \"Sofia\", when = \"temp_min < 0\"}])")
+	}
+
+	conditions := make([]condition, len(cfg.Alerts))
+	for i, rule := range cfg.Alerts {
+		if rule.Location == "" {
+			return nil, fmt.Errorf("alert %q has no location", rule.When)
+		}
+		c, err := parseCondition(rule.When)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := dayIndex(rule.Day); err != nil {
+			return nil, err
+		}
+		conditions[i] = c
+	}
+
+	forecasts := make(map[string][]DayForecast)
+	var fired []FiredAlert
+	var failures []string
+
+	for i, rule := range cfg.Alerts {
+		days, ok := forecasts[rule.Location]
+		if !ok {
+			var err error
+			days, err = fetchFromChain(cfg, func(p Provider) ([]DayForecast, error) {
+				return p.Days(rule.Location, cfg.Timeout)
+			})
+			if err != nil {
+				failures = append(failures, fmt.Sprintf("%s: %v", rule.Location, err))
+				continue
+			}
+			forecasts[rule.Location] = days
+		}
+
+		index, _ := dayIndex(rule.Day)
+		if index >= len(days) {
+			continue
+		}
+
+		if holds, value := conditions[i].eval(days[index]); holds {
+			fired = append(fired, FiredAlert{
+				Location: rule.Location,
+				Rule:     strings.TrimSpace(rule.When),
+				Date:     days[index].Date,
+				Value:    value,
+			})
+		}
+	}
+
+	if len(failures) > 0 {
+		return fired, fmt.Errorf("failed to fetch forecast for %s", strings.Join(failures, "; "))
+	}
+
+	return fired, nil
+}
+
+// notifyAlerts notifies fired alerts that were not notified before for the
+// same day, so an hourly cron job reports each alert once. Returns the ones
+// that were notified. When the state file cannot be read every fired alert
+// is notified, and nothing is recorded.
+func notifyAlerts(fired []FiredAlert, notifCfg *config.NotificationConfig) []FiredAlert {
+	var fresh []FiredAlert
+	err := config.UpdateState(func(state *config.State) error {
+		notified := make(map[string]string)
+		state.Get(alertStateKey, &notified)
+
+		for _, a := range fired {
+			key := a.Location + "|" + a.Rule
+			if notified[key] == a.Date {
+				continue
+			}
+			notified[key] = a.Date
+			fresh = append(fresh, a)
+		}
+
+		return state.Set(alertStateKey, notified)
+	})
+	if err != nil {
+		utils.LogDebug("weather alerts: state not updated", "error", err)
+		fresh = fired
+	}
+
+	for _, a := range fresh {
+		utils.NotifyWithConfig(notifCfg, "Weather Alert: "+a.Location, fmt.Sprintf("%s: %s (%s)", a.Date, a.Rule, strconv.FormatFloat(a.Value, 'f', -1, 64)))
+	}
+
+	return fresh
+}
+
+// parseWttrDays reads the daily forecast from wttr.in's format=j1 output,
+// summing the hourly precipitation
+func parseWttrDays(body []byte) ([]DayForecast, error) {
+	var resp wttrResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse forecast: %w", err)
+	}
+
+	var days []DayForecast
+	for _, w := range resp.Weather {
+		day := DayForecast{Date: w.Date}
+		day.TempMin, _ = strconv.ParseFloat(w.MinTempC, 64)
+		day.TempMax, _ = strconv.ParseFloat(w.MaxTempC, 64)
+		for _, h := range w.Hourly {
+			if mm, err := strconv.ParseFloat(h.PrecipMM, 64); err == nil {
+				day.Precip += mm
+			}
+		}
+		days = append(days, day)
+	}
+
+	if len(days) == 0 {
+		return nil, fmt.Errorf("no daily forecast in response")
+	}
+
+	return days, nil
+}
@@ -0,0 +1,140 @@
+package weather
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// astronomyItem is the menu entry for sun and moon times
+const astronomyItem = "Astronomy"
+
+// Astronomy holds today's sun and moon data for a location
+type Astronomy struct {
+	Location         string `json:"location"`
+	Sunrise          string `json:"sunrise,omitempty"`
+	Sunset           string `json:"sunset,omitempty"`
+	DayLength        string `json:"day_length,omitempty"`
+	Moonrise         string `json:"moonrise,omitempty"`
+	Moonset          string `json:"moonset,omitempty"`
+	MoonPhase        string `json:"moon_phase,omitempty"`
+	MoonIllumination string `json:"moon_illumination,omitempty"`
+}
+
+// wttrAstronomy is the astronomy block of a format=j1 day forecast
+type wttrAstronomy struct {
+	Sunrise          string `json:"sunrise"`
+	Sunset           string `json:"sunset"`
+	Moonrise         string `json:"moonrise"`
+	Moonset          string `json:"moonset"`
+	MoonPhase        string `json:"moon_phase"`
+	MoonIllumination string `json:"moon_illumination"`
+}
+
+// fetchAstronomy fetches today's astronomy data for a single location
+func fetchAstronomy(location string, timeout int) (Astronomy, error) {
+	body, err := httpGet(fmt.Sprintf("https://wttr.in/%s?format=j1", url.PathEscape(location)), timeout)
+	if err != nil {
+		return Astronomy{}, err
+	}
+
+	return parseAstronomy(location, body)
+}
+
+func parseAstronomy(location string, body []byte) (Astronomy, error) {
+	var resp wttrResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return Astronomy{}, fmt.Errorf("failed to parse forecast: %w", err)
+	}
+
+	if len(resp.Weather) == 0 || len(resp.Weather[0].Astronomy) == 0 {
+		return Astronomy{}, fmt.Errorf("no astronomy data in response")
+	}
+
+	astro := resp.Weather[0].Astronomy[0]
+	return Astronomy{
+		Location:         location,
+		Sunrise:          astro.Sunrise,
+		Sunset:           astro.Sunset,
+		DayLength:        dayLength(astro.Sunrise, astro.Sunset),
+		Moonrise:         astro.Moonrise,
+		Moonset:          astro.Moonset,
+		MoonPhase:        astro.MoonPhase,
+		MoonIllumination: astro.MoonIllumination,
+	}, nil
+}
+
+// dayLength returns the time between sunrise and sunset ("07:15 AM"),
+// or "" when either is missing (polar day/night reports "No sunrise")
+func dayLength(sunrise, sunset string) string {
+	rise, err := time.Parse("03:04 PM", sunrise)
+	if err != nil {
+		return ""
+	}
+	set, err := time.Parse("03:04 PM", sunset)
+	if err != nil {
+		return ""
+	}
+
+	length := set.Sub(rise)
+	if length < 0 {
+		length += 24 * time.Hour
+	}
+
+	return fmt.Sprintf("%dh %02dm", int(length.Hours()), int(length.Minutes())%60)
+}
+
+// moonPhaseIcon maps wttr.in's moon phase names to their emoji
+func moonPhaseIcon(phase string) string {
+	switch strings.ToLower(phase) {
+	case "new moon":
+		return "🌑"
+	case "waxing crescent":
+		return "🌒"
+	case "first quarter":
+		return "🌓"
+	case "waxing gibbous":
+		return "🌔"
+	case "full moon":
+		return "🌕"
+	case "waning gibbous":
+		return "🌖"
+	case "last quarter", "third quarter":
+		return "🌗"
+	case "waning crescent":
+		return "🌘"
+	}
+	return "☾"
+}
+
+func formatAstronomy(a Astronomy) string {
+	var output strings.Builder
+
+	fmt.Fprintf(&output, "Astronomy - %s\n\n", a.Location)
+
+	fmt.Fprintf(&output, "  Sunrise:   %s\n", orDash(a.Sunrise))
+	fmt.Fprintf(&output, "  Sunset:    %s\n", orDash(a.Sunset))
+	if a.DayLength != "" {
+		fmt.Fprintf(&output, "  Day:       %s\n", a.DayLength)
+	}
+
+	output.WriteString("\n")
+	fmt.Fprintf(&output, "  Moon:      %s %s", moonPhaseIcon(a.MoonPhase), orDash(a.MoonPhase))
+	if a.MoonIllumination != "" {
+		fmt.Fprintf(&output, " (%s%% illuminated)", a.MoonIllumination)
+	}
+	output.WriteString("\n")
+	fmt.Fprintf(&output, "  Moonrise:  %s\n", orDash(a.Moonrise))
+	fmt.Fprintf(&output, "  Moonset:   %s\n", orDash(a.Moonset))
+
+	return output.String()
+}
+
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
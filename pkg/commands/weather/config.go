@@ -6,6 +6,15 @@ type Config struct {
 	Locations []string `toml:"locations" mapstructure:"locations"`
 	Options   string   `toml:"options" mapstructure:"options"`
 	Timeout   int      `toml:"timeout" mapstructure:"timeout"` // Timeout in seconds
+	// ShowAstronomy appends sun and moon times to the single-location view
+	ShowAstronomy bool `toml:"show_astronomy" mapstructure:"show_astronomy"`
+	// Providers are tried in order until one answers: wttr, open-meteo
+	Providers []string `toml:"providers" mapstructure:"providers"`
+	// NowFormat is the body of the 'ql weather now' notification; see
+	// formatNow for the placeholders
+	NowFormat string `toml:"now_format" mapstructure:"now_format"`
+	// Alerts are checked by 'ql weather check' and notify when they fire
+	Alerts []AlertRule `toml:"alerts" mapstructure:"alerts"`
 }
 
 // DefaultConfig returns default weather configuration
@@ -18,7 +27,10 @@ func DefaultConfig() Config {
 			"New York",
 			"Tokyo",
 		},
-		Options: "",
-		Timeout: 30,
+		Options:       "",
+		Timeout:       30,
+		ShowAstronomy: false,
+		Providers:     []string{"wttr", "open-meteo"},
+		NowFormat:     defaultNowFormat,
 	}
 }
@@ -0,0 +1,146 @@
+package weather
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// dashboardWorkers bounds how many locations are fetched at the same time
+const dashboardWorkers = 4
+
+// Forecast is the compact per-location summary shown in the dashboard
+type Forecast struct {
+	Location   string `json:"location"`
+	Condition  string `json:"condition,omitempty"`
+	TempC      string `json:"temp_c,omitempty"`
+	FeelsLikeC string `json:"feels_like_c,omitempty"`
+	MinC       string `json:"min_c,omitempty"`
+	MaxC       string `json:"max_c,omitempty"`
+	Humidity   string `json:"humidity,omitempty"`
+	WindKmph   string `json:"wind_kmph,omitempty"`
+	WindDir    string `json:"wind_dir,omitempty"` // 16-point compass, e.g. "NNE"
+	Error      string `json:"error,omitempty"`
+}
+
+// wttrResponse is the subset of wttr.in's format=j1 output used by the dashboard
+type wttrResponse struct {
+	CurrentCondition []struct {
+		TempC         string `json:"temp_C"`
+		FeelsLikeC    string `json:"FeelsLikeC"`
+		Humidity      string `json:"humidity"`
+		WindspeedKmph string `json:"windspeedKmph"`
+		WindDir16     string `json:"winddir16Point"`
+		WeatherDesc   []struct {
+			Value string `json:"value"`
+		} `json:"weatherDesc"`
+	} `json:"current_condition"`
+	Weather []struct {
+		Date      string          `json:"date"`
+		MaxTempC  string          `json:"maxtempC"`
+		MinTempC  string          `json:"mintempC"`
+		Astronomy []wttrAstronomy `json:"astronomy"`
+		Hourly    []struct {
+			PrecipMM string `json:"precipMM"`
+		} `json:"hourly"`
+	} `json:"weather"`
+}
+
+// fetchForecast fetches the compact JSON forecast for a single location
+func fetchForecast(location string, timeout int) (Forecast, error) {
+	body, err := httpGet(fmt.Sprintf("https://wttr.in/%s?format=j1", url.PathEscape(location)), timeout)
+	if err != nil {
+		return Forecast{}, err
+	}
+
+	return parseForecast(location, body)
+}
+
+func parseForecast(location string, body []byte) (Forecast, error) {
+	var resp wttrResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return Forecast{}, fmt.Errorf("failed to parse forecast: %w", err)
+	}
+
+	if len(resp.CurrentCondition) == 0 {
+		return Forecast{}, fmt.Errorf("no current conditions in response")
+	}
+
+	current := resp.CurrentCondition[0]
+	forecast := Forecast{
+		Location:   location,
+		TempC:      current.TempC,
+		FeelsLikeC: current.FeelsLikeC,
+		Humidity:   current.Humidity,
+		WindKmph:   current.WindspeedKmph,
+		WindDir:    current.WindDir16,
+	}
+
+	if len(current.WeatherDesc) > 0 {
+		forecast.Condition = strings.TrimSpace(current.WeatherDesc[0].Value)
+	}
+
+	if len(resp.Weather) > 0 {
+		forecast.MinC = resp.Weather[0].MinTempC
+		forecast.MaxC = resp.Weather[0].MaxTempC
+	}
+
+	return forecast, nil
+}
+
+// fetchAllForecasts fetches every location concurrently through a bounded
+// worker pool, each from the first provider that answers. Results keep the
+// configured order; failed fetches carry Error.
+func fetchAllForecasts(locations []string, cfg *Config) []Forecast {
+	results := make([]Forecast, len(locations))
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+	for range min(dashboardWorkers, len(locations)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				forecast, err := fetchFromChain(cfg, func(p Provider) (Forecast, error) {
+					return p.Forecast(locations[i], cfg.Timeout)
+				})
+				if err != nil {
+					forecast = Forecast{Location: locations[i], Error: err.Error()}
+				}
+				results[i] = forecast
+			}
+		}()
+	}
+
+	for i := range locations {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results
+}
+
+func formatDashboard(forecasts []Forecast) string {
+	var output strings.Builder
+
+	output.WriteString("Weather - All Locations\n\n")
+
+	for _, f := range forecasts {
+		fmt.Fprintf(&output, "%s\n", f.Location)
+
+		if f.Error != "" {
+			fmt.Fprintf(&output, "  ✗ Failed: %s\n\n", f.Error)
+			continue
+		}
+
+		fmt.Fprintf(&output, "  %s, %s°C (feels like %s°C)\n", f.Condition, f.TempC, f.FeelsLikeC)
+		fmt.Fprintf(&output, "  Today: %s°C / %s°C   Humidity: %s%%   Wind: %s km/h\n\n", f.MinC, f.MaxC, f.Humidity, f.WindKmph)
+	}
+
+	fmt.Fprintf(&output, "Generated:  %s\n", time.Now().Format("2006-01-02 15:04:05"))
+	return output.String()
+}
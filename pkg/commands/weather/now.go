@@ -0,0 +1,63 @@
+package weather
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/lvim-tech/ql/pkg/commands"
+	"github.com/lvim-tech/ql/pkg/config"
+	"github.com/lvim-tech/ql/pkg/utils"
+)
+
+// defaultNowFormat is used when now_format is empty
+const defaultNowFormat = "{condition}, {temp}°C (feels like {feels_like}°C)\nHumidity {humidity}% · Wind {wind} km/h {wind_dir}"
+
+// notifyNow fetches the current conditions for 'ql weather now' and sends
+// them as one notification, meant for a keybind: nothing is opened or printed
+func notifyNow(ctx commands.LauncherContext, location string, cfg *Config, notifCfg *config.NotificationConfig) commands.CommandResult {
+	forecast, err := fetchFromChain(cfg, func(p Provider) (Forecast, error) {
+		return p.Forecast(location, cfg.Timeout)
+	})
+	if err != nil {
+		return commands.CommandResult{
+			Success: false,
+			Error:   fmt.Errorf("failed to fetch weather for %s: %w", location, err),
+		}
+	}
+
+	utils.NotifyWithConfig(notifCfg, "Weather - "+location, formatNow(cfg.NowFormat, forecast))
+
+	if ctx.IsJSONOutput() {
+		return commands.CommandResult{Success: true, Data: forecast}
+	}
+
+	return commands.CommandResult{Success: true}
+}
+
+// formatNow fills the now_format placeholders: {location}, {condition},
+// {temp}, {feels_like}, {min}, {max}, {humidity}, {wind} and {wind_dir}.
+// Temperatures are °C, wind km/h; a field the provider left out is "?".
+func formatNow(format string, f Forecast) string {
+	if format == "" {
+		format = defaultNowFormat
+	}
+
+	field := func(value string) string {
+		if value == "" {
+			return "?"
+		}
+		return value
+	}
+
+	return strings.NewReplacer(
+		"{location}", f.Location,
+		"{condition}", field(f.Condition),
+		"{temp}", field(f.TempC),
+		"{feels_like}", field(f.FeelsLikeC),
+		"{min}", field(f.MinC),
+		"{max}", field(f.MaxC),
+		"{humidity}", field(f.Humidity),
+		"{wind}", field(f.WindKmph),
+		"{wind_dir}", field(f.WindDir),
+	).Replace(format)
+}
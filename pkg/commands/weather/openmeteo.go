@@ -0,0 +1,239 @@
+package weather
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// openMeteoDays is how many days the open-meteo report lists
+const openMeteoDays = 3
+
+// openMeteoProvider uses open-meteo.com, which needs no API key but only
+// takes coordinates, so locations are geocoded first
+type openMeteoProvider struct{}
+
+func (openMeteoProvider) Name() string {
+	return "open-meteo"
+}
+
+// geoLocation is a geocoding match
+type geoLocation struct {
+	Name      string  `json:"name"`
+	Country   string  `json:"country"`
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+func (g geoLocation) String() string {
+	if g.Country == "" {
+		return g.Name
+	}
+	return g.Name + ", " + g.Country
+}
+
+// openMeteoResponse is the subset of the forecast API response ql requests
+type openMeteoResponse struct {
+	Current struct {
+		Temperature float64 `json:"temperature_2m"`
+		FeelsLike   float64 `json:"apparent_temperature"`
+		Humidity    float64 `json:"relative_humidity_2m"`
+		WindSpeed   float64 `json:"wind_speed_10m"`
+		WindDir     float64 `json:"wind_direction_10m"`
+		WeatherCode int     `json:"weather_code"`
+	} `json:"current"`
+	Daily struct {
+		Time        []string  `json:"time"`
+		MaxTemp     []float64 `json:"temperature_2m_max"`
+		MinTemp     []float64 `json:"temperature_2m_min"`
+		WeatherCode []int     `json:"weather_code"`
+		Precip      []float64 `json:"precipitation_sum"`
+	} `json:"daily"`
+}
+
+func geocode(location string, timeout int) (geoLocation, error) {
+	body, err := httpGet("https://geocoding-api.open-meteo.com/v1/search?count=1&name="+url.QueryEscape(location), timeout)
+	if err != nil {
+		return geoLocation{}, err
+	}
+
+	var resp struct {
+		Results []geoLocation `json:"results"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return geoLocation{}, fmt.Errorf("failed to parse geocoding response: %w", err)
+	}
+	if len(resp.Results) == 0 {
+		return geoLocation{}, fmt.Errorf("location not found: %s", location)
+	}
+
+	return resp.Results[0], nil
+}
+
+// fetchOpenMeteo geocodes location and fetches current conditions plus a
+// short daily forecast
+func fetchOpenMeteo(location string, timeout int) (geoLocation, openMeteoResponse, error) {
+	place, err := geocode(location, timeout)
+	if err != nil {
+		return geoLocation{}, openMeteoResponse{}, err
+	}
+
+	query := url.Values{}
+	query.Set("latitude", fmt.Sprintf("%.4f", place.Latitude))
+	query.Set("longitude", fmt.Sprintf("%.4f", place.Longitude))
+	query.Set("current", "temperature_2m,apparent_temperature,relative_humidity_2m,wind_speed_10m,wind_direction_10m,weather_code")
+	query.Set("daily", "temperature_2m_max,temperature_2m_min,weather_code,precipitation_sum")
+	query.Set("timezone", "auto")
+	query.Set("forecast_days", fmt.Sprint(openMeteoDays))
+
+	body, err := httpGet("https://api.open-meteo.com/v1/forecast?"+query.Encode(), timeout)
+	if err != nil {
+		return geoLocation{}, openMeteoResponse{}, err
+	}
+
+	var resp openMeteoResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return geoLocation{}, openMeteoResponse{}, fmt.Errorf("failed to parse forecast: %w", err)
+	}
+
+	return place, resp, nil
+}
+
+func (openMeteoProvider) Forecast(location string, timeout int) (Forecast, error) {
+	_, resp, err := fetchOpenMeteo(location, timeout)
+	if err != nil {
+		return Forecast{}, err
+	}
+
+	return openMeteoForecast(location, resp), nil
+}
+
+// openMeteoForecast normalizes a response into the dashboard summary
+func openMeteoForecast(location string, resp openMeteoResponse) Forecast {
+	forecast := Forecast{
+		Location:   location,
+		Condition:  weatherCodeDescription(resp.Current.WeatherCode),
+		TempC:      fmt.Sprintf("%.0f", resp.Current.Temperature),
+		FeelsLikeC: fmt.Sprintf("%.0f", resp.Current.FeelsLike),
+		Humidity:   fmt.Sprintf("%.0f", resp.Current.Humidity),
+		WindKmph:   fmt.Sprintf("%.0f", resp.Current.WindSpeed),
+		WindDir:    compassPoint(resp.Current.WindDir),
+	}
+
+	if len(resp.Daily.MinTemp) > 0 && len(resp.Daily.MaxTemp) > 0 {
+		forecast.MinC = fmt.Sprintf("%.0f", resp.Daily.MinTemp[0])
+		forecast.MaxC = fmt.Sprintf("%.0f", resp.Daily.MaxTemp[0])
+	}
+
+	return forecast
+}
+
+func (openMeteoProvider) Days(location string, timeout int) ([]DayForecast, error) {
+	_, resp, err := fetchOpenMeteo(location, timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	var days []DayForecast
+	daily := resp.Daily
+	for i, date := range daily.Time {
+		if i >= len(daily.MinTemp) || i >= len(daily.MaxTemp) {
+			break
+		}
+		day := DayForecast{Date: date, TempMin: daily.MinTemp[i], TempMax: daily.MaxTemp[i]}
+		if i < len(daily.Precip) {
+			day.Precip = daily.Precip[i]
+		}
+		days = append(days, day)
+	}
+
+	return days, nil
+}
+
+func (openMeteoProvider) Report(location string, cfg *Config) (string, error) {
+	place, resp, err := fetchOpenMeteo(location, cfg.Timeout)
+	if err != nil {
+		return "", err
+	}
+
+	return formatOpenMeteoReport(place, resp), nil
+}
+
+func formatOpenMeteoReport(place geoLocation, resp openMeteoResponse) string {
+	var output strings.Builder
+
+	f := openMeteoForecast(place.String(), resp)
+
+	fmt.Fprintf(&output, "Weather report: %s\n\n", f.Location)
+	fmt.Fprintf(&output, "  %s, %s°C (feels like %s°C)\n", f.Condition, f.TempC, f.FeelsLikeC)
+	fmt.Fprintf(&output, "  Humidity: %s%%   Wind: %s km/h\n\n", f.Humidity, f.WindKmph)
+
+	daily := resp.Daily
+	for i := range daily.Time {
+		if i >= len(daily.MinTemp) || i >= len(daily.MaxTemp) || i >= len(daily.WeatherCode) {
+			break
+		}
+
+		day := daily.Time[i]
+		if t, err := time.Parse("2006-01-02", day); err == nil {
+			day = t.Format("Mon Jan 02")
+		}
+
+		fmt.Fprintf(&output, "  %-10s  %3.0f°C / %3.0f°C  %s\n", day, daily.MinTemp[i], daily.MaxTemp[i], weatherCodeDescription(daily.WeatherCode[i]))
+	}
+
+	output.WriteString("\nSource: open-meteo.com\n")
+	return output.String()
+}
+
+// compassPoint names a wind direction in degrees on the 16-point compass
+// wttr.in uses
+func compassPoint(degrees float64) string {
+	points := []string{"N", "NNE", "NE", "ENE", "E", "ESE", "SE", "SSE", "S", "SSW", "SW", "WSW", "W", "WNW", "NW", "NNW"}
+	index := int(math.Round(math.Mod(degrees, 360)/22.5)) % len(points)
+	if index < 0 {
+		index += len(points)
+	}
+	return points[index]
+}
+
+// weatherCodeDescription names a WMO weather interpretation code
+func weatherCodeDescription(code int) string {
+	switch code {
+	case 0:
+		return "Clear sky"
+	case 1:
+		return "Mainly clear"
+	case 2:
+		return "Partly cloudy"
+	case 3:
+		return "Overcast"
+	case 45, 48:
+		return "Fog"
+	case 51, 53, 55:
+		return "Drizzle"
+	case 56, 57:
+		return "Freezing drizzle"
+	case 61, 63, 65:
+		return "Rain"
+	case 66, 67:
+		return "Freezing rain"
+	case 71, 73, 75:
+		return "Snow"
+	case 77:
+		return "Snow grains"
+	case 80, 81, 82:
+		return "Rain showers"
+	case 85, 86:
+		return "Snow showers"
+	case 95:
+		return "Thunderstorm"
+	case 96, 99:
+		return "Thunderstorm with hail"
+	default:
+		return fmt.Sprintf("Weather code %d", code)
+	}
+}
@@ -0,0 +1,103 @@
+package weather
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/lvim-tech/ql/pkg/utils"
+)
+
+// Provider is a weather source. Providers are tried in the configured order,
+// so a failing or slow service falls through to the next one.
+type Provider interface {
+	Name() string
+	// Report returns the text shown in the single-location view
+	Report(location string, cfg *Config) (string, error)
+	// Forecast returns the normalized summary used by the dashboard
+	Forecast(location string, timeout int) (Forecast, error)
+	// Days returns the daily forecast, today first, used by alerts
+	Days(location string, timeout int) ([]DayForecast, error)
+}
+
+// providers maps the names accepted in the providers setting to implementations
+var providers = map[string]Provider{
+	"wttr":       wttrProvider{},
+	"open-meteo": openMeteoProvider{},
+}
+
+// defaultProviders is used when the providers setting is empty
+var defaultProviders = []string{"wttr", "open-meteo"}
+
+// providerChain resolves the configured provider names in order
+func providerChain(cfg *Config) ([]Provider, error) {
+	names := cfg.Providers
+	if len(names) == 0 {
+		names = defaultProviders
+	}
+
+	chain := make([]Provider, 0, len(names))
+	for _, name := range names {
+		p, ok := providers[strings.ToLower(name)]
+		if !ok {
+			return nil, fmt.Errorf("unknown weather provider: %s (available: wttr, open-meteo)", name)
+		}
+		chain = append(chain, p)
+	}
+
+	return chain, nil
+}
+
+// fetchFromChain calls fetch with each provider until one succeeds. The error
+// lists every provider's failure.
+func fetchFromChain[T any](cfg *Config, fetch func(Provider) (T, error)) (T, error) {
+	var zero T
+
+	chain, err := providerChain(cfg)
+	if err != nil {
+		return zero, err
+	}
+
+	var failures []string
+	for _, p := range chain {
+		result, err := fetch(p)
+		if err == nil {
+			return result, nil
+		}
+		utils.LogDebug("weather provider failed", "provider", p.Name(), "error", err)
+		failures = append(failures, fmt.Sprintf("%s: %v", p.Name(), err))
+	}
+
+	return zero, fmt.Errorf("all weather providers failed (%s)", strings.Join(failures, "; "))
+}
+
+// wttrProvider is wttr.in, which renders the full terminal report itself
+type wttrProvider struct{}
+
+func (wttrProvider) Name() string {
+	return "wttr"
+}
+
+func (wttrProvider) Report(location string, cfg *Config) (string, error) {
+	options := cfg.Options
+	if utils.IsTerminal() {
+		if extra := widthOptions(utils.TerminalWidth()); extra != "" {
+			options = strings.Trim(options+"&"+extra, "&")
+		}
+	}
+
+	return fetchWeather(location, options, cfg.Timeout)
+}
+
+func (wttrProvider) Forecast(location string, timeout int) (Forecast, error) {
+	return fetchForecast(location, timeout)
+}
+
+func (wttrProvider) Days(location string, timeout int) ([]DayForecast, error) {
+	body, err := httpGet(fmt.Sprintf("https://wttr.in/%s?format=j1", url.PathEscape(location)), timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseWttrDays(body)
+}
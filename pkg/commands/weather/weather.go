@@ -1,13 +1,12 @@
 // Package weather provides weather information functionality for ql.
-// It fetches weather data from wttr.in and displays it.
+// It fetches weather data from wttr.in, falling back to open-meteo, and displays it.
 package weather
 
 import (
 	"fmt"
 	"io"
 	"net/http"
-	"os"
-	"os/exec"
+	"regexp"
 	"strings"
 	"time"
 
@@ -21,10 +20,26 @@ func init() {
 	commands.Register(commands.Command{
 		Name:        "weather",
 		Description: "Check weather information",
+		Check:       check,
+		Config:      commands.ConfigOf(DefaultConfig),
 		Run:         Run,
 	})
 }
 
+// check verifies the configured locations and providers
+func check(qlCfg *config.Config) error {
+	cfg := DefaultConfig()
+	if err := commands.DecodeConfig(qlCfg.GetWeatherConfig(), &cfg); err != nil {
+		return err
+	}
+
+	_, err := providerChain(&cfg)
+	return err
+}
+
+// allLocationsItem is the menu entry for the multi-location dashboard
+const allLocationsItem = "All Locations"
+
 func Run(ctx commands.LauncherContext) commands.CommandResult {
 	cfgInterface := ctx.Config().GetWeatherConfig()
 
@@ -57,7 +72,7 @@ func Run(ctx commands.LauncherContext) commands.CommandResult {
 	// Check for direct command
 	args := ctx.Args()
 	if len(args) > 0 {
-		return executeDirectCommand(args, &cfg, &notifCfg)
+		return executeDirectCommand(ctx, args, &cfg, &notifCfg)
 	}
 
 	for {
@@ -67,6 +82,7 @@ func Run(ctx commands.LauncherContext) commands.CommandResult {
 			items = append(items, "← Back")
 		}
 
+		items = append(items, allLocationsItem, astronomyItem)
 		items = append(items, cfg.Locations...)
 
 		choice, err := ctx.Show(items, "Weather Location")
@@ -82,9 +98,31 @@ func Run(ctx commands.LauncherContext) commands.CommandResult {
 			}
 		}
 
+		if choice == allLocationsItem {
+			result := showAllLocations(ctx, &cfg, &notifCfg)
+			if result.Error != nil {
+				utils.ShowErrorNotificationWithConfig(&notifCfg, "Weather Error", result.Error.Error())
+				continue
+			}
+			return result
+		}
+
+		if choice == astronomyItem {
+			location, err := ctx.Show(cfg.Locations, "Astronomy Location")
+			if err != nil {
+				return commands.CommandResult{Success: false}
+			}
+			result := showAstronomy(ctx, location, &cfg, &notifCfg)
+			if result.Error != nil {
+				utils.ShowErrorNotificationWithConfig(&notifCfg, "Weather Error", result.Error.Error())
+				continue
+			}
+			return result
+		}
+
 		notifyID := utils.ShowPersistentNotificationWithConfig(&notifCfg, "Weather", fmt.Sprintf("Fetching weather for %s...", choice))
 
-		weatherData, err := fetchWeather(choice, cfg.Options, cfg.Timeout)
+		weatherData, err := fetchLocationReport(choice, &cfg)
 
 		utils.ClosePersistentNotificationWithConfig(&notifCfg, notifyID)
 
@@ -106,30 +144,37 @@ func Run(ctx commands.LauncherContext) commands.CommandResult {
 	}
 }
 
-func executeDirectCommand(args []string, cfg *Config, notifCfg *config.NotificationConfig) commands.CommandResult {
-	// Join all args as location name (supports "New York" etc.)
-	location := strings.Join(args, " ")
+func executeDirectCommand(ctx commands.LauncherContext, args []string, cfg *Config, notifCfg *config.NotificationConfig) commands.CommandResult {
+	if len(args) == 1 && args[0] == "all" {
+		return showAllLocations(ctx, cfg, notifCfg)
+	}
 
-	// Check if location is in configured locations (case-insensitive partial match)
-	var matchedLocation string
-	locationLower := strings.ToLower(location)
+	if len(args) == 1 && args[0] == "check" {
+		return checkAlertsResult(ctx, cfg, notifCfg)
+	}
 
-	for _, configLoc := range cfg.Locations {
-		configLocLower := strings.ToLower(configLoc)
-		if configLocLower == locationLower || strings.Contains(configLocLower, locationLower) {
-			matchedLocation = configLoc
-			break
+	if args[0] == "now" {
+		location := cfg.Locations[0]
+		if len(args) > 1 {
+			location = matchLocation(strings.Join(args[1:], " "), cfg.Locations)
 		}
+		return notifyNow(ctx, location, cfg, notifCfg)
 	}
 
-	// If not found in config, use the provided location directly
-	if matchedLocation == "" {
-		matchedLocation = location
+	if args[0] == "astro" {
+		location := cfg.Locations[0]
+		if len(args) > 1 {
+			location = matchLocation(strings.Join(args[1:], " "), cfg.Locations)
+		}
+		return showAstronomy(ctx, location, cfg, notifCfg)
 	}
 
+	// Join all args as location name (supports "New York" etc.)
+	matchedLocation := matchLocation(strings.Join(args, " "), cfg.Locations)
+
 	notifyID := utils.ShowPersistentNotificationWithConfig(notifCfg, "Weather", fmt.Sprintf("Fetching weather for %s...", matchedLocation))
 
-	weatherData, err := fetchWeather(matchedLocation, cfg.Options, cfg.Timeout)
+	weatherData, err := fetchLocationReport(matchedLocation, cfg)
 
 	utils.ClosePersistentNotificationWithConfig(notifCfg, notifyID)
 
@@ -150,6 +195,59 @@ func executeDirectCommand(args []string, cfg *Config, notifCfg *config.Notificat
 	return commands.CommandResult{Success: true}
 }
 
+// checkAlertsResult runs 'ql weather check', meant for cron: fired alerts
+// are notified once per day and printed, nothing is shown otherwise
+func checkAlertsResult(ctx commands.LauncherContext, cfg *Config, notifCfg *config.NotificationConfig) commands.CommandResult {
+	fired, err := checkAlerts(cfg)
+	fresh := notifyAlerts(fired, notifCfg)
+
+	if ctx.IsJSONOutput() {
+		return commands.CommandResult{Success: err == nil, Error: err, Data: fired}
+	}
+
+	for _, a := range fresh {
+		fmt.Println(a)
+	}
+
+	return commands.ResultOf(err)
+}
+
+// matchLocation returns the configured location matching name (case-insensitive
+// partial match), or name itself when none does
+func matchLocation(name string, locations []string) string {
+	nameLower := strings.ToLower(name)
+
+	for _, configLoc := range locations {
+		configLocLower := strings.ToLower(configLoc)
+		if configLocLower == nameLower || strings.Contains(configLocLower, nameLower) {
+			return configLoc
+		}
+	}
+
+	return name
+}
+
+// fetchLocationReport fetches the report for a location from the first
+// provider that answers and appends the astronomy section when show_astronomy
+// is set
+func fetchLocationReport(location string, cfg *Config) (string, error) {
+	weatherData, err := fetchFromChain(cfg, func(p Provider) (string, error) {
+		return p.Report(location, cfg)
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if cfg.ShowAstronomy {
+		// Astronomy is an extra; the report is still useful without it
+		if astro, err := fetchAstronomy(location, cfg.Timeout); err == nil {
+			weatherData += "\n" + formatAstronomy(astro)
+		}
+	}
+
+	return weatherData, nil
+}
+
 func fetchWeather(location string, options string, timeout int) (string, error) {
 	location = strings.ReplaceAll(location, " ", "%20")
 
@@ -158,9 +256,19 @@ func fetchWeather(location string, options string, timeout int) (string, error)
 		url += "&" + options
 	}
 
+	body, err := httpGet(url, timeout)
+	if err != nil {
+		return "", err
+	}
+
+	return string(body), nil
+}
+
+// httpGet fetches url from wttr.in with the configured timeout in seconds
+func httpGet(url string, timeout int) ([]byte, error) {
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
-		return "", fmt.Errorf("failed to create request:     %w", err)
+		return nil, fmt.Errorf("failed to create request:     %w", err)
 	}
 
 	req.Header.Set("User-Agent", "curl/7.88.0")
@@ -175,71 +283,124 @@ func fetchWeather(location string, options string, timeout int) (string, error)
 
 	resp, err := client.Do(req)
 	if err != nil {
-		return "", fmt.Errorf("network error: %w", err)
+		return nil, fmt.Errorf("network error: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("server returned status %d", resp.StatusCode)
+		return nil, fmt.Errorf("server returned status %d", resp.StatusCode)
 	}
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return "", fmt.Errorf("failed to read response: %w", err)
+		return nil, fmt.Errorf("failed to read response: %w", err)
 	}
 
-	return string(body), nil
+	return body, nil
 }
 
-func displayWeatherTerminal(data string) error {
-	fmt.Println(data)
-	return nil
-}
+// showAllLocations fetches every configured location and displays them stacked
+func showAllLocations(ctx commands.LauncherContext, cfg *Config, notifCfg *config.NotificationConfig) commands.CommandResult {
+	notifyID := utils.ShowPersistentNotificationWithConfig(notifCfg, "Weather", fmt.Sprintf("Fetching weather for %d locations...", len(cfg.Locations)))
 
-func displayWeatherGUI(data string) error {
-	if utils.CommandExists("yad") {
-		tmpFile := "/tmp/ql-weather-data.txt"
-		if err := os.WriteFile(tmpFile, []byte(data), 0644); err == nil {
-			defer os.Remove(tmpFile)
+	forecasts := fetchAllForecasts(cfg.Locations, cfg)
+
+	utils.ClosePersistentNotificationWithConfig(notifCfg, notifyID)
 
-			cmd := exec.Command("yad",
-				"--text-info",
-				"--title=Weather",
-				"--width=800",
-				"--height=600",
-				"--fontname=Monospace 10",
-				"--filename="+tmpFile)
-			cmd.Env = os.Environ()
-			return cmd.Run()
+	failed := 0
+	for _, f := range forecasts {
+		if f.Error != "" {
+			failed++
 		}
 	}
 
-	if utils.CommandExists("zenity") {
-		tmpFile := "/tmp/ql-weather-data.txt"
-		if err := os.WriteFile(tmpFile, []byte(data), 0644); err == nil {
-			defer os.Remove(tmpFile)
-
-			cmd := exec.Command("zenity",
-				"--text-info",
-				"--title=Weather",
-				"--width=800",
-				"--height=600",
-				"--filename="+tmpFile)
-			cmd.Env = os.Environ()
-			return cmd.Run()
+	if failed == len(forecasts) {
+		return commands.CommandResult{
+			Success: false,
+			Error:   fmt.Errorf("failed to fetch weather for all locations"),
 		}
 	}
 
-	terminal := utils.DetectTerminal()
-	if terminal != "" {
-		tmpScript := "/tmp/ql-weather. sh"
-		script := fmt.Sprintf("#!/bin/sh\ncat << 'EOF'\n%s\nEOF\necho ''\necho 'Press Enter to close... '\nread\n", data)
+	if ctx.IsJSONOutput() {
+		return commands.CommandResult{Success: true, Data: forecasts}
+	}
+
+	data := formatDashboard(forecasts)
+	if utils.IsTerminal() {
+		displayWeatherTerminal(data)
+	} else {
+		displayWeatherGUI(data)
+	}
+
+	return commands.CommandResult{Success: true}
+}
 
-		if err := os.WriteFile(tmpScript, []byte(script), 0755); err == nil {
-			defer os.Remove(tmpScript)
-			return exec.Command(terminal, "-e", tmpScript).Run()
+// showAstronomy fetches and displays sun and moon times for a location
+func showAstronomy(ctx commands.LauncherContext, location string, cfg *Config, notifCfg *config.NotificationConfig) commands.CommandResult {
+	notifyID := utils.ShowPersistentNotificationWithConfig(notifCfg, "Weather", fmt.Sprintf("Fetching astronomy for %s...", location))
+
+	astro, err := fetchAstronomy(location, cfg.Timeout)
+
+	utils.ClosePersistentNotificationWithConfig(notifCfg, notifyID)
+
+	if err != nil {
+		return commands.CommandResult{
+			Success: false,
+			Error:   fmt.Errorf("failed to fetch astronomy for %s: %w", location, err),
 		}
 	}
 
-	return displayWeatherTerminal(data)
+	if ctx.IsJSONOutput() {
+		return commands.CommandResult{Success: true, Data: astro}
+	}
+
+	data := formatAstronomy(astro)
+	if utils.IsTerminal() {
+		displayWeatherTerminal(data)
+	} else {
+		displayWeatherGUI(data)
+	}
+
+	return commands.CommandResult{Success: true}
+}
+
+func displayWeatherTerminal(data string) error {
+	fmt.Println(data)
+	return nil
+}
+
+// ansiSequence matches terminal escape sequences: CSI (colors, cursor
+// movement) and OSC (titles, hyperlinks)
+var ansiSequence = regexp.MustCompile(`\x1b\[[0-9;?]*[ -/]*[@-~]|\x1b\][^\x07\x1b]*(?:\x07|\x1b\\)`)
+
+// stripANSI removes terminal escape sequences, which GUI text viewers show verbatim
+func stripANSI(data string) string {
+	return ansiSequence.ReplaceAllString(data, "")
+}
+
+// Widths of the wttr.in reports: the full three-day forecast and the narrow
+// (day and night only) variant
+const (
+	fullReportWidth   = 125
+	narrowReportWidth = 63
+)
+
+// widthOptions returns the wttr.in options that fit a terminal of width
+// columns: "n" for the narrow forecast, "0" for current conditions only.
+// Unknown widths get the full report.
+func widthOptions(width int) string {
+	switch {
+	case width <= 0 || width >= fullReportWidth:
+		return ""
+	case width >= narrowReportWidth:
+		return "n"
+	default:
+		return "0"
+	}
+}
+
+func displayWeatherGUI(data string) error {
+	// The GUI viewers don't interpret escape codes; the terminal fallback does
+	// but gets the same clean text
+	return utils.ShowTextReport("Weather", stripANSI(data))
 }
@@ -0,0 +1,148 @@
+package wifi
+
+import (
+	"fmt"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/lvim-tech/ql/pkg/commands"
+	"github.com/lvim-tech/ql/pkg/config"
+	"github.com/lvim-tech/ql/pkg/utils"
+)
+
+// Network is a WiFi network seen in a scan
+type Network struct {
+	SSID   string
+	Signal int
+}
+
+// scanNetworks lists visible networks, one entry per SSID with its strongest
+// signal, ordered from strongest to weakest
+func scanNetworks() ([]Network, error) {
+	output, err := utils.RunCommandTimeout(scanTimeout, "nmcli", "-t", "-f", "SSID,SIGNAL", "dev", "wifi", "list")
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan networks: %w", err)
+	}
+
+	strongest := make(map[string]int)
+
+	for _, line := range strings.Split(output, "\n") {
+		// SIGNAL is numeric, so the last colon always separates it from the (escaped) SSID
+		idx := strings.LastIndex(line, ":")
+		if idx == -1 {
+			continue
+		}
+
+		ssid := unescapeTerse(line[:idx])
+		if ssid == "" {
+			continue
+		}
+
+		signal, err := strconv.Atoi(strings.TrimSpace(line[idx+1:]))
+		if err != nil {
+			continue
+		}
+
+		if prev, ok := strongest[ssid]; !ok || signal > prev {
+			strongest[ssid] = signal
+		}
+	}
+
+	networks := make([]Network, 0, len(strongest))
+	for ssid, signal := range strongest {
+		networks = append(networks, Network{SSID: ssid, Signal: signal})
+	}
+
+	sort.Slice(networks, func(i, j int) bool {
+		if networks[i].Signal != networks[j].Signal {
+			return networks[i].Signal > networks[j].Signal
+		}
+		return networks[i].SSID < networks[j].SSID
+	})
+
+	return networks, nil
+}
+
+// getSavedProfiles maps the SSID of every saved WiFi profile to the profile name
+func getSavedProfiles() (map[string]string, error) {
+	cmd := exec.Command("nmcli", "-t", "-f", "NAME,TYPE", "con", "show")
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list saved connections: %w", err)
+	}
+
+	profiles := make(map[string]string)
+
+	for _, line := range strings.Split(string(output), "\n") {
+		idx := strings.LastIndex(line, ":")
+		if idx == -1 || !strings.Contains(line[idx+1:], "wireless") {
+			continue
+		}
+
+		name := unescapeTerse(line[:idx])
+
+		// The profile name is often, but not necessarily, the SSID
+		ssidOut, err := exec.Command("nmcli", "-g", "802-11-wireless.ssid", "con", "show", "id", name).Output()
+		ssid := strings.TrimSpace(string(ssidOut))
+		if err != nil || ssid == "" {
+			ssid = name
+		}
+
+		profiles[ssid] = name
+	}
+
+	return profiles, nil
+}
+
+// connectBestAvailable connects to the strongest network with a saved profile,
+// falling back to the network selection menu when none is in range
+func connectBestAvailable(ctx commands.LauncherContext, cfg *Config, notifCfg *config.NotificationConfig) error {
+	networks, err := scanNetworks()
+	if err != nil {
+		return err
+	}
+
+	profiles, err := getSavedProfiles()
+	if err != nil {
+		return err
+	}
+
+	for _, network := range networks {
+		profile, ok := profiles[network.SSID]
+		if !ok {
+			continue
+		}
+		return connectToProfile(profile, network, cfg, notifCfg)
+	}
+
+	if cfg.ShowNotify {
+		utils.NotifyWithConfig(notifCfg, "WiFi", "No known networks in range")
+	}
+
+	return connectToNetwork(ctx, cfg, notifCfg)
+}
+
+// connectToProfile activates a saved connection profile
+func connectToProfile(profile string, network Network, cfg *Config, notifCfg *config.NotificationConfig) error {
+	cmd := exec.Command("nmcli", "con", "up", "id", profile)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to connect: %s", strings.TrimSpace(string(output)))
+	}
+
+	if cfg.ShowNotify {
+		utils.NotifyWithConfig(notifCfg, "WiFi Connected", fmt.Sprintf("%s (%d%%)", network.SSID, network.Signal))
+	}
+
+	checkInternet(cfg, notifCfg)
+
+	return nil
+}
+
+// unescapeTerse undoes the backslash escaping nmcli applies to values in -t output
+func unescapeTerse(value string) string {
+	value = strings.ReplaceAll(value, `\:`, ":")
+	return strings.ReplaceAll(value, `\\`, `\`)
+}
@@ -4,9 +4,12 @@
 package wifi
 
 import (
+	"errors"
 	"fmt"
 	"os/exec"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/lvim-tech/ql/pkg/commands"
 	"github.com/lvim-tech/ql/pkg/config"
@@ -14,14 +17,40 @@ import (
 	"github.com/mitchellh/mapstructure"
 )
 
+// scanTimeout bounds 'nmcli dev wifi list', which can stall on a busy radio
+const scanTimeout = 20 * time.Second
+
 func init() {
 	commands.Register(commands.Command{
 		Name:        "wifi",
 		Description: "WiFi manager",
+		Requires:    []string{"nmcli"},
+		Subcommands: directCommands.Info(),
+		Check:       check,
+		Config:      commands.ConfigOf(DefaultConfig),
 		Run:         Run,
 	})
 }
 
+// check verifies that NetworkManager manages a wifi device
+func check(qlCfg *config.Config) error {
+	cfg := DefaultConfig()
+	if err := commands.DecodeConfig(qlCfg.GetWifiConfig(), &cfg); err != nil {
+		return err
+	}
+
+	output, err := utils.RunCommandTimeout(5*time.Second, "nmcli", "-t", "-f", "TYPE", "device")
+	if err != nil {
+		return fmt.Errorf("nmcli failed (is NetworkManager running?): %w", err)
+	}
+
+	if !slices.Contains(strings.Fields(output), "wifi") {
+		return fmt.Errorf("no wifi device found")
+	}
+
+	return nil
+}
+
 func Run(ctx commands.LauncherContext) commands.CommandResult {
 	cfgInterface := ctx.Config().GetWifiConfig()
 
@@ -45,13 +74,6 @@ func Run(ctx commands.LauncherContext) commands.CommandResult {
 		}
 	}
 
-	if !utils.CommandExists("nmcli") {
-		return commands.CommandResult{
-			Success: false,
-			Error:   fmt.Errorf("nmcli is not installed (required for wifi management)"),
-		}
-	}
-
 	notifCfg := ctx.Config().GetNotificationConfig()
 
 	// Check for direct command
@@ -68,13 +90,14 @@ func Run(ctx commands.LauncherContext) commands.CommandResult {
 		}
 
 		options = append(options,
+			"Best Available",
 			"Connect to Network",
 			"Disconnect",
 			"Show Current Connection",
 			"Toggle WiFi",
 		)
 
-		choice, err := ctx.Show(options, "WiFi")
+		choice, err := ctx.ShowWithMessage(options, "WiFi", connectionStatus())
 		if err != nil {
 			// ESC pressed at main menu - exit completely
 			return commands.CommandResult{Success: false}
@@ -89,6 +112,8 @@ func Run(ctx commands.LauncherContext) commands.CommandResult {
 
 		var actionErr error
 		switch choice {
+		case "Best Available":
+			actionErr = connectBestAvailable(ctx, &cfg, &notifCfg)
 		case "Connect to Network":
 			actionErr = connectToNetwork(ctx, &cfg, &notifCfg)
 		case "Disconnect":
@@ -117,57 +142,74 @@ func Run(ctx commands.LauncherContext) commands.CommandResult {
 	}
 }
 
-func executeDirectCommand(ctx commands.LauncherContext, args []string, cfg *Config, notifCfg *config.NotificationConfig) commands.CommandResult {
-	action := strings.ToLower(args[0])
-
-	var err error
-
-	switch action {
-	case "connect":
-		// If SSID is provided, connect directly
-		if len(args) > 1 {
-			ssid := strings.Join(args[1:], " ")
-			// Check if password is provided via args (not recommended but possible)
-			err = connectToNetworkDirect(ssid, "", cfg, notifCfg)
-		} else {
-			// Otherwise show network selection menu
-			err = connectToNetwork(ctx, cfg, notifCfg)
-		}
-
-	case "disconnect", "off":
-		err = disconnect(cfg, notifCfg)
-
-	case "status", "current", "info":
-		err = showCurrentConnection(cfg, notifCfg)
-
-	case "toggle":
-		err = toggleWifi(cfg, notifCfg)
-
-	case "on":
-		err = setWifiState(true, cfg, notifCfg)
-
-	default:
-		return commands.CommandResult{
-			Success: false,
-			Error:   fmt.Errorf("unknown wifi action: %s (use:   connect, disconnect, status, toggle, on, off)", action),
-		}
-	}
-
-	if err != nil {
-		return commands.CommandResult{Success: false, Error: err}
-	}
+// directEnv is the state shared by the direct subcommands
+type directEnv struct {
+	ctx      commands.LauncherContext
+	cfg      *Config
+	notifCfg *config.NotificationConfig
+}
 
-	return commands.CommandResult{Success: true}
+// directCommands are the actions available as 'ql wifi <action>'
+var directCommands = commands.SubcommandTable[*directEnv]{
+	{
+		Name:  "connect",
+		Usage: "[ssid]",
+		Run: func(e *directEnv, args []string) commands.CommandResult {
+			// Without an SSID show the network selection menu
+			if len(args) == 0 {
+				return commands.ResultOf(connectToNetwork(e.ctx, e.cfg, e.notifCfg))
+			}
+			ssid := strings.Join(args, " ")
+			return commands.ResultOf(connectToNetworkDirect(e.ctx, ssid, "", false, e.cfg, e.notifCfg))
+		},
+	},
+	{
+		Name: "best",
+		Run: func(e *directEnv, args []string) commands.CommandResult {
+			return commands.ResultOf(connectBestAvailable(e.ctx, e.cfg, e.notifCfg))
+		},
+	},
+	{
+		Name:    "disconnect",
+		Aliases: []string{"off"},
+		Run: func(e *directEnv, args []string) commands.CommandResult {
+			return commands.ResultOf(disconnect(e.cfg, e.notifCfg))
+		},
+	},
+	{
+		Name:    "status",
+		Aliases: []string{"current", "info"},
+		Run: func(e *directEnv, args []string) commands.CommandResult {
+			if e.ctx.IsJSONOutput() {
+				info, err := getCurrentConnection()
+				if err != nil {
+					return commands.CommandResult{Success: false, Error: err}
+				}
+				return commands.CommandResult{Success: true, Message: info.String(), Data: info}
+			}
+			return commands.ResultOf(showCurrentConnection(e.cfg, e.notifCfg))
+		},
+	},
+	{
+		Name: "toggle",
+		Run: func(e *directEnv, args []string) commands.CommandResult {
+			return commands.ResultOf(toggleWifi(e.cfg, e.notifCfg))
+		},
+	},
+	{
+		Name: "on",
+		Run: func(e *directEnv, args []string) commands.CommandResult {
+			return commands.ResultOf(setWifiState(true, e.cfg, e.notifCfg))
+		},
+	},
 }
 
-func connectToNetworkDirect(ssid, password string, cfg *Config, notifCfg *config.NotificationConfig) error {
-	var cmd *exec.Cmd
+func executeDirectCommand(ctx commands.LauncherContext, args []string, cfg *Config, notifCfg *config.NotificationConfig) commands.CommandResult {
+	return directCommands.Dispatch("wifi", &directEnv{ctx: ctx, cfg: cfg, notifCfg: notifCfg}, args)
+}
 
-	if password != "" {
-		cmd = exec.Command("nmcli", "dev", "wifi", "connect", ssid, "password", password)
-	} else {
-		cmd = exec.Command("nmcli", "dev", "wifi", "connect", ssid)
-	}
+func connectToNetworkDirect(ctx commands.LauncherContext, ssid, password string, hidden bool, cfg *Config, notifCfg *config.NotificationConfig) error {
+	cmd := exec.CommandContext(ctx.Context(), "nmcli", connectArgs(ssid, password, hidden)...)
 
 	output, err := cmd.CombinedOutput()
 
@@ -175,12 +217,15 @@ func connectToNetworkDirect(ssid, password string, cfg *Config, notifCfg *config
 		if strings.Contains(string(output), "Secrets were required") ||
 			strings.Contains(string(output), "password") {
 
-			promptedPassword, passErr := utils.PromptPassword(fmt.Sprintf("Password for %s", ssid))
-			if passErr != nil || promptedPassword == "" {
+			promptedPassword, passErr := utils.PromptPassword(ctx.Name(), fmt.Sprintf("Password for %s", ssid))
+			if errors.Is(passErr, utils.ErrCancelled) {
 				return fmt.Errorf("password required but not provided")
 			}
+			if passErr != nil {
+				return passErr
+			}
 
-			cmd = exec.Command("nmcli", "dev", "wifi", "connect", ssid, "password", promptedPassword)
+			cmd = exec.CommandContext(ctx.Context(), "nmcli", connectArgs(ssid, promptedPassword, hidden)...)
 			output, err = cmd.CombinedOutput()
 			if err != nil {
 				return fmt.Errorf("failed to connect: %s", strings.TrimSpace(string(output)))
@@ -194,17 +239,37 @@ func connectToNetworkDirect(ssid, password string, cfg *Config, notifCfg *config
 		utils.NotifyWithConfig(notifCfg, "WiFi Connected", ssid)
 	}
 
-	if cfg.TestHost != "" {
-		if testErr := testConnection(cfg); testErr != nil {
-			if cfg.ShowNotify {
-				utils.ShowErrorNotificationWithConfig(notifCfg, "WiFi Warning", fmt.Sprintf("Connected but no internet:        %v", testErr))
-			}
-		}
-	}
+	checkInternet(cfg, notifCfg)
 
 	return nil
 }
 
+// connectArgs builds the nmcli arguments for connecting to ssid.
+// Hidden networks are not in the scan results and must be probed explicitly.
+func connectArgs(ssid, password string, hidden bool) []string {
+	args := []string{"dev", "wifi", "connect", ssid}
+	if password != "" {
+		args = append(args, "password", password)
+	}
+	if hidden {
+		args = append(args, "hidden", "yes")
+	}
+	return args
+}
+
+// checkInternet warns when a fresh connection cannot reach test_host
+func checkInternet(cfg *Config, notifCfg *config.NotificationConfig) {
+	if cfg.TestHost == "" {
+		return
+	}
+
+	if testErr := testConnection(cfg); testErr != nil {
+		if cfg.ShowNotify {
+			utils.ShowErrorNotificationWithConfig(notifCfg, "WiFi Warning", fmt.Sprintf("Connected but no internet:        %v", testErr))
+		}
+	}
+}
+
 func setWifiState(enable bool, cfg *Config, notifCfg *config.NotificationConfig) error {
 	var cmd *exec.Cmd
 	var newState string
@@ -230,13 +295,12 @@ func setWifiState(enable bool, cfg *Config, notifCfg *config.NotificationConfig)
 }
 
 func connectToNetwork(ctx commands.LauncherContext, cfg *Config, notifCfg *config.NotificationConfig) error {
-	cmd := exec.Command("nmcli", "-t", "-f", "SSID", "dev", "wifi", "list")
-	output, err := cmd.Output()
+	output, err := utils.RunCommandTimeout(scanTimeout, "nmcli", "-t", "-f", "SSID", "dev", "wifi", "list")
 	if err != nil {
 		return fmt.Errorf("failed to scan networks: %w", err)
 	}
 
-	lines := strings.Split(string(output), "\n")
+	lines := strings.Split(output, "\n")
 	var networks []string
 	seen := make(map[string]bool)
 
@@ -254,7 +318,8 @@ func connectToNetwork(ctx commands.LauncherContext, cfg *Config, notifCfg *confi
 
 	networks = append([]string{"← Back"}, networks...)
 
-	choice, err := ctx.Show(networks, "Select Network")
+	// Typing an SSID that is not listed connects to it as a hidden network
+	choice, err := ctx.ShowAllowCustom(networks, "Select Network")
 	if err != nil {
 		// ESC pressed - return "cancelled" to exit completely
 		return fmt.Errorf("cancelled")
@@ -265,7 +330,7 @@ func connectToNetwork(ctx commands.LauncherContext, cfg *Config, notifCfg *confi
 		return fmt.Errorf("cancelled")
 	}
 
-	return connectToNetworkDirect(choice, "", cfg, notifCfg)
+	return connectToNetworkDirect(ctx, choice, "", !seen[choice], cfg, notifCfg)
 }
 
 func disconnect(cfg *Config, notifCfg *config.NotificationConfig) error {
@@ -305,32 +370,66 @@ func disconnect(cfg *Config, notifCfg *config.NotificationConfig) error {
 	return nil
 }
 
-func showCurrentConnection(cfg *Config, notifCfg *config.NotificationConfig) error {
+// ConnectionInfo describes the active WiFi connection
+type ConnectionInfo struct {
+	Connected bool   `json:"connected"`
+	Network   string `json:"network,omitempty"`
+	Device    string `json:"device,omitempty"`
+}
+
+// String returns a human-readable connection summary
+func (c *ConnectionInfo) String() string {
+	if !c.Connected {
+		return "Not connected to WiFi"
+	}
+	return fmt.Sprintf("Network: %s\nDevice:  %s", c.Network, c.Device)
+}
+
+func getCurrentConnection() (*ConnectionInfo, error) {
 	cmd := exec.Command("nmcli", "-t", "-f", "NAME,TYPE,DEVICE", "con", "show", "--active")
 	output, err := cmd.Output()
 	if err != nil {
-		return fmt.Errorf("failed to get connection info: %w", err)
+		return nil, fmt.Errorf("failed to get connection info: %w", err)
 	}
 
 	lines := strings.Split(string(output), "\n")
-	var wifiInfo string
 
 	for _, line := range lines {
 		if strings.Contains(line, "802-11-wireless") || strings.Contains(line, "wireless") {
 			parts := strings.Split(line, ":")
 			if len(parts) >= 3 {
-				wifiInfo = fmt.Sprintf("Network: %s\nDevice:  %s", parts[0], parts[2])
-				break
+				return &ConnectionInfo{
+					Connected: true,
+					Network:   parts[0],
+					Device:    parts[2],
+				}, nil
 			}
 		}
 	}
 
-	if wifiInfo == "" {
-		wifiInfo = "Not connected to WiFi"
+	return &ConnectionInfo{Connected: false}, nil
+}
+
+// connectionStatus is the one-line status shown above the WiFi menu
+func connectionStatus() string {
+	info, err := getCurrentConnection()
+	if err != nil {
+		return ""
+	}
+	if !info.Connected {
+		return "Not connected"
+	}
+	return fmt.Sprintf("Connected: %s (%s)", info.Network, info.Device)
+}
+
+func showCurrentConnection(cfg *Config, notifCfg *config.NotificationConfig) error {
+	info, err := getCurrentConnection()
+	if err != nil {
+		return err
 	}
 
 	if cfg.ShowNotify {
-		utils.NotifyWithConfig(notifCfg, "WiFi Status", wifiInfo)
+		utils.NotifyWithConfig(notifCfg, "WiFi Status", info.String())
 	}
 
 	return nil
@@ -6,6 +6,8 @@ import (
 	"maps"
 	"os"
 	"path/filepath"
+	"slices"
+	"time"
 
 	_ "embed"
 
@@ -17,18 +19,33 @@ var defaultConfig string
 
 // Config represents the main configuration structure
 type Config struct {
-	DefaultLauncher   string                    `toml:"default_launcher"`
-	MenuStyle         string                    `toml:"menu_style"`
-	PdfViewer         string                    `toml:"pdf_viewer"`
-	Browser           string                    `toml:"browser"`
-	Editor            string                    `toml:"editor"`
-	ManViewer         string                    `toml:"man_viewer"`
-	ModuleOrder       []string                  `toml:"module_order"`
-	ModuleGroupsOrder []string                  `toml:"module_groups_order"`
-	ModuleGroups      map[string]ModuleGroup    `toml:"module_groups"`
-	Launchers         map[string]LauncherConfig `toml:"launchers"`
-	Notifications     NotificationConfig        `toml:"notifications"`
-	Commands          map[string]map[string]any `toml:"commands"`
+	ConfigVersion      int                       `toml:"config_version"`
+	DefaultLauncher    string                    `toml:"default_launcher"`
+	LauncherFallback   bool                      `toml:"launcher_fallback"`
+	DangerousDefaultNo *bool                     `toml:"dangerous_default_no"`
+	MenuStyle          string                    `toml:"menu_style"`
+	PdfViewer          string                    `toml:"pdf_viewer"`
+	Browser            string                    `toml:"browser"`
+	Editor             string                    `toml:"editor"`
+	ManViewer          string                    `toml:"man_viewer"`
+	ModuleOrder        []string                  `toml:"module_order"`
+	ModuleGroupsOrder  []string                  `toml:"module_groups_order"`
+	ShowUngrouped      *bool                     `toml:"show_ungrouped"`
+	ShowFrequent       bool                      `toml:"show_frequent"`
+	FrequentCount      int                       `toml:"frequent_count"`
+	DisabledModules    []string                  `toml:"disabled_modules"`
+	ModuleTimeout      int                       `toml:"module_timeout"`
+	ModuleGroups       map[string]ModuleGroup    `toml:"module_groups"`
+	Launchers          map[string]LauncherConfig `toml:"launchers"`
+	Notifications      NotificationConfig        `toml:"notifications"`
+	Commands           map[string]map[string]any `toml:"commands"`
+
+	// userEnabled records commands whose enabled flag is set in the user config
+	userEnabled map[string]bool
+	// notificationFlags holds the notification booleans as set in the user config
+	notificationFlags notificationFlags
+	// warnings collects non-fatal problems found while loading (e.g. missing includes)
+	warnings []string
 }
 
 // ModuleGroup represents a group of related modules
@@ -38,9 +55,16 @@ type ModuleGroup struct {
 	Modules []string `toml:"modules"`
 }
 
-// LauncherConfig represents launcher-specific configuration
+// LauncherConfig represents launcher-specific configuration. The structured
+// fields are translated into each launcher's own flags; Args is passed as is
+// after them. Fields a launcher has no flag for are ignored.
 type LauncherConfig struct {
-	Args []string `toml:"args"`
+	Theme  string   `toml:"theme"`
+	Config string   `toml:"config"`
+	Font   string   `toml:"font"`
+	Lines  int      `toml:"lines"`
+	Width  int      `toml:"width"`
+	Args   []string `toml:"args"`
 }
 
 // NotificationConfig controls notification behavior
@@ -50,6 +74,16 @@ type NotificationConfig struct {
 	Timeout        int    `toml:"timeout"`
 	Urgency        string `toml:"urgency"`
 	ShowInTerminal bool   `toml:"show_in_terminal"`
+	// IconNormal and IconCritical are icon names or paths; empty means no icon
+	IconNormal   string `toml:"icon_normal"`
+	IconCritical string `toml:"icon_critical"`
+}
+
+// notificationFlags mirrors the boolean NotificationConfig fields as pointers,
+// so an explicit false in the user config can be told apart from "not set"
+type notificationFlags struct {
+	Enabled        *bool `toml:"enabled"`
+	ShowInTerminal *bool `toml:"show_in_terminal"`
 }
 
 // Load loads configuration from default and user config
@@ -64,12 +98,27 @@ func Load() (*Config, error) {
 		return &defaultCfg, nil
 	}
 
-	var userCfg Config
-	if _, err := toml.DecodeFile(userConfigPath, &userCfg); err != nil {
+	rawUserCfg, warnings, err := decodeWithIncludes(userConfigPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode user config: %w", err)
+	}
+
+	// Must run before migration, which fills in default enabled flags
+	userEnabled := rawEnabledFlags(rawUserCfg)
+
+	// Bring older configs up to the current schema in memory
+	if _, err := migrateRaw(rawUserCfg); err != nil {
+		return nil, fmt.Errorf("failed to migrate user config: %w", err)
+	}
+
+	userCfg, err := decodeRaw(rawUserCfg)
+	if err != nil {
 		return nil, fmt.Errorf("failed to decode user config: %w", err)
 	}
 
 	mergedCfg := mergeConfigs(defaultCfg, userCfg)
+	mergedCfg.userEnabled = userEnabled
+	mergedCfg.warnings = warnings
 	return &mergedCfg, nil
 }
 
@@ -99,10 +148,20 @@ func GetUserConfigPath() string {
 func mergeConfigs(defaultCfg, userCfg Config) Config {
 	result := defaultCfg
 
+	if userCfg.ConfigVersion != 0 {
+		result.ConfigVersion = userCfg.ConfigVersion
+	}
+
 	// Merge simple string fields
 	if userCfg.DefaultLauncher != "" {
 		result.DefaultLauncher = userCfg.DefaultLauncher
 	}
+	if userCfg.LauncherFallback {
+		result.LauncherFallback = true
+	}
+	if userCfg.DangerousDefaultNo != nil {
+		result.DangerousDefaultNo = userCfg.DangerousDefaultNo
+	}
 	if userCfg.MenuStyle != "" {
 		result.MenuStyle = userCfg.MenuStyle
 	}
@@ -126,6 +185,22 @@ func mergeConfigs(defaultCfg, userCfg Config) Config {
 	if len(userCfg.ModuleGroupsOrder) > 0 {
 		result.ModuleGroupsOrder = userCfg.ModuleGroupsOrder
 	}
+	if userCfg.ShowUngrouped != nil {
+		result.ShowUngrouped = userCfg.ShowUngrouped
+	}
+	if userCfg.ShowFrequent {
+		result.ShowFrequent = true
+	}
+	if userCfg.FrequentCount != 0 {
+		result.FrequentCount = userCfg.FrequentCount
+	}
+	if userCfg.DisabledModules != nil {
+		result.DisabledModules = userCfg.DisabledModules
+	}
+
+	if userCfg.ModuleTimeout != 0 {
+		result.ModuleTimeout = userCfg.ModuleTimeout
+	}
 
 	// Merge maps
 	if result.ModuleGroups == nil {
@@ -136,7 +211,9 @@ func mergeConfigs(defaultCfg, userCfg Config) Config {
 	if result.Launchers == nil {
 		result.Launchers = make(map[string]LauncherConfig)
 	}
-	maps.Copy(result.Launchers, userCfg.Launchers)
+	for name, userLauncher := range userCfg.Launchers {
+		result.Launchers[name] = mergeLauncherConfig(result.Launchers[name], userLauncher)
+	}
 
 	// Merge notification config
 	if userCfg.Notifications.Tool != "" {
@@ -148,8 +225,18 @@ func mergeConfigs(defaultCfg, userCfg Config) Config {
 	if userCfg.Notifications.Urgency != "" {
 		result.Notifications.Urgency = userCfg.Notifications.Urgency
 	}
-	result.Notifications.Enabled = userCfg.Notifications.Enabled || result.Notifications.Enabled
-	result.Notifications.ShowInTerminal = userCfg.Notifications.ShowInTerminal
+	if userCfg.Notifications.IconNormal != "" {
+		result.Notifications.IconNormal = userCfg.Notifications.IconNormal
+	}
+	if userCfg.Notifications.IconCritical != "" {
+		result.Notifications.IconCritical = userCfg.Notifications.IconCritical
+	}
+	if userCfg.notificationFlags.Enabled != nil {
+		result.Notifications.Enabled = *userCfg.notificationFlags.Enabled
+	}
+	if userCfg.notificationFlags.ShowInTerminal != nil {
+		result.Notifications.ShowInTerminal = *userCfg.notificationFlags.ShowInTerminal
+	}
 
 	// Merge commands
 	if result.Commands == nil {
@@ -165,14 +252,112 @@ func mergeConfigs(defaultCfg, userCfg Config) Config {
 	return result
 }
 
+// mergeLauncherConfig overrides the default launcher settings field by field,
+// so setting only a theme keeps the default args
+func mergeLauncherConfig(defaultCfg, userCfg LauncherConfig) LauncherConfig {
+	result := defaultCfg
+
+	if userCfg.Theme != "" {
+		result.Theme = userCfg.Theme
+	}
+	if userCfg.Config != "" {
+		result.Config = userCfg.Config
+	}
+	if userCfg.Font != "" {
+		result.Font = userCfg.Font
+	}
+	if userCfg.Lines != 0 {
+		result.Lines = userCfg.Lines
+	}
+	if userCfg.Width != 0 {
+		result.Width = userCfg.Width
+	}
+	if userCfg.Args != nil {
+		result.Args = userCfg.Args
+	}
+
+	return result
+}
+
+// rawEnabledFlags returns the commands that set 'enabled' in a raw user config
+func rawEnabledFlags(raw map[string]any) map[string]bool {
+	result := make(map[string]bool)
+
+	commandsTable, ok := raw["commands"].(map[string]any)
+	if !ok {
+		return result
+	}
+
+	for name, value := range commandsTable {
+		if table, ok := value.(map[string]any); ok {
+			if _, set := table["enabled"]; set {
+				result[name] = true
+			}
+		}
+	}
+
+	return result
+}
+
 // ============================================================================
 // GLOBAL GETTERS
 // ============================================================================
 
+// IsListedDisabled reports whether a module appears in disabled_modules
+func (c *Config) IsListedDisabled(name string) bool {
+	return slices.Contains(c.DisabledModules, name)
+}
+
+// HasUserEnabledFlag reports whether the user config sets [commands.<name>] enabled
+func (c *Config) HasUserEnabledFlag(name string) bool {
+	return c.userEnabled[name]
+}
+
+// IsCommandEnabled reports whether a command is enabled. disabled_modules
+// applies unless the user config sets the command's own enabled flag.
+func (c *Config) IsCommandEnabled(name string) bool {
+	if c.IsListedDisabled(name) && !c.HasUserEnabledFlag(name) {
+		return false
+	}
+
+	commandCfg, exists := c.Commands[name]
+	if !exists {
+		return true
+	}
+
+	if enabledVal, ok := commandCfg["enabled"]; ok {
+		if enabled, ok := enabledVal.(bool); ok {
+			return enabled
+		}
+	}
+
+	return true
+}
+
+// Warnings returns non-fatal problems found while loading the config
+func (c *Config) Warnings() []string {
+	return c.warnings
+}
+
 func (c *Config) GetDefaultLauncher() string {
 	return c.DefaultLauncher
 }
 
+// GetDangerousDefaultNo reports whether confirmations of destructive actions
+// list "No" first, so the entry launchers preselect is the safe one
+func (c *Config) GetDangerousDefaultNo() bool {
+	if c.DangerousDefaultNo == nil {
+		return true
+	}
+	return *c.DangerousDefaultNo
+}
+
+// GetLauncherFallback reports whether a launcher that is not installed
+// should be replaced by the first installed one
+func (c *Config) GetLauncherFallback() bool {
+	return c.LauncherFallback
+}
+
 func (c *Config) GetMenuStyle() string {
 	if c.MenuStyle == "" {
 		return "flat"
@@ -223,6 +408,28 @@ func (c *Config) GetModuleGroupsOrder() []string {
 	return []string{"system", "network", "media", "info"}
 }
 
+// GetShowUngrouped reports whether the grouped menu gets an "Other" group
+// for modules that no group lists (default true)
+func (c *Config) GetShowUngrouped() bool {
+	if c.ShowUngrouped == nil {
+		return true
+	}
+	return *c.ShowUngrouped
+}
+
+// GetShowFrequent reports whether the flat menu starts with the most used modules
+func (c *Config) GetShowFrequent() bool {
+	return c.ShowFrequent
+}
+
+// GetFrequentCount returns how many modules the frequent section shows
+func (c *Config) GetFrequentCount() int {
+	if c.FrequentCount <= 0 {
+		return 3
+	}
+	return c.FrequentCount
+}
+
 func (c *Config) GetModuleGroups() map[string]ModuleGroup {
 	result := make(map[string]ModuleGroup)
 	for key, group := range c.ModuleGroups {
@@ -249,6 +456,31 @@ func (c *Config) GetNotificationConfig() NotificationConfig {
 	return c.Notifications
 }
 
+// GetModuleTimeout returns the execution timeout for a module: its own
+// [commands.<name>] module_timeout, else the global module_timeout (0 = none)
+func (c *Config) GetModuleTimeout(name string) time.Duration {
+	seconds := int64(c.ModuleTimeout)
+
+	switch v := c.Commands[name]["module_timeout"].(type) {
+	case int64:
+		seconds = v
+	case int:
+		seconds = int64(v)
+	}
+
+	if seconds <= 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// GetKeepOpen reports whether a module's menu is shown again after a
+// successful action ([commands.<name>] keep_open = true)
+func (c *Config) GetKeepOpen(name string) bool {
+	keepOpen, _ := c.Commands[name]["keep_open"].(bool)
+	return keepOpen
+}
+
 // ============================================================================
 // MODULE CONFIGS (alphabetically sorted)
 // ============================================================================
@@ -265,6 +497,10 @@ func (c *Config) GetKillConfig() any {
 	return c.Commands["kill"]
 }
 
+func (c *Config) GetHubConfig() any {
+	return c.Commands["hub"]
+}
+
 func (c *Config) GetManConfig() any {
 	return c.Commands["man"]
 }
@@ -273,6 +509,10 @@ func (c *Config) GetMpcConfig() any {
 	return c.Commands["mpc"]
 }
 
+func (c *Config) GetNotificationsConfig() any {
+	return c.Commands["notifications"]
+}
+
 func (c *Config) GetPowerConfig() any {
 	return c.Commands["power"]
 }
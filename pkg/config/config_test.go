@@ -0,0 +1,83 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+)
+
+// decodeUser decodes a user config the way Load does
+func decodeUser(t *testing.T, text string) Config {
+	t.Helper()
+
+	var raw map[string]any
+	if err := toml.Unmarshal([]byte(text), &raw); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := decodeRaw(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cfg
+}
+
+func TestMergeNotificationFlags(t *testing.T) {
+	tests := []struct {
+		name         string
+		user         string
+		defaultVal   bool
+		wantEnabled  bool
+		wantTerminal bool
+	}{
+		{"unset keeps default true", "", true, true, true},
+		{"unset keeps default false", "", false, false, false},
+		{"true overrides default false", "enabled = true\nshow_in_terminal = true", false, true, true},
+		{"true keeps default true", "enabled = true\nshow_in_terminal = true", true, true, true},
+		{"false overrides default true", "enabled = false\nshow_in_terminal = false", true, false, false},
+		{"false keeps default false", "enabled = false\nshow_in_terminal = false", false, false, false},
+		{"only enabled set", "enabled = false", true, false, true},
+		{"only show_in_terminal set", "show_in_terminal = false", true, true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defaults := Config{Notifications: NotificationConfig{
+				Enabled:        tt.defaultVal,
+				ShowInTerminal: tt.defaultVal,
+			}}
+			user := decodeUser(t, "[notifications]\n"+tt.user+"\n")
+
+			got := mergeConfigs(defaults, user).Notifications
+			if got.Enabled != tt.wantEnabled {
+				t.Errorf("Enabled = %v, want %v", got.Enabled, tt.wantEnabled)
+			}
+			if got.ShowInTerminal != tt.wantTerminal {
+				t.Errorf("ShowInTerminal = %v, want %v", got.ShowInTerminal, tt.wantTerminal)
+			}
+		})
+	}
+}
+
+func TestLoadNotificationsDisabled(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	dir := filepath.Join(home, ".config", "ql")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	// default.toml enables notifications; the user turns them off
+	if err := os.WriteFile(filepath.Join(dir, "config.toml"), []byte("[notifications]\nenabled = false\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.GetNotificationConfig().Enabled {
+		t.Error("notifications enabled, want the user's false to win over the default true")
+	}
+}
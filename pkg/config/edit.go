@@ -0,0 +1,235 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// SetUserConfigValue sets key to value in the [table] of the user's
+// config.toml, e.g. a station in "commands.radio.stations". Like 'ql config
+// upgrade' the file is edited as text, so comments and layout survive, and
+// the previous file is kept as config.toml.bak. A table the config does not
+// define yet is added with the default entries: a user table replaces the
+// default one rather than adding to it.
+func SetUserConfigValue(table, key string, value any) error {
+	return editUserConfig(table, func(lines []string, header, end int) ([]string, error) {
+		line, err := encodeKeyValue(key, value)
+		if err != nil {
+			return nil, err
+		}
+
+		if idx := findTableKey(lines, header, end, key); idx != -1 {
+			lines[idx] = line
+			return lines, nil
+		}
+
+		insertAt := header + 1
+		for i := header + 1; i < end; i++ {
+			if trimmed := strings.TrimSpace(lines[i]); trimmed != "" && !strings.HasPrefix(trimmed, "#") {
+				insertAt = i + 1
+			}
+		}
+		return slices.Insert(lines, insertAt, line), nil
+	}, func(got map[string]any) error {
+		if fmt.Sprint(got[key]) != fmt.Sprint(value) {
+			return fmt.Errorf("%s was not set in [%s]", key, table)
+		}
+		return nil
+	})
+}
+
+// DeleteUserConfigValue removes key from the [table] of the user's
+// config.toml the way SetUserConfigValue edits it. Keys defined in an
+// included file or only in the defaults cannot be removed.
+func DeleteUserConfigValue(table, key string) error {
+	return editUserConfig(table, func(lines []string, header, end int) ([]string, error) {
+		idx := findTableKey(lines, header, end, key)
+		if idx == -1 {
+			return nil, fmt.Errorf("%s is not set in [%s] of %s", key, table, GetUserConfigPath())
+		}
+		return slices.Delete(lines, idx, idx+1), nil
+	}, func(got map[string]any) error {
+		if _, exists := got[key]; exists {
+			return fmt.Errorf("%s was not removed from [%s]", key, table)
+		}
+		return nil
+	})
+}
+
+// editUserConfig applies edit to the lines of table in config.toml, which
+// run from its header to end (the next header or the end of the file), and
+// writes the result once it parses and check accepts the table's new value
+func editUserConfig(table string, edit func(lines []string, header, end int) ([]string, error), check func(map[string]any) error) error {
+	configPath := GetUserConfigPath()
+
+	original, err := os.ReadFile(configPath)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read user config: %w", err)
+	}
+	exists := err == nil
+
+	lines := strings.Split(string(original), "\n")
+	header := slices.IndexFunc(lines, tableHeaderRe(table).MatchString)
+
+	if header == -1 {
+		var own, merged map[string]any
+		if exists {
+			if err := toml.Unmarshal(original, &own); err != nil {
+				return fmt.Errorf("failed to decode user config: %w", err)
+			}
+			if merged, _, err = decodeWithIncludes(configPath); err != nil {
+				return fmt.Errorf("failed to decode user config: %w", err)
+			}
+		}
+		if hasTable(own, table) {
+			// Defined inline or with dotted keys
+			return fmt.Errorf("[%s] in %s is not a table header ql can edit", table, configPath)
+		}
+
+		// A table from an included file is merged key by key with this one,
+		// so it needs no copy of the defaults
+		block, err := newTableBlock(table, !hasTable(merged, table))
+		if err != nil {
+			return err
+		}
+		if len(lines) > 0 && lines[len(lines)-1] == "" {
+			lines = lines[:len(lines)-1]
+		}
+		if len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) != "" {
+			lines = append(lines, "")
+		}
+		header = len(lines)
+		lines = append(lines, block...)
+		lines = append(lines, "")
+	}
+
+	end := len(lines)
+	for i := header + 1; i < len(lines); i++ {
+		if strings.HasPrefix(strings.TrimSpace(lines[i]), "[") {
+			end = i
+			break
+		}
+	}
+
+	lines, err = edit(lines, header, end)
+	if err != nil {
+		return err
+	}
+	text := strings.Join(lines, "\n")
+
+	var updated map[string]any
+	if err := toml.Unmarshal([]byte(text), &updated); err != nil {
+		return fmt.Errorf("edited config does not parse, left unchanged: %w", err)
+	}
+	got, _ := lookupTable(updated, table)
+	if err := check(got); err != nil {
+		return fmt.Errorf("%w, config left unchanged", err)
+	}
+
+	if exists {
+		if err := os.WriteFile(configPath+".bak", original, 0644); err != nil {
+			return fmt.Errorf("failed to write backup: %w", err)
+		}
+	} else if err := os.MkdirAll(filepath.Dir(configPath), 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	if err := os.WriteFile(configPath, []byte(text), 0644); err != nil {
+		return fmt.Errorf("failed to write user config: %w", err)
+	}
+
+	return nil
+}
+
+// tableHeaderRe matches the [a.b.c] header of table
+func tableHeaderRe(table string) *regexp.Regexp {
+	parts := strings.Split(table, ".")
+	for i, part := range parts {
+		parts[i] = regexp.QuoteMeta(part)
+	}
+	return regexp.MustCompile(`^\s*\[\s*` + strings.Join(parts, `\s*\.\s*`) + `\s*\]\s*(#.*)?$`)
+}
+
+// findTableKey returns the line between header and end that sets key, or -1
+func findTableKey(lines []string, header, end int, key string) int {
+	for i := header + 1; i < end; i++ {
+		trimmed := strings.TrimSpace(lines[i])
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		var kv map[string]any
+		if toml.Unmarshal([]byte(trimmed), &kv) != nil {
+			continue
+		}
+		if _, found := kv[key]; found && len(kv) == 1 {
+			return i
+		}
+	}
+	return -1
+}
+
+// lookupTable returns the table at a dotted path of a raw config
+func lookupTable(raw map[string]any, table string) (map[string]any, bool) {
+	current := raw
+	for _, part := range strings.Split(table, ".") {
+		next, ok := current[part].(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		current = next
+	}
+	return current, true
+}
+
+func hasTable(raw map[string]any, table string) bool {
+	_, found := lookupTable(raw, table)
+	return found
+}
+
+// newTableBlock returns the lines of a new [table], with the default
+// entries when seed is set
+func newTableBlock(table string, seed bool) ([]string, error) {
+	block := []string{"[" + table + "]"}
+	if !seed {
+		return block, nil
+	}
+
+	var defaults map[string]any
+	if err := toml.Unmarshal([]byte(defaultConfig), &defaults); err != nil {
+		return nil, fmt.Errorf("failed to decode default config: %w", err)
+	}
+	entries, _ := lookupTable(defaults, table)
+
+	keys := make([]string, 0, len(entries))
+	for key := range entries {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		line, err := encodeKeyValue(key, entries[key])
+		if err != nil {
+			return nil, err
+		}
+		block = append(block, line)
+	}
+
+	return block, nil
+}
+
+// encodeKeyValue renders a single `key = value` line, quoting as TOML needs
+func encodeKeyValue(key string, value any) (string, error) {
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(map[string]any{key: value}); err != nil {
+		return "", fmt.Errorf("failed to encode %s: %w", key, err)
+	}
+	return strings.TrimSpace(buf.String()), nil
+}
@@ -0,0 +1,122 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeUserConfig points HOME at a temp dir holding config.toml with text
+func writeUserConfig(t *testing.T, text string) string {
+	t.Helper()
+
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	path := GetUserConfigPath()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if text != "" {
+		if err := os.WriteFile(path, []byte(text), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return path
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestSetUserConfigValueExistingTable(t *testing.T) {
+	original := `# my config
+[commands.radio.stations]
+# jazz
+"Jazz FM" = "https://jazz.example"
+
+[commands.weather]
+location = "Sofia"
+`
+	path := writeUserConfig(t, original)
+
+	if err := SetUserConfigValue("commands.radio.stations", `Rock "Live"`, "https://rock.example"); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `# my config
+[commands.radio.stations]
+# jazz
+"Jazz FM" = "https://jazz.example"
+"Rock \"Live\"" = "https://rock.example"
+
+[commands.weather]
+location = "Sofia"
+`
+	if got := readFile(t, path); got != want {
+		t.Errorf("config.toml =\n%s\nwant\n%s", got, want)
+	}
+	if got := readFile(t, path+".bak"); got != original {
+		t.Errorf("config.toml.bak =\n%s\nwant the original", got)
+	}
+
+	// Setting it again replaces the line
+	if err := SetUserConfigValue("commands.radio.stations", `Rock "Live"`, "https://rock2.example"); err != nil {
+		t.Fatal(err)
+	}
+	if got := readFile(t, path); strings.Count(got, "Rock") != 1 || !strings.Contains(got, "rock2.example") {
+		t.Errorf("station not replaced in place:\n%s", got)
+	}
+
+	if err := DeleteUserConfigValue("commands.radio.stations", `Rock "Live"`); err != nil {
+		t.Fatal(err)
+	}
+	if got := readFile(t, path); got != original {
+		t.Errorf("after delete config.toml =\n%s\nwant the original", got)
+	}
+
+	if err := DeleteUserConfigValue("commands.radio.stations", "Missing"); err == nil {
+		t.Error("deleting a station that is not set succeeded")
+	}
+}
+
+func TestSetUserConfigValueSeedsDefaults(t *testing.T) {
+	path := writeUserConfig(t, "[commands.weather]\nlocation = \"Sofia\"\n")
+
+	if err := SetUserConfigValue("commands.radio.stations", "Mine", "https://mine.example"); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stations, _ := cfg.Commands["radio"]["stations"].(map[string]any)
+	if stations["Mine"] != "https://mine.example" {
+		t.Errorf("saved station missing: %v", stations)
+	}
+	if stations["SomaFM Groove Salad"] == nil {
+		t.Error("default stations were dropped by the new table")
+	}
+	if !strings.HasPrefix(readFile(t, path), "[commands.weather]\nlocation = \"Sofia\"\n\n[commands.radio.stations]\n") {
+		t.Errorf("table not appended after the existing config:\n%s", readFile(t, path))
+	}
+}
+
+func TestSetUserConfigValueInlineTable(t *testing.T) {
+	path := writeUserConfig(t, "[commands.radio]\nstations = { \"A\" = \"https://a.example\" }\n")
+
+	if err := SetUserConfigValue("commands.radio.stations", "B", "https://b.example"); err == nil {
+		t.Error("an inline stations table was edited")
+	}
+	if _, err := os.Stat(path + ".bak"); err == nil {
+		t.Error("a failed edit wrote a backup")
+	}
+}
@@ -0,0 +1,124 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/BurntSushi/toml"
+	"github.com/lvim-tech/ql/pkg/pathutil"
+)
+
+// decodeWithIncludes decodes a config file and the files listed in its
+// include directive. Included files are merged in order and the including
+// file's own values are applied last. Missing or cyclic includes are skipped
+// and reported as warnings; only the top-level file is required to decode.
+func decodeWithIncludes(path string) (map[string]any, []string, error) {
+	var warnings []string
+
+	raw, err := resolveIncludes(path, map[string]bool{}, &warnings)
+	if err != nil {
+		return nil, warnings, err
+	}
+
+	return raw, warnings, nil
+}
+
+func resolveIncludes(path string, visiting map[string]bool, warnings *[]string) (map[string]any, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		absPath = path
+	}
+
+	var raw map[string]any
+	if _, err := toml.DecodeFile(absPath, &raw); err != nil {
+		return nil, err
+	}
+
+	includes, err := rawIncludes(raw)
+	delete(raw, "include")
+	if err != nil {
+		*warnings = append(*warnings, fmt.Sprintf("%s: %v", absPath, err))
+		return raw, nil
+	}
+	if len(includes) == 0 {
+		return raw, nil
+	}
+
+	visiting[absPath] = true
+	defer delete(visiting, absPath)
+
+	merged := make(map[string]any)
+	for _, include := range includes {
+		includePath := expandIncludePath(include, filepath.Dir(absPath))
+
+		if visiting[includePath] {
+			*warnings = append(*warnings, fmt.Sprintf("%s: include cycle via %s, skipped", absPath, include))
+			continue
+		}
+
+		if _, err := os.Stat(includePath); err != nil {
+			*warnings = append(*warnings, fmt.Sprintf("%s: included file %s not found, skipped", absPath, include))
+			continue
+		}
+
+		included, err := resolveIncludes(includePath, visiting, warnings)
+		if err != nil {
+			*warnings = append(*warnings, fmt.Sprintf("%s: failed to decode included file %s: %v", absPath, include, err))
+			continue
+		}
+
+		mergeRaw(merged, included)
+	}
+
+	mergeRaw(merged, raw)
+	return merged, nil
+}
+
+// rawIncludes reads the include directive, which is a list of file paths
+func rawIncludes(raw map[string]any) ([]string, error) {
+	value, ok := raw["include"]
+	if !ok {
+		return nil, nil
+	}
+
+	list, ok := value.([]any)
+	if !ok {
+		return nil, fmt.Errorf("include must be a list of file paths")
+	}
+
+	var includes []string
+	for _, item := range list {
+		path, ok := item.(string)
+		if !ok {
+			return nil, fmt.Errorf("include must be a list of file paths")
+		}
+		includes = append(includes, path)
+	}
+
+	return includes, nil
+}
+
+// expandIncludePath expands an include like any other config path, then
+// resolves it relative to the including file's directory
+func expandIncludePath(path, baseDir string) string {
+	path = pathutil.Expand(path)
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(baseDir, path)
+	}
+	return filepath.Clean(path)
+}
+
+// mergeRaw deep merges src into dst: tables are merged key by key,
+// any other value (including arrays) in src replaces the one in dst
+func mergeRaw(dst, src map[string]any) {
+	for key, srcVal := range src {
+		srcTable, srcIsTable := srcVal.(map[string]any)
+		dstTable, dstIsTable := dst[key].(map[string]any)
+		if srcIsTable && dstIsTable {
+			mergeRaw(dstTable, srcTable)
+			continue
+		}
+		dst[key] = srcVal
+	}
+}
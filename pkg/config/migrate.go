@@ -0,0 +1,224 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// CurrentConfigVersion is the schema version of the embedded default.toml.
+// Bump it together with a new entry in migrations whenever the schema changes.
+const CurrentConfigVersion = 1
+
+// migration upgrades a raw user config from Version-1 to Version. Apply runs
+// on the decoded config at every load; Edit, when set, makes the same change
+// to the text of config.toml for 'ql config upgrade' (a renamed or moved key),
+// leaving the rest of the file as the user wrote it.
+type migration struct {
+	Version     int
+	Description string
+	Apply       func(user, defaults map[string]any)
+	Edit        func(text string) string
+}
+
+// migrations are applied in order to user configs older than their Version
+var migrations = []migration{
+	{
+		Version:     1,
+		Description: "add config_version and missing default keys",
+		Apply:       fillMissingDefaults,
+	},
+}
+
+// freeformTables are tables whose keys are user data rather than schema,
+// so default entries must never be merged into them
+var freeformTables = map[string]bool{
+	"commands.radio.stations": true,
+}
+
+// migrateRaw applies all pending migrations to a raw user config.
+// Returns the version the config was migrated from.
+func migrateRaw(user map[string]any) (int, error) {
+	from := rawConfigVersion(user)
+	if from >= CurrentConfigVersion {
+		return from, nil
+	}
+
+	var defaults map[string]any
+	if err := toml.Unmarshal([]byte(defaultConfig), &defaults); err != nil {
+		return from, fmt.Errorf("failed to decode default config: %w", err)
+	}
+
+	for _, m := range migrations {
+		if m.Version <= from {
+			continue
+		}
+		m.Apply(user, defaults)
+		user["config_version"] = int64(m.Version)
+	}
+
+	return from, nil
+}
+
+// rawConfigVersion reads config_version from a raw config (0 when absent)
+func rawConfigVersion(raw map[string]any) int {
+	switch v := raw["config_version"].(type) {
+	case int64:
+		return int(v)
+	case int:
+		return v
+	}
+	return 0
+}
+
+// fillMissingDefaults copies keys present in defaults but absent in user,
+// without overwriting any user value
+func fillMissingDefaults(user, defaults map[string]any) {
+	fillMissing(user, defaults, "")
+}
+
+func fillMissing(dst, src map[string]any, path string) {
+	for key, srcVal := range src {
+		keyPath := key
+		if path != "" {
+			keyPath = path + "." + key
+		}
+
+		dstVal, exists := dst[key]
+		if !exists {
+			dst[key] = srcVal
+			continue
+		}
+
+		if freeformTables[keyPath] {
+			continue
+		}
+
+		srcTable, srcIsTable := srcVal.(map[string]any)
+		dstTable, dstIsTable := dstVal.(map[string]any)
+		if srcIsTable && dstIsTable {
+			fillMissing(dstTable, srcTable, keyPath)
+		}
+	}
+}
+
+// decodeRaw converts a raw config map into a Config
+func decodeRaw(raw map[string]any) (Config, error) {
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(raw); err != nil {
+		return Config{}, err
+	}
+
+	var cfg Config
+	if _, err := toml.Decode(buf.String(), &cfg); err != nil {
+		return Config{}, err
+	}
+
+	var flags struct {
+		Notifications notificationFlags `toml:"notifications"`
+	}
+	if _, err := toml.Decode(buf.String(), &flags); err != nil {
+		return Config{}, err
+	}
+	cfg.notificationFlags = flags.Notifications
+
+	return cfg, nil
+}
+
+// UpgradeUserConfig migrates the user config file to CurrentConfigVersion,
+// keeping the original next to it with a .bak suffix. The file is edited as
+// text, only where a migration renames or moves a key and on the
+// config_version line, so comments and layout survive. Missing default keys
+// are not written out; they are filled in at load time.
+// Returns the version the file was upgraded from.
+func UpgradeUserConfig() (int, error) {
+	configPath := GetUserConfigPath()
+
+	original, err := os.ReadFile(configPath)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read user config: %w", err)
+	}
+
+	var raw map[string]any
+	if err := toml.Unmarshal(original, &raw); err != nil {
+		return 0, fmt.Errorf("failed to decode user config: %w", err)
+	}
+
+	from, err := migrateRaw(raw)
+	if err != nil {
+		return from, err
+	}
+
+	if from >= CurrentConfigVersion {
+		return from, nil
+	}
+
+	if err := os.WriteFile(configPath+".bak", original, 0644); err != nil {
+		return from, fmt.Errorf("failed to write backup: %w", err)
+	}
+
+	text := string(original)
+	for _, m := range migrations {
+		if m.Version > from && m.Edit != nil {
+			text = m.Edit(text)
+		}
+	}
+	text = setConfigVersion(text, CurrentConfigVersion)
+
+	// The edits must leave a file that still decodes
+	var check map[string]any
+	if err := toml.Unmarshal([]byte(text), &check); err != nil {
+		return from, fmt.Errorf("upgraded config does not parse, left unchanged: %w", err)
+	}
+
+	if err := os.WriteFile(configPath, []byte(text), 0644); err != nil {
+		return from, fmt.Errorf("failed to write upgraded config: %w", err)
+	}
+
+	return from, nil
+}
+
+// configVersionRe matches a config_version line
+var configVersionRe = regexp.MustCompile(`^\s*config_version\s*=`)
+
+// setConfigVersion sets config_version in the root table of a config file's
+// text, replacing the existing line or adding one before the first key or
+// table, after any leading comments
+func setConfigVersion(text string, version int) string {
+	line := fmt.Sprintf("config_version = %d", version)
+	lines := strings.Split(text, "\n")
+
+	insertAt := -1
+	for i, l := range lines {
+		trimmed := strings.TrimSpace(l)
+		if strings.HasPrefix(trimmed, "[") {
+			// Past the root table: config_version was not set there
+			break
+		}
+		if configVersionRe.MatchString(l) {
+			lines[i] = line
+			return strings.Join(lines, "\n")
+		}
+		if insertAt < 0 && trimmed != "" && !strings.HasPrefix(trimmed, "#") {
+			insertAt = i
+		}
+	}
+
+	if insertAt < 0 {
+		insertAt = 0
+		for insertAt < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[insertAt]), "#") {
+			insertAt++
+		}
+	}
+
+	added := []string{line}
+	if insertAt < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[insertAt]), "[") {
+		added = append(added, "")
+	}
+	return strings.Join(slices.Insert(lines, insertAt, added...), "\n")
+}
@@ -0,0 +1,48 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"maps"
+
+	"github.com/BurntSushi/toml"
+)
+
+// Effective returns a copy of the merged config with optional settings
+// resolved the way ql reads them: unset booleans get their defaults and each
+// command's enabled flag accounts for disabled_modules
+func (c *Config) Effective() Config {
+	result := *c
+
+	showUngrouped := c.GetShowUngrouped()
+	result.ShowUngrouped = &showUngrouped
+	dangerousDefaultNo := c.GetDangerousDefaultNo()
+	result.DangerousDefaultNo = &dangerousDefaultNo
+
+	result.ModuleGroupsOrder = c.GetModuleGroupsOrder()
+
+	result.Commands = make(map[string]map[string]any, len(c.Commands))
+	for name, commandCfg := range c.Commands {
+		copied := maps.Clone(commandCfg)
+		if copied == nil {
+			copied = make(map[string]any)
+		}
+		copied["enabled"] = c.IsCommandEnabled(name)
+		result.Commands[name] = copied
+	}
+
+	return result
+}
+
+// EncodeTOML serializes the effective config (see Effective) as TOML
+func (c *Config) EncodeTOML() (string, error) {
+	var buf bytes.Buffer
+
+	enc := toml.NewEncoder(&buf)
+	enc.Indent = ""
+	if err := enc.Encode(c.Effective()); err != nil {
+		return "", fmt.Errorf("failed to encode config: %w", err)
+	}
+
+	return buf.String(), nil
+}
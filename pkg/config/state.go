@@ -0,0 +1,150 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+)
+
+// State is the data ql writes at runtime (usage counts and similar). It is
+// kept in $XDG_DATA_HOME/ql/state.json, apart from config.toml: ql never
+// rewrites the user's config on its own, so comments and layout there
+// survive. Each feature stores its data under its own key.
+type State struct {
+	path    string
+	entries map[string]json.RawMessage
+}
+
+// GetStatePath returns the path of the state file
+func GetStatePath() string {
+	dataDir := os.Getenv("XDG_DATA_HOME")
+	if dataDir == "" {
+		dataDir = filepath.Join(os.Getenv("HOME"), ".local", "share")
+	}
+	return filepath.Join(dataDir, "ql", "state.json")
+}
+
+// UpdateState loads the state, lets update change it and saves it, holding
+// an exclusive lock on the state file throughout, so two ql processes
+// updating at once do not lose each other's changes. A state file that
+// cannot be read is reported and left alone: saving over it would drop the
+// data of every other key. Nothing is saved when update fails.
+func UpdateState(update func(*State) error) error {
+	path := GetStatePath()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+
+	lock, err := os.OpenFile(path+".lock", os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to lock state: %w", err)
+	}
+	defer lock.Close()
+
+	if err := syscall.Flock(int(lock.Fd()), syscall.LOCK_EX); err != nil {
+		return fmt.Errorf("failed to lock state: %w", err)
+	}
+	defer syscall.Flock(int(lock.Fd()), syscall.LOCK_UN)
+
+	state, err := LoadState()
+	if err != nil {
+		return err
+	}
+
+	if err := update(state); err != nil {
+		return err
+	}
+
+	return state.Save()
+}
+
+// LoadState reads the state file. A missing file is an empty state; a
+// broken one is reported. Writers use UpdateState rather than loading and
+// saving themselves.
+func LoadState() (*State, error) {
+	state := &State{
+		path:    GetStatePath(),
+		entries: make(map[string]json.RawMessage),
+	}
+
+	data, err := os.ReadFile(state.path)
+	if os.IsNotExist(err) {
+		return state, nil
+	}
+	if err != nil {
+		return state, fmt.Errorf("failed to read state: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &state.entries); err != nil {
+		state.entries = make(map[string]json.RawMessage)
+		return state, fmt.Errorf("failed to parse state %s: %w", state.path, err)
+	}
+
+	return state, nil
+}
+
+// Get decodes the value stored under key into v. It returns false, leaving v
+// untouched, when the key is missing or does not decode into v.
+func (s *State) Get(key string, v any) bool {
+	raw, ok := s.entries[key]
+	if !ok {
+		return false
+	}
+	return json.Unmarshal(raw, v) == nil
+}
+
+// Set stores v under key; Save writes it to disk
+func (s *State) Set(key string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to encode state %s: %w", key, err)
+	}
+	s.entries[key] = data
+	return nil
+}
+
+// Delete removes key from the state
+func (s *State) Delete(key string) {
+	delete(s.entries, key)
+}
+
+// Save writes the state through a temp file of its own and a rename, so a
+// reader never sees a half-written file. Only UpdateState's lock keeps
+// concurrent writers from overwriting each other's changes.
+func (s *State) Save() error {
+	data, err := json.MarshalIndent(s.entries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode state: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), "state-*.json")
+	if err != nil {
+		return fmt.Errorf("failed to write state: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write state: %w", err)
+	}
+	// CreateTemp makes the file 0600
+	if err := tmp.Chmod(0644); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write state: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write state: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("failed to write state: %w", err)
+	}
+
+	return nil
+}
@@ -2,8 +2,10 @@ package launcher
 
 import (
 	"bufio"
+	"bytes"
 	"fmt"
 	"os/exec"
+	"strconv"
 	"strings"
 
 	"github.com/lvim-tech/ql/pkg/config"
@@ -19,12 +21,19 @@ func NewBemenu(cfg *config.Config) *Bemenu {
 	}
 }
 
+// Name returns the launcher name
+func (b *Bemenu) Name() string {
+	return "bemenu"
+}
+
 func (b *Bemenu) Show(options []string, prompt string) (string, error) {
-	launcherCfg := b.cfg.GetLauncherConfig("bemenu")
-	args := append(launcherCfg.Args, "-p", prompt)
+	args := append(bemenuArgs(b.cfg.GetLauncherConfig("bemenu")), "-p", prompt)
 
 	cmd := exec.Command("bemenu", args...)
 
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
 	stdin, err := cmd.StdinPipe()
 	if err != nil {
 		return "", fmt.Errorf("failed to create stdin pipe: %w", err)
@@ -53,7 +62,7 @@ func (b *Bemenu) Show(options []string, prompt string) (string, error) {
 	}
 
 	if err := cmd.Wait(); err != nil {
-		return "", fmt.Errorf("bemenu exited with error:  %w", err)
+		return "", b.menuError("bemenu", err, stderr.String())
 	}
 
 	if choice == "" {
@@ -63,4 +72,40 @@ func (b *Bemenu) Show(options []string, prompt string) (string, error) {
 	return choice, nil
 }
 
+// ShowOrdered is Show: bemenu never reorders its input
+func (b *Bemenu) ShowOrdered(options []string, prompt string) (string, error) {
+	return b.Show(options, prompt)
+}
+
+// ShowAllowCustom returns the typed text when it matches no option,
+// which bemenu already does on its own
+func (b *Bemenu) ShowAllowCustom(options []string, prompt string) (string, error) {
+	return b.Show(options, prompt)
+}
+
+// ShowWithMessage shows the message after the prompt, since bemenu has
+// no message line
+func (b *Bemenu) ShowWithMessage(options []string, prompt, message string) (string, error) {
+	return b.Show(options, promptWithMessage(prompt, message))
+}
+
+// ShowMulti picks one option at a time, since bemenu has no multi-select
+func (b *Bemenu) ShowMulti(options []string, prompt string) ([]string, error) {
+	return showMultiLoop(b.Show, options, prompt)
+}
+
 // Config() вече идва от baseLauncher - премахни го
+
+// bemenuArgs translates the launcher config into bemenu flags, followed by the raw args
+func bemenuArgs(cfg config.LauncherConfig) []string {
+	var args []string
+
+	if cfg.Font != "" {
+		args = append(args, "--fn", cfg.Font)
+	}
+	if cfg.Lines > 0 {
+		args = append(args, "-l", strconv.Itoa(cfg.Lines))
+	}
+
+	return append(args, cfg.Args...)
+}
@@ -2,8 +2,10 @@ package launcher
 
 import (
 	"bufio"
+	"bytes"
 	"fmt"
 	"os/exec"
+	"strconv"
 	"strings"
 
 	"github.com/lvim-tech/ql/pkg/config"
@@ -19,12 +21,19 @@ func NewDmenu(cfg *config.Config) *Dmenu {
 	}
 }
 
+// Name returns the launcher name
+func (d *Dmenu) Name() string {
+	return "dmenu"
+}
+
 func (d *Dmenu) Show(options []string, prompt string) (string, error) {
-	launcherCfg := d.cfg.GetLauncherConfig("dmenu")
-	args := append(launcherCfg.Args, "-p", prompt)
+	args := append(dmenuArgs(d.cfg.GetLauncherConfig("dmenu")), "-p", prompt)
 
 	cmd := exec.Command("dmenu", args...)
 
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
 	stdin, err := cmd.StdinPipe()
 	if err != nil {
 		return "", fmt.Errorf("failed to create stdin pipe:  %w", err)
@@ -51,7 +60,7 @@ func (d *Dmenu) Show(options []string, prompt string) (string, error) {
 	}
 
 	if err := cmd.Wait(); err != nil {
-		return "", fmt.Errorf("dmenu exited with error: %w", err)
+		return "", d.menuError("dmenu", err, stderr.String())
 	}
 
 	if choice == "" {
@@ -60,3 +69,39 @@ func (d *Dmenu) Show(options []string, prompt string) (string, error) {
 
 	return choice, nil
 }
+
+// ShowOrdered is Show: dmenu never reorders its input
+func (d *Dmenu) ShowOrdered(options []string, prompt string) (string, error) {
+	return d.Show(options, prompt)
+}
+
+// ShowAllowCustom returns the typed text when it matches no option,
+// which dmenu already does on its own
+func (d *Dmenu) ShowAllowCustom(options []string, prompt string) (string, error) {
+	return d.Show(options, prompt)
+}
+
+// ShowWithMessage shows the message after the prompt, since dmenu has
+// no message line
+func (d *Dmenu) ShowWithMessage(options []string, prompt, message string) (string, error) {
+	return d.Show(options, promptWithMessage(prompt, message))
+}
+
+// ShowMulti picks one option at a time, since dmenu has no multi-select
+func (d *Dmenu) ShowMulti(options []string, prompt string) ([]string, error) {
+	return showMultiLoop(d.Show, options, prompt)
+}
+
+// dmenuArgs translates the launcher config into dmenu flags, followed by the raw args
+func dmenuArgs(cfg config.LauncherConfig) []string {
+	var args []string
+
+	if cfg.Font != "" {
+		args = append(args, "-fn", cfg.Font)
+	}
+	if cfg.Lines > 0 {
+		args = append(args, "-l", strconv.Itoa(cfg.Lines))
+	}
+
+	return append(args, cfg.Args...)
+}
@@ -2,11 +2,14 @@ package launcher
 
 import (
 	"bufio"
+	"bytes"
 	"fmt"
 	"os/exec"
+	"strconv"
 	"strings"
 
 	"github.com/lvim-tech/ql/pkg/config"
+	"github.com/lvim-tech/ql/pkg/utils"
 )
 
 type Fuzzel struct {
@@ -19,12 +22,31 @@ func NewFuzzel(cfg *config.Config) *Fuzzel {
 	}
 }
 
+// Name returns the launcher name
+func (f *Fuzzel) Name() string {
+	return "fuzzel"
+}
+
 func (f *Fuzzel) Show(options []string, prompt string) (string, error) {
-	launcherCfg := f.cfg.GetLauncherConfig("fuzzel")
-	args := launcherCfg.Args
+	return f.show(options, prompt)
+}
+
+// ShowOrdered is Show with fuzzel's match sorting turned off
+func (f *Fuzzel) ShowOrdered(options []string, prompt string) (string, error) {
+	return f.show(options, prompt, "--no-sort")
+}
+
+// show runs fuzzel with extra flags and the prompt placed before the
+// configured args, so args can still override them
+func (f *Fuzzel) show(options []string, prompt string, extra ...string) (string, error) {
+	args := append(extra, "--prompt="+prompt+": ")
+	args = append(args, fuzzelArgs(f.cfg.GetLauncherConfig("fuzzel"))...)
 
 	cmd := exec.Command("fuzzel", args...)
 
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
 	stdin, err := cmd.StdinPipe()
 	if err != nil {
 		return "", fmt.Errorf("failed to create stdin pipe:  %w", err)
@@ -51,7 +73,7 @@ func (f *Fuzzel) Show(options []string, prompt string) (string, error) {
 	}
 
 	if err := cmd.Wait(); err != nil {
-		return "", fmt.Errorf("fuzzel exited with error: %w", err)
+		return "", f.menuError("fuzzel", err, stderr.String())
 	}
 
 	if choice == "" {
@@ -60,3 +82,48 @@ func (f *Fuzzel) Show(options []string, prompt string) (string, error) {
 
 	return choice, nil
 }
+
+// ShowAllowCustom returns the typed text when it matches no option,
+// which fuzzel already does on its own
+func (f *Fuzzel) ShowAllowCustom(options []string, prompt string) (string, error) {
+	return f.Show(options, prompt)
+}
+
+// ShowWithMessage shows the message after the prompt, since fuzzel has
+// no message line
+func (f *Fuzzel) ShowWithMessage(options []string, prompt, message string) (string, error) {
+	return f.Show(options, promptWithMessage(prompt, message))
+}
+
+// ShowMulti picks one option at a time, since fuzzel has no multi-select
+func (f *Fuzzel) ShowMulti(options []string, prompt string) ([]string, error) {
+	return showMultiLoop(f.Show, options, prompt)
+}
+
+// fuzzelArgs translates the launcher config into fuzzel flags, followed by the raw args
+func fuzzelArgs(cfg config.LauncherConfig) []string {
+	var args []string
+
+	if cfg.Config != "" {
+		args = append(args, "--config", utils.ExpandPath(cfg.Config))
+	}
+	if cfg.Font != "" {
+		args = append(args, "--font", cfg.Font)
+	}
+	if cfg.Lines > 0 {
+		args = append(args, "--lines", strconv.Itoa(cfg.Lines))
+	}
+	if cfg.Width > 0 {
+		args = append(args, "--width", strconv.Itoa(cfg.Width))
+	}
+
+	// Older default args ended with a bare --prompt for ql to fill in; the
+	// prompt is now passed on its own, and a trailing --prompt would take
+	// nothing as its value
+	userArgs := cfg.Args
+	if n := len(userArgs); n > 0 && userArgs[n-1] == "--prompt" {
+		userArgs = userArgs[:n-1]
+	}
+
+	return append(args, userArgs...)
+}
@@ -2,9 +2,13 @@ package launcher
 
 import (
 	"bufio"
+	"bytes"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
+	"strconv"
 	"strings"
 
 	"github.com/lvim-tech/ql/pkg/config"
@@ -20,12 +24,36 @@ func NewFzf(cfg *config.Config) *Fzf {
 	}
 }
 
+// Name returns the launcher name
+func (f *Fzf) Name() string {
+	return "fzf"
+}
+
 func (f *Fzf) Show(options []string, prompt string) (string, error) {
-	launcherCfg := f.cfg.GetLauncherConfig("fzf")
-	args := append(launcherCfg.Args, "--prompt", prompt+"> ")
+	return f.show(options, prompt)
+}
+
+// ShowOrdered is Show with fzf's match sorting turned off
+func (f *Fzf) ShowOrdered(options []string, prompt string) (string, error) {
+	return f.show(options, prompt, "--no-sort")
+}
+
+// ShowWithMessage is Show with the message as fzf's sticky header
+func (f *Fzf) ShowWithMessage(options []string, prompt, message string) (string, error) {
+	if message == "" {
+		return f.show(options, prompt)
+	}
+	return f.show(options, prompt, "--header", message)
+}
+
+// show runs fzf with extra flags placed before the configured args
+func (f *Fzf) show(options []string, prompt string, extra ...string) (string, error) {
+	args := append(append(extra, fzfArgs(f.cfg.GetLauncherConfig("fzf"))...), "--prompt", prompt+"> ")
 
+	// fzf draws on the terminal itself; stderr only carries its messages
+	var stderr bytes.Buffer
 	cmd := exec.Command("fzf", args...)
-	cmd.Stderr = os.Stderr
+	cmd.Stderr = io.MultiWriter(os.Stderr, &stderr)
 
 	stdin, err := cmd.StdinPipe()
 	if err != nil {
@@ -53,7 +81,7 @@ func (f *Fzf) Show(options []string, prompt string) (string, error) {
 	}
 
 	if err := cmd.Wait(); err != nil {
-		return "", fmt.Errorf("fzf exited with error: %w", err)
+		return "", f.menuError("fzf", err, stderr.String())
 	}
 
 	if choice == "" {
@@ -62,3 +90,63 @@ func (f *Fzf) Show(options []string, prompt string) (string, error) {
 
 	return choice, nil
 }
+
+// ShowAllowCustom works like Show but returns the typed query when it matches
+// no option. fzf prints the query first (--print-query) and exits with 1 when
+// nothing matched, or 130 when the user aborted.
+func (f *Fzf) ShowAllowCustom(options []string, prompt string) (string, error) {
+	args := append(fzfArgs(f.cfg.GetLauncherConfig("fzf")), "--print-query", "--prompt", prompt+"> ")
+
+	var stderr bytes.Buffer
+	cmd := exec.Command("fzf", args...)
+	cmd.Stderr = io.MultiWriter(os.Stderr, &stderr)
+
+	lines, err := runMenu(cmd, options)
+
+	var exitErr *exec.ExitError
+	if err != nil && (!errors.As(err, &exitErr) || exitErr.ExitCode() != 1) {
+		return "", f.menuError("fzf", err, stderr.String())
+	}
+
+	if len(lines) > 1 && lines[1] != "" {
+		return lines[1], nil
+	}
+
+	if len(lines) > 0 && lines[0] != "" {
+		return lines[0], nil
+	}
+
+	return "", fmt.Errorf("no selection made")
+}
+
+// ShowMulti lets the user mark several options (Tab in fzf)
+func (f *Fzf) ShowMulti(options []string, prompt string) ([]string, error) {
+	args := append(fzfArgs(f.cfg.GetLauncherConfig("fzf")), "--multi", "--prompt", prompt+"> ")
+
+	var stderr bytes.Buffer
+	cmd := exec.Command("fzf", args...)
+	cmd.Stderr = io.MultiWriter(os.Stderr, &stderr)
+
+	lines, err := runMenu(cmd, options)
+	if err != nil {
+		return nil, f.menuError("fzf", err, stderr.String())
+	}
+
+	return selectedLines(lines)
+}
+
+// fzfArgs translates the launcher config into fzf flags, followed by the raw args.
+// theme is passed to --color (e.g. "dark", "light" or a full color spec) and
+// lines sets the height, counting the prompt and info lines.
+func fzfArgs(cfg config.LauncherConfig) []string {
+	var args []string
+
+	if cfg.Theme != "" {
+		args = append(args, "--color", cfg.Theme)
+	}
+	if cfg.Lines > 0 {
+		args = append(args, "--height", strconv.Itoa(cfg.Lines+2))
+	}
+
+	return append(args, cfg.Args...)
+}
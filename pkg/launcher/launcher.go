@@ -4,15 +4,33 @@
 package launcher
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+
 	"github.com/lvim-tech/ql/pkg/config"
+	"github.com/lvim-tech/ql/pkg/utils"
 )
 
 // Launcher interface defines launcher behavior
 type Launcher interface {
+	Name() string
 	Show(options []string, prompt string) (string, error)
+	ShowOrdered(options []string, prompt string) (string, error)
+	ShowAllowCustom(options []string, prompt string) (string, error)
+	ShowMulti(options []string, prompt string) ([]string, error)
+	ShowWithMessage(options []string, prompt, message string) (string, error)
 	Config() *config.Config
+	Context() context.Context
 	IsDirectLaunch() bool
 	SetDirectLaunch(bool)
+	IsJSONOutput() bool
+	SetJSONOutput(bool)
 	Args() []string
 	SetArgs([]string)
 }
@@ -21,6 +39,7 @@ type Launcher interface {
 type baseLauncher struct {
 	cfg          *config.Config
 	directLaunch bool
+	jsonOutput   bool
 	args         []string
 }
 
@@ -28,6 +47,12 @@ func (b *baseLauncher) Config() *config.Config {
 	return b.cfg
 }
 
+// Context is cancelled when ql is interrupted; the module_timeout watchdog
+// derives its own from it
+func (b *baseLauncher) Context() context.Context {
+	return utils.InterruptContext()
+}
+
 func (b *baseLauncher) IsDirectLaunch() bool {
 	return b.directLaunch
 }
@@ -36,6 +61,14 @@ func (b *baseLauncher) SetDirectLaunch(direct bool) {
 	b.directLaunch = direct
 }
 
+func (b *baseLauncher) IsJSONOutput() bool {
+	return b.jsonOutput
+}
+
+func (b *baseLauncher) SetJSONOutput(jsonOutput bool) {
+	b.jsonOutput = jsonOutput
+}
+
 func (b *baseLauncher) Args() []string {
 	return b.args
 }
@@ -44,20 +77,299 @@ func (b *baseLauncher) SetArgs(args []string) {
 	b.args = args
 }
 
-// New creates a new launcher instance
+// ErrMenuCancelled is returned when the user closes a menu without choosing
+var ErrMenuCancelled = errors.New("menu cancelled")
+
+// menuError turns a launcher's failed exit into an error. Closing the menu
+// exits 1 (rofi, dmenu, bemenu, fuzzel) or 130 (fzf) and is
+// ErrMenuCancelled, even when the launcher printed warnings on the way,
+// which fuzzel and rofi often do. Any other exit code, or stderr that
+// reports an actual error (a bad theme or option), is a real failure: the
+// launcher's own message is included and shown in a notification, since
+// modules treat every menu error as Escape.
+func (b *baseLauncher) menuError(name string, err error, stderr string) error {
+	message := stderrSummary(stderr)
+
+	var exitErr *exec.ExitError
+	cancelExit := errors.As(err, &exitErr) && (exitErr.ExitCode() == 1 || exitErr.ExitCode() == 130)
+	if cancelExit && !reportsError(stderr) {
+		if message != "" {
+			utils.LogDebug("launcher closed", "cmd", name, "stderr", message)
+		}
+		return fmt.Errorf("%s: %w", name, ErrMenuCancelled)
+	}
+
+	if message == "" {
+		message = err.Error()
+	}
+
+	utils.LogDebug("launcher failed", "cmd", name, "err", err, "stderr", message)
+
+	notifCfg := b.cfg.GetNotificationConfig()
+	utils.ShowErrorNotificationWithConfig(&notifCfg, "Launcher Error", fmt.Sprintf("%s failed: %s", name, message))
+
+	return fmt.Errorf("%s exited with error: %s: %w", name, message, err)
+}
+
+var (
+	// stderrErrorRe matches launcher messages about a real failure, e.g.
+	// "err: config.c:12: invalid value" or "Rofi-ERROR: unknown option"
+	stderrErrorRe = regexp.MustCompile(`(?i)\b(err|error|failed|invalid|unknown option|unrecognized option)\b`)
+	// stderrWarningRe matches warnings, which never make a closed menu a failure
+	stderrWarningRe = regexp.MustCompile(`(?i)\bwarn(ing)?\b`)
+)
+
+// reportsError reports whether a launcher's stderr has an error line that
+// is not a warning
+func reportsError(stderr string) bool {
+	for _, line := range strings.Split(stderr, "\n") {
+		if stderrErrorRe.MatchString(line) && !stderrWarningRe.MatchString(line) {
+			return true
+		}
+	}
+	return false
+}
+
+// stderrSummary keeps the last lines of a launcher's stderr, where the
+// reason for a failure usually is
+func stderrSummary(stderr string) string {
+	var lines []string
+	for _, line := range strings.Split(stderr, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	if len(lines) > 3 {
+		lines = lines[len(lines)-3:]
+	}
+
+	summary := strings.Join(lines, "; ")
+	if runes := []rune(summary); len(runes) > 300 {
+		summary = string(runes[:300]) + "…"
+	}
+	return summary
+}
+
+// runMenu pipes options into a launcher process and returns its output lines.
+// The exit error is returned together with the output, because some launchers
+// (fzf with --print-query) still print usable text on a non-zero exit.
+func runMenu(cmd *exec.Cmd, options []string) ([]string, error) {
+	cmd.Stdin = strings.NewReader(strings.Join(options, "\n") + "\n")
+
+	output, err := cmd.Output()
+	utils.LogDebug("launcher", "cmd", cmd.Args[0], "options", len(options), "err", err)
+
+	var exitErr *exec.ExitError
+	if err != nil && !errors.As(err, &exitErr) {
+		return nil, fmt.Errorf("failed to start %s: %w", cmd.Args[0], err)
+	}
+
+	var lines []string
+	for _, line := range strings.Split(string(output), "\n") {
+		lines = append(lines, strings.TrimSpace(line))
+	}
+
+	return lines, err
+}
+
+// promptWithMessage folds a message into the prompt for launchers that
+// cannot show one on its own line, e.g. "WiFi · Connected: home"
+func promptWithMessage(prompt, message string) string {
+	message = strings.Join(strings.Fields(message), " ")
+	if message == "" {
+		return prompt
+	}
+	return prompt + " · " + message
+}
+
+// multiDoneItem ends a one-at-a-time multi-selection
+const multiDoneItem = "✓ Done"
+
+// showMultiLoop emulates multi-select for launchers without it: options are
+// picked one at a time and removed from the list until "✓ Done" is chosen.
+// A navigation entry such as "← Back" ends the selection right away and is
+// returned on its own; cancelling the menu discards the selection.
+func showMultiLoop(show func([]string, string) (string, error), options []string, prompt string) ([]string, error) {
+	remaining := slices.Clone(options)
+	var selected []string
+
+	for len(remaining) > 0 {
+		menu := remaining
+		menuPrompt := prompt
+		if len(selected) > 0 {
+			menu = append([]string{multiDoneItem}, remaining...)
+			menuPrompt = fmt.Sprintf("%s (%d selected)", prompt, len(selected))
+		}
+
+		choice, err := show(menu, menuPrompt)
+		if err != nil {
+			return nil, err
+		}
+
+		if choice == multiDoneItem {
+			break
+		}
+
+		if strings.HasPrefix(choice, "← ") {
+			return []string{choice}, nil
+		}
+
+		idx := slices.Index(remaining, choice)
+		if idx == -1 {
+			continue
+		}
+		selected = append(selected, choice)
+		remaining = slices.Delete(remaining, idx, idx+1)
+	}
+
+	return selected, nil
+}
+
+// selectedLines returns the non-empty output lines of a multi-select launcher
+func selectedLines(lines []string) ([]string, error) {
+	var selected []string
+	for _, line := range lines {
+		if line != "" {
+			selected = append(selected, line)
+		}
+	}
+
+	if len(selected) == 0 {
+		return nil, fmt.Errorf("no selection made")
+	}
+
+	return selected, nil
+}
+
+// autoOrder is the preference order for default_launcher = "auto" and for
+// falling back from a launcher that is not installed
+var autoOrder = []string{"rofi", "fuzzel", "bemenu", "dmenu", "fzf"}
+
+// IsAvailable reports whether the launcher binary is installed
+func IsAvailable(name string) bool {
+	_, err := exec.LookPath(name)
+	return err == nil
+}
+
+// New creates a new launcher instance. "auto" picks the first installed
+// launcher; a launcher that is not installed is an error, unless
+// launcher_fallback is set, in which case the first installed one is used.
+// The built-in TUI needs no binary and stands in for a missing launcher
+// when ql runs in a terminal.
 func New(name string, cfg *config.Config) (Launcher, error) {
+	if name == "tui" {
+		if !utils.IsTerminal() {
+			return nil, fmt.Errorf("launcher 'tui' needs a terminal")
+		}
+		return NewTUI(cfg), nil
+	}
+
+	if name == "auto" || name == "" {
+		if fallback := firstAvailable(); fallback != "" {
+			return newLauncher(fallback, cfg), nil
+		}
+		if utils.IsTerminal() {
+			return NewTUI(cfg), nil
+		}
+		return nil, fmt.Errorf("no launcher is installed (install one of: %s)", strings.Join(autoOrder, ", "))
+	}
+
+	if !IsKnown(name) {
+		name = "rofi"
+	}
+
+	if !IsAvailable(name) {
+		if cfg.GetLauncherFallback() {
+			if fallback := firstAvailable(); fallback != "" {
+				return newLauncher(fallback, cfg), nil
+			}
+			if utils.IsTerminal() {
+				return NewTUI(cfg), nil
+			}
+		}
+		return nil, fmt.Errorf("launcher '%s' is not installed", name)
+	}
+
+	return newLauncher(name, cfg), nil
+}
+
+// unavailable stands in for a launcher that is not installed, so direct
+// module calls that never open a menu (ql mpc status) still work
+type unavailable struct {
+	baseLauncher
+	name   string
+	err    error
+	notify func(error)
+	once   sync.Once
+}
+
+// NewUnavailable returns a launcher whose menus fail with err. notify is
+// called the first time a menu is asked for, so the user learns why nothing
+// opened while calls that need no menu stay quiet.
+func NewUnavailable(name string, cfg *config.Config, err error, notify func(error)) Launcher {
+	return &unavailable{baseLauncher: baseLauncher{cfg: cfg}, name: name, err: err, notify: notify}
+}
+
+// fail reports err through notify, once, and returns it
+func (u *unavailable) fail() error {
+	u.once.Do(func() {
+		if u.notify != nil {
+			u.notify(u.err)
+		}
+	})
+	return u.err
+}
+
+func (u *unavailable) Name() string {
+	return u.name
+}
+
+func (u *unavailable) Show(options []string, prompt string) (string, error) {
+	return "", u.fail()
+}
+
+func (u *unavailable) ShowOrdered(options []string, prompt string) (string, error) {
+	return "", u.fail()
+}
+
+func (u *unavailable) ShowAllowCustom(options []string, prompt string) (string, error) {
+	return "", u.fail()
+}
+
+func (u *unavailable) ShowMulti(options []string, prompt string) ([]string, error) {
+	return nil, u.fail()
+}
+
+func (u *unavailable) ShowWithMessage(options []string, prompt, message string) (string, error) {
+	return "", u.fail()
+}
+
+// IsKnown reports whether name is a supported launcher
+func IsKnown(name string) bool {
+	return name == "tui" || slices.Contains(autoOrder, name)
+}
+
+func firstAvailable() string {
+	for _, name := range autoOrder {
+		if IsAvailable(name) {
+			return name
+		}
+	}
+	return ""
+}
+
+func newLauncher(name string, cfg *config.Config) Launcher {
 	switch name {
-	case "rofi":
-		return NewRofi(cfg), nil
 	case "dmenu":
-		return NewDmenu(cfg), nil
+		return NewDmenu(cfg)
 	case "fzf":
-		return NewFzf(cfg), nil
+		return NewFzf(cfg)
 	case "bemenu":
-		return NewBemenu(cfg), nil
+		return NewBemenu(cfg)
 	case "fuzzel":
-		return NewFuzzel(cfg), nil
+		return NewFuzzel(cfg)
 	default:
-		return NewRofi(cfg), nil
+		return NewRofi(cfg)
 	}
 }
@@ -2,11 +2,15 @@ package launcher
 
 import (
 	"bufio"
+	"bytes"
 	"fmt"
+	"html"
 	"os/exec"
+	"strconv"
 	"strings"
 
 	"github.com/lvim-tech/ql/pkg/config"
+	"github.com/lvim-tech/ql/pkg/utils"
 )
 
 type Rofi struct {
@@ -19,12 +23,38 @@ func NewRofi(cfg *config.Config) *Rofi {
 	}
 }
 
+// Name returns the launcher name
+func (r *Rofi) Name() string {
+	return "rofi"
+}
+
 func (r *Rofi) Show(options []string, prompt string) (string, error) {
-	launcherCfg := r.cfg.GetLauncherConfig("rofi")
-	args := append(launcherCfg.Args, prompt)
+	return r.show(options, prompt)
+}
+
+// ShowOrdered is Show with rofi's match sorting turned off
+func (r *Rofi) ShowOrdered(options []string, prompt string) (string, error) {
+	return r.show(options, prompt, "-no-sort")
+}
+
+// ShowWithMessage is Show with the message in rofi's message bar
+func (r *Rofi) ShowWithMessage(options []string, prompt, message string) (string, error) {
+	if message == "" {
+		return r.show(options, prompt)
+	}
+	// -mesg is Pango markup
+	return r.show(options, prompt, "-mesg", html.EscapeString(message))
+}
+
+// show runs rofi with extra flags placed before the configured args
+func (r *Rofi) show(options []string, prompt string, extra ...string) (string, error) {
+	args := append(append(extra, rofiArgs(r.cfg.GetLauncherConfig("rofi"))...), prompt)
 
 	cmd := exec.Command("rofi", args...)
 
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
 	stdin, err := cmd.StdinPipe()
 	if err != nil {
 		return "", fmt.Errorf("failed to create stdin pipe: %w", err)
@@ -53,7 +83,7 @@ func (r *Rofi) Show(options []string, prompt string) (string, error) {
 	}
 
 	if err := cmd.Wait(); err != nil {
-		return "", fmt.Errorf("rofi exited with error: %w", err)
+		return "", r.menuError("rofi", err, stderr.String())
 	}
 
 	if choice == "" {
@@ -63,4 +93,71 @@ func (r *Rofi) Show(options []string, prompt string) (string, error) {
 	return choice, nil
 }
 
+// ShowAllowCustom works like Show but returns the typed text when it matches
+// no option. Rofi does this natively in -dmenu mode (Ctrl+Return forces it),
+// so only -no-custom has to be dropped from the configured args.
+func (r *Rofi) ShowAllowCustom(options []string, prompt string) (string, error) {
+	var args []string
+	for _, arg := range rofiArgs(r.cfg.GetLauncherConfig("rofi")) {
+		if arg != "-no-custom" {
+			args = append(args, arg)
+		}
+	}
+	args = append(args, prompt)
+
+	var stderr bytes.Buffer
+	cmd := exec.Command("rofi", args...)
+	cmd.Stderr = &stderr
+
+	lines, err := runMenu(cmd, options)
+	if err != nil {
+		return "", r.menuError("rofi", err, stderr.String())
+	}
+
+	if len(lines) == 0 || lines[0] == "" {
+		return "", fmt.Errorf("no selection made")
+	}
+
+	return lines[0], nil
+}
+
+// ShowMulti lets the user mark several options (Shift+Return in rofi)
+func (r *Rofi) ShowMulti(options []string, prompt string) ([]string, error) {
+	args := append(rofiArgs(r.cfg.GetLauncherConfig("rofi")), "-multi-select", prompt)
+
+	var stderr bytes.Buffer
+	cmd := exec.Command("rofi", args...)
+	cmd.Stderr = &stderr
+
+	lines, err := runMenu(cmd, options)
+	if err != nil {
+		return nil, r.menuError("rofi", err, stderr.String())
+	}
+
+	return selectedLines(lines)
+}
+
 // Config() вече идва от baseLauncher - премахни го
+
+// rofiArgs translates the launcher config into rofi flags, followed by the raw args
+func rofiArgs(cfg config.LauncherConfig) []string {
+	var args []string
+
+	if cfg.Config != "" {
+		args = append(args, "-config", utils.ExpandPath(cfg.Config))
+	}
+	if cfg.Theme != "" {
+		args = append(args, "-theme", utils.ExpandPath(cfg.Theme))
+	}
+	if cfg.Font != "" {
+		args = append(args, "-font", cfg.Font)
+	}
+	if cfg.Lines > 0 {
+		args = append(args, "-l", strconv.Itoa(cfg.Lines))
+	}
+	if cfg.Width > 0 {
+		args = append(args, "-theme-str", fmt.Sprintf("window {width: %dch;}", cfg.Width))
+	}
+
+	return append(args, cfg.Args...)
+}
@@ -0,0 +1,344 @@
+package launcher
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/lvim-tech/ql/pkg/config"
+	"github.com/lvim-tech/ql/pkg/utils"
+)
+
+// TUI draws menus directly in the terminal, for use without rofi or fzf.
+// It reads keys from /dev/tty, so stdout stays free for module output.
+type TUI struct {
+	baseLauncher
+}
+
+func NewTUI(cfg *config.Config) *TUI {
+	return &TUI{
+		baseLauncher: baseLauncher{cfg: cfg},
+	}
+}
+
+// Name returns the launcher name
+func (t *TUI) Name() string {
+	return "tui"
+}
+
+func (t *TUI) Show(options []string, prompt string) (string, error) {
+	selected, err := runTUIMenu(options, prompt, "", tuiSingle)
+	if err != nil {
+		return "", err
+	}
+	return selected[0], nil
+}
+
+// ShowOrdered is Show: the TUI filters without reordering
+func (t *TUI) ShowOrdered(options []string, prompt string) (string, error) {
+	return t.Show(options, prompt)
+}
+
+// ShowAllowCustom returns the typed filter when it matches no option
+func (t *TUI) ShowAllowCustom(options []string, prompt string) (string, error) {
+	selected, err := runTUIMenu(options, prompt, "", tuiCustom)
+	if err != nil {
+		return "", err
+	}
+	return selected[0], nil
+}
+
+// ShowMulti lets the user mark several options with Tab
+func (t *TUI) ShowMulti(options []string, prompt string) ([]string, error) {
+	return runTUIMenu(options, prompt, "", tuiMulti)
+}
+
+// ShowWithMessage is Show with the message dimmed under the prompt line
+func (t *TUI) ShowWithMessage(options []string, prompt, message string) (string, error) {
+	selected, err := runTUIMenu(options, prompt, message, tuiSingle)
+	if err != nil {
+		return "", err
+	}
+	return selected[0], nil
+}
+
+type tuiMode int
+
+const (
+	tuiSingle tuiMode = iota
+	tuiCustom
+	tuiMulti
+)
+
+// tuiMenu is the state of one open menu
+type tuiMenu struct {
+	options []string
+	prompt  string
+	message []string // status lines under the prompt, not selectable
+	mode    tuiMode
+	filter  string
+	cursor  int // index into matches()
+	offset  int // first visible match
+	marked  map[string]bool
+}
+
+// matches returns the options containing every space-separated filter
+// term, case-insensitively
+func (m *tuiMenu) matches() []string {
+	terms := strings.Fields(strings.ToLower(m.filter))
+	if len(terms) == 0 {
+		return m.options
+	}
+
+	var result []string
+	for _, option := range m.options {
+		lower := strings.ToLower(option)
+		if !slices.ContainsFunc(terms, func(term string) bool { return !strings.Contains(lower, term) }) {
+			result = append(result, option)
+		}
+	}
+	return result
+}
+
+func (m *tuiMenu) move(delta, count int) {
+	m.cursor = max(0, min(m.cursor+delta, count-1))
+}
+
+// render draws the prompt line and as many matches as fit in rows
+func (m *tuiMenu) render(rows, cols int) string {
+	matches := m.matches()
+	visible := max(rows-1-len(m.message), 1)
+
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+	if m.cursor >= m.offset+visible {
+		m.offset = m.cursor - visible + 1
+	}
+
+	var b strings.Builder
+	b.WriteString("\x1b[H\x1b[2J")
+
+	status := fmt.Sprintf("  %d/%d", len(matches), len(m.options))
+	if m.mode == tuiMulti && len(m.marked) > 0 {
+		status += fmt.Sprintf(" (%d marked)", len(m.marked))
+	}
+	fmt.Fprintf(&b, "\x1b[1m%s>\x1b[0m %s\x1b[2m%s\x1b[0m\r\n", m.prompt, m.filter, status)
+	for _, line := range m.message {
+		fmt.Fprintf(&b, "\x1b[2m%s\x1b[0m\r\n", truncateRunes("  "+line, cols))
+	}
+
+	for i := m.offset; i < len(matches) && i < m.offset+visible; i++ {
+		marker := "  "
+		if m.marked[matches[i]] {
+			marker = "* "
+		}
+		line := truncateRunes(marker+matches[i], cols)
+		if i == m.cursor {
+			fmt.Fprintf(&b, "\x1b[7m%s\x1b[0m\r\n", line)
+		} else {
+			b.WriteString(line + "\r\n")
+		}
+	}
+
+	// Leave the cursor after the filter text
+	fmt.Fprintf(&b, "\x1b[1;%dH", utf8.RuneCountInString(m.prompt)+3+utf8.RuneCountInString(m.filter))
+
+	return b.String()
+}
+
+// key applies one keypress. done is set when the menu closes; a nil result
+// with done set means the menu was cancelled.
+func (m *tuiMenu) key(input []byte) (result []string, done bool) {
+	matches := m.matches()
+
+	switch s := string(input); {
+	case s == "\x1b" || s == "\x03": // Esc, Ctrl-C
+		return nil, true
+
+	case s == "\r" || s == "\n":
+		if m.mode == tuiMulti && len(m.marked) > 0 {
+			var selected []string
+			for _, option := range m.options {
+				if m.marked[option] {
+					selected = append(selected, option)
+				}
+			}
+			return selected, true
+		}
+		if len(matches) > 0 {
+			return []string{matches[m.cursor]}, true
+		}
+		if m.mode == tuiCustom && strings.TrimSpace(m.filter) != "" {
+			return []string{strings.TrimSpace(m.filter)}, true
+		}
+
+	case s == "\t":
+		if m.mode == tuiMulti && len(matches) > 0 {
+			option := matches[m.cursor]
+			if m.marked[option] {
+				delete(m.marked, option)
+			} else {
+				m.marked[option] = true
+			}
+			m.move(1, len(matches))
+		}
+
+	case s == "\x1b[A" || s == "\x1bOA" || s == "\x10": // Up, Ctrl-P
+		m.move(-1, len(matches))
+	case s == "\x1b[B" || s == "\x1bOB" || s == "\x0e": // Down, Ctrl-N
+		m.move(1, len(matches))
+	case s == "\x1b[5~": // Page Up
+		m.move(-10, len(matches))
+	case s == "\x1b[6~": // Page Down
+		m.move(10, len(matches))
+
+	case s == "\x7f" || s == "\x08": // Backspace
+		if m.filter != "" {
+			_, size := utf8.DecodeLastRuneInString(m.filter)
+			m.filter = m.filter[:len(m.filter)-size]
+			m.cursor = 0
+		}
+	case s == "\x15": // Ctrl-U
+		m.filter = ""
+		m.cursor = 0
+
+	case input[0] >= 0x20 && input[0] != 0x7f && utf8.Valid(input):
+		m.filter += s
+		m.cursor = 0
+	}
+
+	return nil, false
+}
+
+// runTUIMenu puts the terminal in raw mode on the alternate screen and runs
+// the menu until a selection is made or it is cancelled
+func runTUIMenu(options []string, prompt, message string, mode tuiMode) ([]string, error) {
+	tty, err := os.OpenFile("/dev/tty", os.O_RDWR, 0)
+	if err != nil {
+		return nil, fmt.Errorf("tui launcher needs a terminal: %w", err)
+	}
+	defer tty.Close()
+
+	state, err := stty(tty, "-g")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read terminal state: %w", err)
+	}
+	if _, err := stty(tty, "raw", "-echo"); err != nil {
+		return nil, fmt.Errorf("failed to set raw mode: %w", err)
+	}
+
+	fmt.Fprint(tty, "\x1b[?1049h")
+
+	restore := func() {
+		fmt.Fprint(tty, "\x1b[?1049l")
+		stty(tty, strings.TrimSpace(state))
+	}
+	// Raw mode swallows Ctrl+C, but SIGTERM must still leave a usable terminal
+	release := utils.OnCleanup(restore)
+	defer func() {
+		release()
+		restore()
+	}()
+
+	menu := &tuiMenu{options: options, prompt: prompt, mode: mode, marked: make(map[string]bool)}
+	if message != "" {
+		menu.message = strings.Split(message, "\n")
+	}
+	buf := make([]byte, 64)
+
+	for {
+		rows, cols := terminalSize(tty)
+		fmt.Fprint(tty, menu.render(rows, cols))
+
+		n, err := tty.Read(buf)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read input: %w", err)
+		}
+		if n == 0 {
+			continue
+		}
+
+		for _, key := range splitKeys(buf[:n]) {
+			if result, done := menu.key(key); done {
+				if result == nil {
+					return nil, fmt.Errorf("no selection made")
+				}
+				return result, nil
+			}
+		}
+	}
+}
+
+// splitKeys splits one read into keypresses, since fast typing or pasting
+// delivers several at once: escape sequences ("\x1b[A", "\x1b[5~"),
+// single UTF-8 characters and control bytes
+func splitKeys(input []byte) [][]byte {
+	var keys [][]byte
+
+	for len(input) > 0 {
+		size := 1
+
+		switch {
+		case input[0] == 0x1b && len(input) > 2 && input[1] == 'O':
+			size = 3
+		case input[0] == 0x1b && len(input) > 2 && input[1] == '[':
+			// CSI: parameter bytes up to a final byte in @..~
+			size = 2
+			for size < len(input) {
+				size++
+				if input[size-1] >= 0x40 && input[size-1] <= 0x7e {
+					break
+				}
+			}
+		case input[0] >= 0x80:
+			_, size = utf8.DecodeRune(input)
+		}
+
+		keys = append(keys, input[:size])
+		input = input[size:]
+	}
+
+	return keys
+}
+
+// stty runs stty against the terminal and returns its output
+func stty(tty *os.File, args ...string) (string, error) {
+	cmd := exec.Command("stty", args...)
+	cmd.Stdin = tty
+	output, err := cmd.Output()
+	return string(output), err
+}
+
+// terminalSize returns rows and columns, defaulting to 24x80
+func terminalSize(tty *os.File) (int, int) {
+	output, err := stty(tty, "size")
+	if err != nil {
+		return 24, 80
+	}
+
+	fields := strings.Fields(output)
+	if len(fields) != 2 {
+		return 24, 80
+	}
+
+	rows, err1 := strconv.Atoi(fields[0])
+	cols, err2 := strconv.Atoi(fields[1])
+	if err1 != nil || err2 != nil || rows <= 0 || cols <= 0 {
+		return 24, 80
+	}
+
+	return rows, cols
+}
+
+func truncateRunes(s string, width int) string {
+	if utf8.RuneCountInString(s) <= width {
+		return s
+	}
+	runes := []rune(s)
+	return string(runes[:max(width-1, 0)]) + "…"
+}
@@ -0,0 +1,47 @@
+// Package pathutil expands the paths ql reads from its config. It imports
+// nothing from ql, so both pkg/config and pkg/utils can use it.
+package pathutil
+
+import (
+	"os"
+	"os/user"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// envNameRe matches the variable names Expand substitutes
+var envNameRe = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// Expand expands a leading ~ or ~user to the home directory, then $VAR and
+// ${VAR}. Every path taken from the config goes through it, so they all
+// resolve alike. An unset variable expands to the empty string, as with
+// os.ExpandEnv; an unknown user is left as written.
+func Expand(path string) string {
+	path = expandTilde(path)
+
+	return os.Expand(path, func(name string) string {
+		if !envNameRe.MatchString(name) {
+			return "$" + name
+		}
+		return os.Getenv(name)
+	})
+}
+
+func expandTilde(path string) string {
+	if !strings.HasPrefix(path, "~") {
+		return path
+	}
+
+	name, rest, _ := strings.Cut(path[1:], "/")
+	home := os.Getenv("HOME")
+	if name != "" {
+		u, err := user.Lookup(name)
+		if err != nil {
+			return path
+		}
+		home = u.HomeDir
+	}
+
+	return filepath.Join(home, rest)
+}
@@ -0,0 +1,102 @@
+package utils
+
+import (
+	"context"
+	"os"
+	"sync"
+)
+
+// cleanup is a callback registered with OnCleanup
+type cleanup struct {
+	id int
+	fn func()
+}
+
+var (
+	cleanupMu     sync.Mutex
+	cleanups      []cleanup
+	nextCleanupID int
+)
+
+// OnCleanup registers fn to run when ql is interrupted (SIGINT/SIGTERM), so
+// temp files, PID files and persistent notifications are not left behind.
+// Call the returned release function once the resource is gone normally.
+func OnCleanup(fn func()) (release func()) {
+	cleanupMu.Lock()
+	defer cleanupMu.Unlock()
+
+	nextCleanupID++
+	id := nextCleanupID
+	cleanups = append(cleanups, cleanup{id: id, fn: fn})
+
+	return func() {
+		cleanupMu.Lock()
+		defer cleanupMu.Unlock()
+
+		for i, c := range cleanups {
+			if c.id == id {
+				cleanups = append(cleanups[:i], cleanups[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// interruptCtx is cancelled when ql is interrupted
+var interruptCtx, cancelInterrupt = context.WithCancel(context.Background())
+
+// InterruptContext returns the context that is cancelled when ql receives
+// SIGINT, SIGTERM or SIGHUP. Long-running work (detached samplers, timers,
+// the commands modules run) watches it to stop and clean up on its own.
+func InterruptContext() context.Context {
+	return interruptCtx
+}
+
+// Interrupt cancels InterruptContext; the signal handler in main calls it
+func Interrupt() {
+	cancelInterrupt()
+}
+
+// RunCleanup runs the registered callbacks, most recent first, and clears them
+func RunCleanup() {
+	cleanupMu.Lock()
+	pending := cleanups
+	cleanups = nil
+	cleanupMu.Unlock()
+
+	for i := len(pending) - 1; i >= 0; i-- {
+		pending[i].fn()
+	}
+}
+
+// WriteTempFile writes data to a new file in the temp directory, named after
+// pattern as in os.CreateTemp. The file is removed on interrupt; call remove
+// when done with it.
+func WriteTempFile(pattern string, data []byte, perm os.FileMode) (path string, remove func(), err error) {
+	f, err := os.CreateTemp("", pattern)
+	if err != nil {
+		return "", nil, err
+	}
+	path = f.Name()
+
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		os.Remove(path)
+		return "", nil, err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(path)
+		return "", nil, err
+	}
+	if err := os.Chmod(path, perm); err != nil {
+		os.Remove(path)
+		return "", nil, err
+	}
+
+	release := OnCleanup(func() { os.Remove(path) })
+
+	return path, func() {
+		release()
+		os.Remove(path)
+	}, nil
+}
@@ -0,0 +1,105 @@
+package utils
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// ============================================================================
+// Clipboard
+// ============================================================================
+
+// CopyToClipboard copies text to the clipboard with wl-copy on Wayland,
+// or xclip/xsel on X11
+func CopyToClipboard(content string) error {
+	var cmd *exec.Cmd
+	if DetectDisplayServer().IsWayland() {
+		if !CommandExists("wl-copy") {
+			return fmt.Errorf("wl-copy not found (install wl-clipboard)")
+		}
+		cmd = exec.Command("wl-copy")
+	} else {
+		if CommandExists("xclip") {
+			cmd = exec.Command("xclip", "-selection", "clipboard")
+		} else if CommandExists("xsel") {
+			cmd = exec.Command("xsel", "-b")
+		} else {
+			return fmt.Errorf("no clipboard tool found (install xclip or xsel)")
+		}
+	}
+
+	return pipeToClipboard(cmd, []byte(content))
+}
+
+// CopyToPrimary sets the PRIMARY selection (middle-click paste). On Wayland
+// this needs a compositor with the primary-selection protocol; wl-copy fails
+// without it.
+func CopyToPrimary(content string) error {
+	var cmd *exec.Cmd
+	if DetectDisplayServer().IsWayland() {
+		if !CommandExists("wl-copy") {
+			return fmt.Errorf("wl-copy not found (install wl-clipboard)")
+		}
+		cmd = exec.Command("wl-copy", "--primary")
+	} else {
+		if CommandExists("xclip") {
+			cmd = exec.Command("xclip", "-selection", "primary")
+		} else if CommandExists("xsel") {
+			cmd = exec.Command("xsel", "-p")
+		} else {
+			return fmt.Errorf("no clipboard tool found (install xclip or xsel)")
+		}
+	}
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := pipeToClipboard(cmd, []byte(content)); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("failed to set primary selection: %s", msg)
+		}
+		return fmt.Errorf("failed to set primary selection: %w", err)
+	}
+
+	return nil
+}
+
+// CopyImage copies binary data such as a PNG to the clipboard with the given
+// MIME type. xsel cannot hold images, so X11 requires xclip.
+func CopyImage(data []byte, mime string) error {
+	var cmd *exec.Cmd
+	if DetectDisplayServer().IsWayland() {
+		if !CommandExists("wl-copy") {
+			return fmt.Errorf("wl-copy not found (install wl-clipboard)")
+		}
+		cmd = exec.Command("wl-copy", "--type", mime)
+	} else {
+		if !CommandExists("xclip") {
+			return fmt.Errorf("xclip not found (required to copy images)")
+		}
+		cmd = exec.Command("xclip", "-selection", "clipboard", "-t", mime)
+	}
+
+	return pipeToClipboard(cmd, data)
+}
+
+// clipboardWaitDelay is how long a copy waits for the tool's stderr to close
+// after the tool exited. xclip and wl-copy fork a child that owns the
+// selection and inherits stderr, so it stays open until another client
+// takes the selection; the parent's own errors are written before it exits.
+const clipboardWaitDelay = 100 * time.Millisecond
+
+func pipeToClipboard(cmd *exec.Cmd, data []byte) error {
+	cmd.Stdin = bytes.NewReader(data)
+	cmd.WaitDelay = clipboardWaitDelay
+
+	err := cmd.Run()
+	if errors.Is(err, exec.ErrWaitDelay) {
+		// The tool exited successfully; only its forked child is still running
+		return nil
+	}
+	return err
+}
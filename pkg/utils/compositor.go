@@ -0,0 +1,122 @@
+package utils
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// Compositor identifies the Wayland compositor (or desktop) ql runs under,
+// which decides how windows and outputs can be queried
+type Compositor int
+
+const (
+	CompositorUnknown Compositor = iota
+	CompositorSway
+	CompositorHyprland
+	CompositorGNOME
+	CompositorKDE
+	// CompositorWlroots is any other wlroots-based compositor (river,
+	// wayfire, labwc, ...): grim and slurp work, but there is no window IPC
+	CompositorWlroots
+)
+
+// String returns the compositor's name
+func (c Compositor) String() string {
+	switch c {
+	case CompositorSway:
+		return "sway"
+	case CompositorHyprland:
+		return "hyprland"
+	case CompositorGNOME:
+		return "gnome"
+	case CompositorKDE:
+		return "kde"
+	case CompositorWlroots:
+		return "wlroots"
+	default:
+		return "unknown"
+	}
+}
+
+// desktopCompositors maps XDG_CURRENT_DESKTOP entries (lowercased) to compositors
+var desktopCompositors = map[string]Compositor{
+	"sway":     CompositorSway,
+	"hyprland": CompositorHyprland,
+	"gnome":    CompositorGNOME,
+	"kde":      CompositorKDE,
+	"river":    CompositorWlroots,
+	"wayfire":  CompositorWlroots,
+	"labwc":    CompositorWlroots,
+	"dwl":      CompositorWlroots,
+}
+
+// compositorProcesses is checked in order when the environment says nothing,
+// e.g. when ql is started from a service that lacks the session variables
+var compositorProcesses = []struct {
+	name       string
+	compositor Compositor
+}{
+	{"Hyprland", CompositorHyprland},
+	{"sway", CompositorSway},
+	{"gnome-shell", CompositorGNOME},
+	{"kwin_wayland", CompositorKDE},
+	{"river", CompositorWlroots},
+	{"wayfire", CompositorWlroots},
+	{"labwc", CompositorWlroots},
+	{"dwl", CompositorWlroots},
+}
+
+// DetectCompositor identifies the running compositor from its IPC socket
+// variables, then XDG_CURRENT_DESKTOP, then the running processes
+func DetectCompositor() Compositor {
+	if os.Getenv("HYPRLAND_INSTANCE_SIGNATURE") != "" {
+		return CompositorHyprland
+	}
+	if os.Getenv("SWAYSOCK") != "" {
+		return CompositorSway
+	}
+
+	if c := compositorFromDesktop(GetCurrentDesktop()); c != CompositorUnknown {
+		return c
+	}
+
+	for _, p := range compositorProcesses {
+		if exec.Command("pgrep", "-x", p.name).Run() == nil {
+			return p.compositor
+		}
+	}
+
+	return CompositorUnknown
+}
+
+// WindowCompositor is DetectCompositor for active window queries, which
+// only sway and Hyprland answer. A compositor it does not recognise as
+// either (a sway fork, a session without SWAYSOCK exported) is taken to be
+// sway when swaymsg is installed, or Hyprland when hyprctl is, as ql did
+// before it detected compositors.
+func WindowCompositor() Compositor {
+	compositor := DetectCompositor()
+	if compositor != CompositorUnknown && compositor != CompositorWlroots {
+		return compositor
+	}
+
+	switch {
+	case CommandExists("swaymsg"):
+		return CompositorSway
+	case CommandExists("hyprctl"):
+		return CompositorHyprland
+	}
+	return compositor
+}
+
+// compositorFromDesktop parses XDG_CURRENT_DESKTOP, a colon-separated list
+// such as "ubuntu:GNOME"
+func compositorFromDesktop(desktop string) Compositor {
+	for _, entry := range strings.Split(desktop, ":") {
+		if c, ok := desktopCompositors[strings.ToLower(strings.TrimSpace(entry))]; ok {
+			return c
+		}
+	}
+	return CompositorUnknown
+}
@@ -0,0 +1,82 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCompositorFromDesktop(t *testing.T) {
+	tests := []struct {
+		desktop string
+		want    Compositor
+	}{
+		{"", CompositorUnknown},
+		{"sway", CompositorSway},
+		{"Hyprland", CompositorHyprland},
+		{"GNOME", CompositorGNOME},
+		{"ubuntu:GNOME", CompositorGNOME},
+		{"KDE", CompositorKDE},
+		{"river", CompositorWlroots},
+		{"wayfire", CompositorWlroots},
+		{"labwc:wlroots", CompositorWlroots},
+		{"XFCE", CompositorUnknown},
+		{" sway ", CompositorSway},
+	}
+
+	for _, tt := range tests {
+		if got := compositorFromDesktop(tt.desktop); got != tt.want {
+			t.Errorf("compositorFromDesktop(%q) = %s, want %s", tt.desktop, got, tt.want)
+		}
+	}
+}
+
+func TestDetectCompositorEnv(t *testing.T) {
+	tests := []struct {
+		name               string
+		hyprland, swaysock string
+		desktop            string
+		want               Compositor
+	}{
+		// The IPC sockets are what window queries need, so they win
+		{"hyprland socket", "abc", "", "GNOME", CompositorHyprland},
+		{"sway socket", "", "/run/user/1000/sway-ipc.sock", "KDE", CompositorSway},
+		{"hyprland before sway", "abc", "/run/user/1000/sway-ipc.sock", "", CompositorHyprland},
+		{"desktop only", "", "", "ubuntu:GNOME", CompositorGNOME},
+		{"wlroots desktop", "", "", "river", CompositorWlroots},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("HYPRLAND_INSTANCE_SIGNATURE", tt.hyprland)
+			t.Setenv("SWAYSOCK", tt.swaysock)
+			t.Setenv("XDG_CURRENT_DESKTOP", tt.desktop)
+
+			if got := DetectCompositor(); got != tt.want {
+				t.Errorf("DetectCompositor() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWindowCompositorFallback(t *testing.T) {
+	bin := t.TempDir()
+	if err := os.WriteFile(filepath.Join(bin, "swaymsg"), []byte("#!/bin/sh\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin)
+	t.Setenv("HYPRLAND_INSTANCE_SIGNATURE", "")
+	t.Setenv("SWAYSOCK", "")
+
+	// A wlroots compositor without window IPC of its own still gets swaymsg
+	t.Setenv("XDG_CURRENT_DESKTOP", "river")
+	if got := WindowCompositor(); got != CompositorSway {
+		t.Errorf("WindowCompositor() with swaymsg installed = %s, want sway", got)
+	}
+
+	// A recognised desktop is not second-guessed
+	t.Setenv("XDG_CURRENT_DESKTOP", "GNOME")
+	if got := WindowCompositor(); got != CompositorGNOME {
+		t.Errorf("WindowCompositor() on GNOME = %s, want gnome", got)
+	}
+}
@@ -0,0 +1,65 @@
+package utils
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// FormatBytes converts bytes to human-readable format, in 1024 steps with
+// the short unit names (KB, MB) users know from most tools
+func FormatBytes(bytes uint64) string {
+	return formatBytes(bytes, 1024, "B", []string{"KB", "MB", "GB", "TB", "PB", "EB"})
+}
+
+// FormatBytesIEC is FormatBytes with the IEC unit names (KiB, MiB)
+func FormatBytesIEC(bytes uint64) string {
+	return formatBytes(bytes, 1024, "B", []string{"KiB", "MiB", "GiB", "TiB", "PiB", "EiB"})
+}
+
+// FormatBytesSI converts bytes in 1000 steps (kB, MB), as disk vendors count
+func FormatBytesSI(bytes uint64) string {
+	return formatBytes(bytes, 1000, "B", []string{"kB", "MB", "GB", "TB", "PB", "EB"})
+}
+
+// FormatRate formats a per-second byte count, e.g. "1.5 MB/s". Negative and
+// NaN rates, as from a counter that went backwards, show as zero.
+func FormatRate(bytesPerSecond float64) string {
+	if bytesPerSecond <= 0 || math.IsNaN(bytesPerSecond) {
+		return FormatBytes(0) + "/s"
+	}
+	if bytesPerSecond >= math.MaxUint64 {
+		return FormatBytes(math.MaxUint64) + "/s"
+	}
+	return FormatBytes(uint64(bytesPerSecond)) + "/s"
+}
+
+// FormatElapsed formats a running time as a clock, "04:37" or "1:04:37"
+func FormatElapsed(d time.Duration) string {
+	seconds := max(int(d.Seconds()), 0)
+	if seconds >= 3600 {
+		return fmt.Sprintf("%d:%02d:%02d", seconds/3600, seconds/60%60, seconds%60)
+	}
+	return fmt.Sprintf("%02d:%02d", seconds/60, seconds%60)
+}
+
+func formatBytes(bytes, unit uint64, base string, units []string) string {
+	if bytes < unit {
+		return fmt.Sprintf("%d %s", bytes, base)
+	}
+
+	div, exp := unit, 0
+	for n := bytes / unit; n >= unit && exp < len(units)-1; n /= unit {
+		div *= unit
+		exp++
+	}
+
+	value := float64(bytes) / float64(div)
+	// Just below the next unit, e.g. 1048575 B, %.1f would round to "1024.0 KB"
+	if math.Round(value*10)/10 >= float64(unit) && exp < len(units)-1 {
+		value /= float64(unit)
+		exp++
+	}
+
+	return fmt.Sprintf("%.1f %s", value, units[exp])
+}
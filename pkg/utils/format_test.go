@@ -0,0 +1,84 @@
+package utils
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestFormatBytes(t *testing.T) {
+	tests := []struct {
+		bytes                 uint64
+		want, wantIEC, wantSI string
+	}{
+		{0, "0 B", "0 B", "0 B"},
+		{1, "1 B", "1 B", "1 B"},
+		{999, "999 B", "999 B", "999 B"},
+		{1000, "1000 B", "1000 B", "1.0 kB"},
+		{1023, "1023 B", "1023 B", "1.0 kB"},
+		{1024, "1.0 KB", "1.0 KiB", "1.0 kB"},
+		{1536, "1.5 KB", "1.5 KiB", "1.5 kB"},
+		{1048575, "1.0 MB", "1.0 MiB", "1.0 MB"},
+		{1048576, "1.0 MB", "1.0 MiB", "1.0 MB"},
+		{999999, "976.6 KB", "976.6 KiB", "1.0 MB"},
+		{1 << 30, "1.0 GB", "1.0 GiB", "1.1 GB"},
+		{1 << 60, "1.0 EB", "1.0 EiB", "1.2 EB"},
+		{math.MaxUint64, "16.0 EB", "16.0 EiB", "18.4 EB"},
+	}
+
+	for _, tt := range tests {
+		if got := FormatBytes(tt.bytes); got != tt.want {
+			t.Errorf("FormatBytes(%d) = %q, want %q", tt.bytes, got, tt.want)
+		}
+		if got := FormatBytesIEC(tt.bytes); got != tt.wantIEC {
+			t.Errorf("FormatBytesIEC(%d) = %q, want %q", tt.bytes, got, tt.wantIEC)
+		}
+		if got := FormatBytesSI(tt.bytes); got != tt.wantSI {
+			t.Errorf("FormatBytesSI(%d) = %q, want %q", tt.bytes, got, tt.wantSI)
+		}
+	}
+}
+
+func TestFormatRate(t *testing.T) {
+	tests := []struct {
+		rate float64
+		want string
+	}{
+		{0, "0 B/s"},
+		{-512, "0 B/s"},
+		{math.NaN(), "0 B/s"},
+		{0.5, "0 B/s"},
+		{1023.9, "1023 B/s"},
+		{1024, "1.0 KB/s"},
+		{1.5 * 1024 * 1024, "1.5 MB/s"},
+		{math.MaxUint64, "16.0 EB/s"},
+		{math.Inf(1), "16.0 EB/s"},
+		{1e30, "16.0 EB/s"},
+	}
+
+	for _, tt := range tests {
+		if got := FormatRate(tt.rate); got != tt.want {
+			t.Errorf("FormatRate(%v) = %q, want %q", tt.rate, got, tt.want)
+		}
+	}
+}
+
+func TestFormatElapsed(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{0, "00:00"},
+		{-time.Second, "00:00"},
+		{59 * time.Second, "00:59"},
+		{time.Hour - time.Second, "59:59"},
+		{time.Hour, "1:00:00"},
+		{26*time.Hour + 4*time.Minute + 37*time.Second, "26:04:37"},
+	}
+
+	for _, tt := range tests {
+		if got := FormatElapsed(tt.d); got != tt.want {
+			t.Errorf("FormatElapsed(%v) = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+}
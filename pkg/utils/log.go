@@ -0,0 +1,92 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ============================================================================
+// Debug Logging
+// ============================================================================
+
+// logger discards everything until SetupLogging enables debug output
+var logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+// LogFile returns the path of the debug log
+func LogFile() string {
+	return filepath.Join(GetCacheDir(), "ql", "ql.log")
+}
+
+// DebugEnabled reports whether QL_DEBUG asks for debug logging
+func DebugEnabled() bool {
+	value := os.Getenv("QL_DEBUG")
+	return value != "" && value != "0" && value != "false"
+}
+
+// SetupLogging enables debug logging to stderr and LogFile. Without debug,
+// logging stays silent. The log file is optional: if it cannot be opened,
+// only stderr is used.
+func SetupLogging(debug bool) {
+	if !debug {
+		return
+	}
+
+	var out io.Writer = os.Stderr
+	if err := EnsureDir(filepath.Dir(LogFile())); err == nil {
+		if file, err := os.OpenFile(LogFile(), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644); err == nil {
+			out = io.MultiWriter(os.Stderr, file)
+		}
+	}
+
+	logger = slog.New(slog.NewTextHandler(out, &slog.HandlerOptions{Level: slog.LevelDebug}))
+}
+
+func LogDebug(msg string, args ...any) {
+	logger.Debug(msg, args...)
+}
+
+func LogInfo(msg string, args ...any) {
+	logger.Info(msg, args...)
+}
+
+func LogWarn(msg string, args ...any) {
+	logger.Warn(msg, args...)
+}
+
+func LogError(msg string, args ...any) {
+	logger.Error(msg, args...)
+}
+
+// logExec records an external command that finished, with its exit code
+func logExec(name string, args []string, start time.Time, err error) {
+	if !logger.Enabled(context.Background(), slog.LevelDebug) {
+		return
+	}
+
+	exitCode := 0
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		exitCode = exitErr.ExitCode()
+	} else if err != nil {
+		exitCode = -1
+	}
+
+	attrs := []any{
+		"cmd", name,
+		"args", strings.Join(args, " "),
+		"exit", exitCode,
+		"duration", time.Since(start).Round(time.Millisecond),
+	}
+	if err != nil {
+		attrs = append(attrs, "err", err)
+	}
+
+	logger.Debug("exec", attrs...)
+}
@@ -0,0 +1,230 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// monitorQueryTimeout bounds xrandr and compositor IPC calls
+const monitorQueryTimeout = 5 * time.Second
+
+// Monitor is a connected output and its place in the virtual screen
+type Monitor struct {
+	Name    string
+	Width   int
+	Height  int
+	X       int
+	Y       int
+	Primary bool
+}
+
+// Geometry returns the size as "WIDTHxHEIGHT" (ffmpeg -video_size)
+func (m Monitor) Geometry() string {
+	return fmt.Sprintf("%dx%d", m.Width, m.Height)
+}
+
+// Offset returns the position as "X,Y" (x11grab input offset)
+func (m Monitor) Offset() string {
+	return fmt.Sprintf("%d,%d", m.X, m.Y)
+}
+
+// ListMonitors returns the active monitors. X11 uses xrandr; Wayland asks
+// Hyprland or Sway over IPC and falls back to wlr-randr.
+func ListMonitors() ([]Monitor, error) {
+	if DetectDisplayServer().IsWayland() {
+		return listWaylandMonitors()
+	}
+
+	if !CommandExists("xrandr") {
+		return nil, fmt.Errorf("xrandr is not installed")
+	}
+
+	output, err := RunCommandTimeout(monitorQueryTimeout, "xrandr", "--listmonitors")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list monitors: %w", err)
+	}
+
+	return parseXrandrMonitors(output), nil
+}
+
+// FindMonitor returns the monitor with the given output name (case-insensitive)
+func FindMonitor(name string) (Monitor, error) {
+	monitors, err := ListMonitors()
+	if err != nil {
+		return Monitor{}, err
+	}
+
+	names := make([]string, 0, len(monitors))
+	for _, m := range monitors {
+		if strings.EqualFold(m.Name, name) {
+			return m, nil
+		}
+		names = append(names, m.Name)
+	}
+
+	return Monitor{}, fmt.Errorf("unknown output: %s (available: %s)", name, strings.Join(names, ", "))
+}
+
+// xrandrMonitorGeometry matches "2560/597x1440/336+1920+0" (size/mm and offset)
+var xrandrMonitorGeometry = regexp.MustCompile(`^(\d+)/\d+x(\d+)/\d+\+(-?\d+)\+(-?\d+)$`)
+
+// parseXrandrMonitors parses 'xrandr --listmonitors':
+//
+//	Monitors: 2
+//	 0: +*DP-1 2560/597x1440/336+0+0  DP-1
+//	 1: +HDMI-1 1920/527x1080/296+2560+0  HDMI-1
+func parseXrandrMonitors(output string) []Monitor {
+	var monitors []Monitor
+
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 4 || !strings.HasSuffix(fields[0], ":") {
+			continue
+		}
+
+		match := xrandrMonitorGeometry.FindStringSubmatch(fields[2])
+		if match == nil {
+			continue
+		}
+
+		m := Monitor{
+			Name:    fields[len(fields)-1],
+			Primary: strings.Contains(fields[1], "*"),
+		}
+		m.Width, _ = strconv.Atoi(match[1])
+		m.Height, _ = strconv.Atoi(match[2])
+		m.X, _ = strconv.Atoi(match[3])
+		m.Y, _ = strconv.Atoi(match[4])
+
+		monitors = append(monitors, m)
+	}
+
+	return monitors
+}
+
+func listWaylandMonitors() ([]Monitor, error) {
+	compositor := DetectCompositor()
+
+	switch {
+	case compositor == CompositorHyprland && CommandExists("hyprctl"):
+		output, err := RunCommandTimeout(monitorQueryTimeout, "hyprctl", "monitors", "-j")
+		if err != nil {
+			return nil, fmt.Errorf("failed to list monitors: %w", err)
+		}
+		return parseHyprlandMonitors(output)
+
+	case compositor == CompositorSway && CommandExists("swaymsg"):
+		output, err := RunCommandTimeout(monitorQueryTimeout, "swaymsg", "-t", "get_outputs", "-r")
+		if err != nil {
+			return nil, fmt.Errorf("failed to list monitors: %w", err)
+		}
+		return parseSwayOutputs(output)
+
+	case CommandExists("wlr-randr"):
+		output, err := RunCommandTimeout(monitorQueryTimeout, "wlr-randr", "--json")
+		if err != nil {
+			return nil, fmt.Errorf("failed to list monitors: %w", err)
+		}
+		return parseWlrRandr(output)
+	}
+
+	return nil, fmt.Errorf("listing monitors needs hyprctl, swaymsg or wlr-randr")
+}
+
+// parseHyprlandMonitors parses 'hyprctl monitors -j'. Sizes are in physical
+// pixels, positions in layout coordinates.
+func parseHyprlandMonitors(output string) ([]Monitor, error) {
+	var outputs []struct {
+		Name   string `json:"name"`
+		Width  int    `json:"width"`
+		Height int    `json:"height"`
+		X      int    `json:"x"`
+		Y      int    `json:"y"`
+	}
+	if err := json.Unmarshal([]byte(output), &outputs); err != nil {
+		return nil, fmt.Errorf("failed to parse hyprctl output: %w", err)
+	}
+
+	monitors := make([]Monitor, 0, len(outputs))
+	for _, o := range outputs {
+		monitors = append(monitors, Monitor{Name: o.Name, Width: o.Width, Height: o.Height, X: o.X, Y: o.Y})
+	}
+
+	return monitors, nil
+}
+
+// parseSwayOutputs parses 'swaymsg -t get_outputs -r', skipping disabled outputs
+func parseSwayOutputs(output string) ([]Monitor, error) {
+	var outputs []struct {
+		Name    string `json:"name"`
+		Active  bool   `json:"active"`
+		Primary bool   `json:"primary"`
+		Rect    struct {
+			X      int `json:"x"`
+			Y      int `json:"y"`
+			Width  int `json:"width"`
+			Height int `json:"height"`
+		} `json:"rect"`
+	}
+	if err := json.Unmarshal([]byte(output), &outputs); err != nil {
+		return nil, fmt.Errorf("failed to parse swaymsg output: %w", err)
+	}
+
+	var monitors []Monitor
+	for _, o := range outputs {
+		if !o.Active {
+			continue
+		}
+		monitors = append(monitors, Monitor{
+			Name:    o.Name,
+			Width:   o.Rect.Width,
+			Height:  o.Rect.Height,
+			X:       o.Rect.X,
+			Y:       o.Rect.Y,
+			Primary: o.Primary,
+		})
+	}
+
+	return monitors, nil
+}
+
+// parseWlrRandr parses 'wlr-randr --json', using the current mode for the size
+func parseWlrRandr(output string) ([]Monitor, error) {
+	var outputs []struct {
+		Name    string `json:"name"`
+		Enabled bool   `json:"enabled"`
+		Modes   []struct {
+			Width   int  `json:"width"`
+			Height  int  `json:"height"`
+			Current bool `json:"current"`
+		} `json:"modes"`
+		Position struct {
+			X int `json:"x"`
+			Y int `json:"y"`
+		} `json:"position"`
+	}
+	if err := json.Unmarshal([]byte(output), &outputs); err != nil {
+		return nil, fmt.Errorf("failed to parse wlr-randr output: %w", err)
+	}
+
+	var monitors []Monitor
+	for _, o := range outputs {
+		if !o.Enabled {
+			continue
+		}
+		m := Monitor{Name: o.Name, X: o.Position.X, Y: o.Position.Y}
+		for _, mode := range o.Modes {
+			if mode.Current {
+				m.Width, m.Height = mode.Width, mode.Height
+				break
+			}
+		}
+		monitors = append(monitors, m)
+	}
+
+	return monitors, nil
+}
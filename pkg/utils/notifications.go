@@ -7,6 +7,7 @@ import (
 	"os"
 	"os/exec"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/lvim-tech/ql/pkg/config"
@@ -31,7 +32,11 @@ func NotifyWithConfig(cfg *config.NotificationConfig, title, message string) {
 	}
 
 	// Send notification
-	sendNotification(tool, title, message, cfg.Timeout, cfg.Urgency, "normal")
+	urgency := cfg.Urgency
+	if urgency == "" {
+		urgency = "normal"
+	}
+	sendNotification(tool, title, message, cfg.Timeout, urgency, notificationIcon(cfg, urgency))
 }
 
 // ShowErrorNotificationWithConfig sends an error notification using the provided config
@@ -53,7 +58,7 @@ func ShowErrorNotificationWithConfig(cfg *config.NotificationConfig, title, mess
 	}
 
 	// Send error notification with critical urgency
-	sendNotification(tool, title, message, cfg.Timeout, "critical", "critical")
+	sendNotification(tool, title, message, cfg.Timeout, "critical", notificationIcon(cfg, "critical"))
 }
 
 // ShowPersistentNotificationWithConfig shows a persistent notification that doesn't auto-close
@@ -70,6 +75,7 @@ func ShowPersistentNotificationWithConfig(cfg *config.NotificationConfig, title,
 	}
 
 	notifyID := int(time.Now().UnixNano() % 1000000)
+	registerPersistentCleanup(cfg, notifyID)
 
 	// Determine which notification tool to use
 	tool := cfg.Tool
@@ -77,24 +83,26 @@ func ShowPersistentNotificationWithConfig(cfg *config.NotificationConfig, title,
 		tool = detectNotificationTool()
 	}
 
+	icon := notificationIcon(cfg, cfg.Urgency)
+
 	if tool == "dunstify" {
-		cmd := exec.Command("dunstify",
+		args := append([]string{
 			"-u", cfg.Urgency,
 			"-t", "0",
 			"-r", strconv.Itoa(notifyID),
-			title,
-			message)
+		}, iconArgs(icon)...)
+		cmd := exec.Command("dunstify", append(args, title, message)...)
 		cmd.Env = os.Environ()
 		cmd.Start()
 		return notifyID
 	}
 
 	if tool == "notify-send" {
-		cmd := exec.Command("notify-send",
+		args := append([]string{
 			"-u", cfg.Urgency,
 			"-t", "0",
-			title,
-			message)
+		}, iconArgs(icon)...)
+		cmd := exec.Command("notify-send", append(args, title, message)...)
 		cmd.Env = os.Environ()
 		cmd.Start()
 		return notifyID
@@ -109,6 +117,8 @@ func ClosePersistentNotificationWithConfig(cfg *config.NotificationConfig, notif
 		return
 	}
 
+	releasePersistentCleanup(notifyID)
+
 	// Determine which notification tool to use
 	tool := cfg.Tool
 	if tool == "" || tool == "auto" {
@@ -126,6 +136,35 @@ func ClosePersistentNotificationWithConfig(cfg *config.NotificationConfig, notif
 // Internal Helper Functions
 // ============================================================================
 
+var (
+	persistentMu       sync.Mutex
+	persistentCleanups = make(map[int]func())
+)
+
+// registerPersistentCleanup closes the notification if ql is interrupted
+// before ClosePersistentNotificationWithConfig runs
+func registerPersistentCleanup(cfg *config.NotificationConfig, notifyID int) {
+	cfgCopy := *cfg
+	release := OnCleanup(func() {
+		ClosePersistentNotificationWithConfig(&cfgCopy, notifyID)
+	})
+
+	persistentMu.Lock()
+	persistentCleanups[notifyID] = release
+	persistentMu.Unlock()
+}
+
+func releasePersistentCleanup(notifyID int) {
+	persistentMu.Lock()
+	release, ok := persistentCleanups[notifyID]
+	delete(persistentCleanups, notifyID)
+	persistentMu.Unlock()
+
+	if ok {
+		release()
+	}
+}
+
 // detectNotificationTool detects which notification tool is available
 func detectNotificationTool() string {
 	if CommandExists("dunstify") {
@@ -137,17 +176,33 @@ func detectNotificationTool() string {
 	return ""
 }
 
+// notificationIcon returns the configured icon for urgency, or "" for none.
+// Paths may start with ~.
+func notificationIcon(cfg *config.NotificationConfig, urgency string) string {
+	icon := cfg.IconNormal
+	if urgency == "critical" {
+		icon = cfg.IconCritical
+	}
+	if icon == "" {
+		return ""
+	}
+	return ExpandPath(icon)
+}
+
+// iconArgs returns the -i flag shared by dunstify and notify-send
+func iconArgs(icon string) []string {
+	if icon == "" {
+		return nil
+	}
+	return []string{"-i", icon}
+}
+
 // sendNotification sends a notification using the specified tool
-func sendNotification(tool, title, message string, timeout int, urgency, fallbackUrgency string) {
+func sendNotification(tool, title, message string, timeout int, urgency, icon string) {
 	if tool == "" {
 		return
 	}
 
-	// Use fallback urgency if urgency is not set
-	if urgency == "" {
-		urgency = fallbackUrgency
-	}
-
 	// Default timeout
 	if timeout <= 0 {
 		timeout = 5000
@@ -155,20 +210,18 @@ func sendNotification(tool, title, message string, timeout int, urgency, fallbac
 
 	var cmd *exec.Cmd
 
+	args := append([]string{
+		"-u", urgency,
+		"-t", strconv.Itoa(timeout),
+	}, iconArgs(icon)...)
+	args = append(args, title, message)
+
 	switch tool {
 	case "dunstify":
-		cmd = exec.Command("dunstify",
-			"-u", urgency,
-			"-t", strconv.Itoa(timeout),
-			title,
-			message)
+		cmd = exec.Command("dunstify", args...)
 
 	case "notify-send":
-		cmd = exec.Command("notify-send",
-			"-u", urgency,
-			"-t", strconv.Itoa(timeout),
-			title,
-			message)
+		cmd = exec.Command("notify-send", args...)
 
 	default:
 		return
@@ -0,0 +1,51 @@
+package utils
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// ShowTextReport shows a plain text report (weather, network statistics,
+// station checks) in a yad or zenity text window, else in a terminal that
+// waits for Enter, else on stdout. It returns once the window is closed.
+func ShowTextReport(title, text string) error {
+	textFile, remove, err := WriteTempFile("ql-report-*.txt", []byte(text), 0644)
+	if err != nil {
+		fmt.Println(text)
+		return nil
+	}
+	defer remove()
+
+	for _, tool := range []string{"yad", "zenity"} {
+		if !CommandExists(tool) {
+			continue
+		}
+
+		args := []string{"--text-info", "--title=" + title, "--width=800", "--height=600", "--filename=" + textFile}
+		if tool == "yad" {
+			args = append(args, "--fontname=Monospace 10")
+		}
+		cmd := exec.Command(tool, args...)
+		cmd.Env = os.Environ()
+		return cmd.Run()
+	}
+
+	if terminal := DetectTerminal(); terminal != "" {
+		// The script reads the report from its file, so no text can end a heredoc early
+		script := fmt.Sprintf("#!/bin/sh\ncat %s\necho ''\necho 'Press Enter to close... '\nread _\n", shellQuote(textFile))
+		if tmpScript, removeScript, err := WriteTempFile("ql-report-*.sh", []byte(script), 0755); err == nil {
+			defer removeScript()
+			return exec.Command(terminal, "-e", tmpScript).Run()
+		}
+	}
+
+	fmt.Println(text)
+	return nil
+}
+
+// shellQuote quotes s as a single sh word
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,34 @@
+package utils
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestTerminalWidth(t *testing.T) {
+	noTTY := func() (int, error) { return 0, errors.New("no tty") }
+	tty := func(cols int) func() (int, error) {
+		return func() (int, error) { return cols, nil }
+	}
+
+	tests := []struct {
+		name    string
+		query   func() (int, error)
+		columns string
+		want    int
+	}{
+		{"tty wins over COLUMNS", tty(120), "80", 120},
+		{"tty only", tty(100), "", 100},
+		{"COLUMNS without a tty", noTTY, "80", 80},
+		{"tty reporting 0", tty(0), "72", 72},
+		{"nothing known", noTTY, "", 0},
+		{"COLUMNS not a number", noTTY, "wide", 0},
+		{"COLUMNS negative", noTTY, "-5", 0},
+	}
+
+	for _, tt := range tests {
+		if got := terminalWidth(tt.query, tt.columns); got != tt.want {
+			t.Errorf("%s: terminalWidth() = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
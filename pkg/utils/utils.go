@@ -4,14 +4,22 @@
 package utils
 
 import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strconv"
 	"strings"
+	"sync"
 	"syscall"
 	"time"
+	"unsafe"
+
+	"github.com/lvim-tech/ql/pkg/pathutil"
 )
 
 // ============================================================================
@@ -87,19 +95,90 @@ func CommandExists(cmd string) bool {
 
 // RunCommand executes a command and returns output
 func RunCommand(name string, args ...string) (string, error) {
+	start := time.Now()
 	cmd := exec.Command(name, args...)
 	output, err := cmd.CombinedOutput()
+	logExec(name, args, start, err)
 	return string(output), err
 }
 
+// ErrCommandTimeout is returned (wrapped) when a command exceeds its deadline
+var ErrCommandTimeout = errors.New("command timed out")
+
+// RunCommandContext executes a command and returns its stdout, killing the
+// command's whole process group when ctx is done. Unlike RunCommand, stderr is
+// not mixed into the output; it is appended to the error instead.
+func RunCommandContext(ctx context.Context, name string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error {
+		// Negative PID targets the group, so helpers spawned by the tool die too
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	}
+	// Don't wait forever on pipes held open by surviving grandchildren
+	cmd.WaitDelay = time.Second
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	start := time.Now()
+	output, err := cmd.Output()
+	logExec(name, args, start, err)
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return string(output), fmt.Errorf("%s: %w", name, ErrCommandTimeout)
+		}
+		if errors.Is(ctx.Err(), context.Canceled) {
+			return string(output), fmt.Errorf("%s: %w", name, context.Canceled)
+		}
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return string(output), fmt.Errorf("%s: %w: %s", name, err, msg)
+		}
+		return string(output), fmt.Errorf("%s: %w", name, err)
+	}
+
+	return string(output), nil
+}
+
+// RunCommandTimeout is RunCommandContext with a deadline of d, under the
+// context set with SetCommandContext
+func RunCommandTimeout(d time.Duration, name string, args ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(commandContext(), d)
+	defer cancel()
+	return RunCommandContext(ctx, name, args...)
+}
+
+var (
+	commandCtxMu sync.Mutex
+	commandCtx   = InterruptContext()
+)
+
+// SetCommandContext sets the parent context of RunCommandTimeout. While a
+// module runs under module_timeout this is the module's context, so the
+// commands helpers start without a LauncherContext at hand are killed with
+// it as well. Pass InterruptContext(), the default, to reset it.
+func SetCommandContext(ctx context.Context) {
+	commandCtxMu.Lock()
+	defer commandCtxMu.Unlock()
+	commandCtx = ctx
+}
+
+func commandContext() context.Context {
+	commandCtxMu.Lock()
+	defer commandCtxMu.Unlock()
+	return commandCtx
+}
+
 // RunCommandBackground executes a command in background
 func RunCommandBackground(name string, args ...string) error {
+	LogDebug("start", "cmd", name, "args", strings.Join(args, " "))
 	cmd := exec.Command(name, args...)
 	return cmd.Start()
 }
 
 // StartDetachedProcess starts a process completely detached (daemon mode)
 func StartDetachedProcess(name string, args ...string) error {
+	LogDebug("start detached", "cmd", name, "args", strings.Join(args, " "))
 	cmd := exec.Command(name, args...)
 	cmd.Stdin = nil
 	cmd.Stdout = nil
@@ -148,39 +227,56 @@ func GetProcessPID(name string) (int, error) {
 // File System Utilities
 // ============================================================================
 
-// ExpandHomeDir expands ~ in paths
-func ExpandHomeDir(path string) string {
-	if len(path) > 0 && path[0] == '~' {
-		return filepath.Join(GetHomeDir(), path[1:])
-	}
-	return path
+// ExpandPath expands ~, ~user, $VAR and ${VAR} in a config path; see
+// pathutil.Expand
+func ExpandPath(path string) string {
+	return pathutil.Expand(path)
 }
 
-// EnsureDir creates directory if it doesn't exist
+// EnsureDir creates directory if it doesn't exist. Paths from the config
+// must be expanded with ExpandPath first.
 func EnsureDir(path string) error {
-	path = ExpandHomeDir(path)
 	if _, err := os.Stat(path); os.IsNotExist(err) {
 		return os.MkdirAll(path, 0755)
 	}
 	return nil
 }
 
-// EnsureDirExpanded creates directory after home expansion
-// Alias for EnsureDir (for backward compatibility)
+// EnsureDirExpanded is EnsureDir for a config path, expanding it first
 func EnsureDirExpanded(path string) error {
-	return EnsureDir(path)
+	return EnsureDir(ExpandPath(path))
+}
+
+// SaveDir resolves a capture module's save_dir for its save_dir_layout and
+// creates it. "flat" (or empty) uses save_dir as is; "date" uses save_dir/YYYY/MM
+// for the current month, so a new month gets its own directory on first use.
+func SaveDir(saveDir, layout string) (string, error) {
+	dir := ExpandPath(saveDir)
+
+	switch layout {
+	case "", "flat":
+	case "date":
+		now := time.Now()
+		dir = filepath.Join(dir, now.Format("2006"), now.Format("01"))
+	default:
+		return "", fmt.Errorf("invalid save_dir_layout: %s (must be 'flat' or 'date')", layout)
+	}
+
+	if err := EnsureDir(dir); err != nil {
+		return "", err
+	}
+
+	return dir, nil
 }
 
 // FileExists checks if file exists
 func FileExists(path string) bool {
-	path = ExpandHomeDir(path)
 	_, err := os.Stat(path)
 	return err == nil
 }
 
 // IsDirectory checks if path is a directory
 func IsDirectory(path string) bool {
-	path = ExpandHomeDir(path)
 	info, err := os.Stat(path)
 	if err != nil {
 		return false
@@ -248,49 +344,200 @@ func GetCacheDir() string {
 	return filepath.Join(GetHomeDir(), ".cache")
 }
 
+// GetUserDir returns an XDG user directory (name is PICTURES, VIDEOS, MUSIC, ...)
+// as set in user-dirs.dirs, so localized folders like ~/Imágenes are found.
+// Returns the expanded fallback when the file or entry is missing, or when the
+// entry points at $HOME itself, which marks the directory as disabled.
+func GetUserDir(name, fallback string) string {
+	data, err := os.ReadFile(filepath.Join(GetConfigDir(), "user-dirs.dirs"))
+	if err != nil {
+		return ExpandPath(fallback)
+	}
+
+	if dir := parseUserDirs(string(data), name); dir != "" && dir != GetHomeDir() {
+		return dir
+	}
+
+	return ExpandPath(fallback)
+}
+
+// parseUserDirs finds XDG_<name>_DIR="$HOME/..." in user-dirs.dirs content
+func parseUserDirs(content, name string) string {
+	key := "XDG_" + name + "_DIR="
+
+	for _, line := range strings.Split(content, "\n") {
+		value, found := strings.CutPrefix(strings.TrimSpace(line), key)
+		if !found {
+			continue
+		}
+
+		value = strings.Trim(value, `"`)
+		if rest, ok := strings.CutPrefix(value, "$HOME"); ok {
+			return filepath.Join(GetHomeDir(), rest)
+		}
+		if filepath.IsAbs(value) {
+			return filepath.Clean(value)
+		}
+	}
+
+	return ""
+}
+
 // ============================================================================
 // Password Input Utilities
 // ============================================================================
 
-// PromptPassword shows password prompt with appropriate launcher
-func PromptPassword(prompt string) (string, error) {
-	// Try rofi first (best password support)
-	if CommandExists("rofi") {
-		cmd := exec.Command("rofi", "-dmenu", "-password", "-p", prompt)
-		output, err := cmd.Output()
-		if err == nil {
-			password := strings.TrimSpace(string(output))
-			if password != "" {
-				return password, nil
-			}
-		}
+// passwordPrompts builds the command line of a password prompt per tool.
+// masked reports whether the tool hides the typed input.
+var passwordPrompts = map[string]struct {
+	args   func(prompt string) []string
+	masked bool
+}{
+	"rofi": {
+		args:   func(prompt string) []string { return []string{"-dmenu", "-password", "-p", prompt} },
+		masked: true,
+	},
+	"bemenu": {
+		args:   func(prompt string) []string { return []string{"-x", "indicator", "-p", prompt} },
+		masked: true,
+	},
+	"fuzzel": {
+		args: func(prompt string) []string {
+			return []string{"--dmenu", "--password", "--lines", "0", "--prompt", prompt + ": "}
+		},
+		masked: true,
+	},
+	"zenity": {
+		args:   func(prompt string) []string { return []string{"--password", "--title", prompt} },
+		masked: true,
+	},
+	"dmenu": {
+		args:   func(prompt string) []string { return []string{"-p", prompt} },
+		masked: false,
+	},
+}
+
+// passwordPromptOrder is the fallback order; unmasked tools come last
+var passwordPromptOrder = []string{"rofi", "bemenu", "fuzzel", "zenity", "dmenu"}
+
+// promptCancelCode is the exit status rofi, bemenu, fuzzel, zenity and dmenu
+// all use when the user dismisses the prompt
+const promptCancelCode = 1
+
+// ErrCancelled is returned when the user dismisses a prompt
+var ErrCancelled = errors.New("cancelled")
+
+var (
+	promptPauseMu sync.Mutex
+	promptPause   func() (resume func())
+)
+
+// SetPromptPause sets the hook PromptPassword calls before its prompt; the
+// function the hook returns is called once the prompt closes. The
+// module_timeout watchdog uses it so the time spent typing a password does
+// not count, as with launcher menus. Pass nil to reset it.
+func SetPromptPause(pause func() (resume func())) {
+	promptPauseMu.Lock()
+	defer promptPauseMu.Unlock()
+	promptPause = pause
+}
+
+func pausePrompt() (resume func()) {
+	promptPauseMu.Lock()
+	pause := promptPause
+	promptPauseMu.Unlock()
+
+	if pause == nil {
+		return func() {}
 	}
+	return pause()
+}
 
-	// Try zenity (GUI password dialog)
-	if CommandExists("zenity") {
-		cmd := exec.Command("zenity", "--password", "--title", prompt)
-		output, err := cmd.Output()
-		if err == nil {
-			password := strings.TrimSpace(string(output))
-			if password != "" {
-				return password, nil
-			}
+// PromptPassword shows a password prompt, preferring the active launcher when it
+// can mask input. The fzf and tui launchers run in a terminal, so there the
+// password is read from it with echo off. Otherwise the next tool is tried
+// only when one is missing or fails to start: a dismissed or empty prompt
+// returns ErrCancelled instead of opening another one, which could be dmenu
+// showing the password in clear text.
+func PromptPassword(launcherName, prompt string) (string, error) {
+	defer pausePrompt()()
+
+	if launcherName == "fzf" || launcherName == "tui" {
+		if password, err := promptPasswordTerminal(prompt); !errors.Is(err, errNoTerminal) {
+			return password, err
 		}
 	}
 
-	// Try dmenu (no password masking, but works)
-	if CommandExists("dmenu") {
-		cmd := exec.Command("dmenu", "-p", prompt)
+	order := passwordPromptOrder
+	if p, ok := passwordPrompts[launcherName]; ok && p.masked {
+		order = append([]string{launcherName}, passwordPromptOrder...)
+	}
+
+	tried := make(map[string]bool)
+
+	for _, tool := range order {
+		if tried[tool] || !CommandExists(tool) {
+			continue
+		}
+		tried[tool] = true
+
+		cmd := exec.Command(tool, passwordPrompts[tool].args(prompt)...)
 		output, err := cmd.Output()
-		if err == nil {
-			password := strings.TrimSpace(string(output))
-			if password != "" {
-				return password, nil
-			}
+
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == promptCancelCode {
+			return "", ErrCancelled
+		}
+		if err != nil {
+			LogDebug("password prompt failed", "tool", tool, "error", err)
+			continue
+		}
+
+		password := strings.TrimSpace(string(output))
+		if password == "" {
+			return "", ErrCancelled
 		}
+		return password, nil
 	}
 
-	return "", fmt.Errorf("no password prompt tool found (rofi, zenity, dmenu)")
+	return "", fmt.Errorf("no password prompt tool found (%s)", strings.Join(passwordPromptOrder, ", "))
+}
+
+// errNoTerminal means there is no terminal to read a password from
+var errNoTerminal = errors.New("no terminal")
+
+// promptPasswordTerminal reads a line from the controlling terminal with
+// echo turned off. An empty line returns ErrCancelled.
+func promptPasswordTerminal(prompt string) (string, error) {
+	tty, err := os.OpenFile("/dev/tty", os.O_RDWR, 0)
+	if err != nil {
+		return "", errNoTerminal
+	}
+	defer tty.Close()
+
+	stty := func(arg string) error {
+		cmd := exec.Command("stty", arg)
+		cmd.Stdin = tty
+		return cmd.Run()
+	}
+	if err := stty("-echo"); err != nil {
+		// Never read a password that would be echoed
+		return "", errNoTerminal
+	}
+	defer stty("echo")
+
+	fmt.Fprintf(tty, "%s: ", prompt)
+	line, err := bufio.NewReader(tty).ReadString('\n')
+	fmt.Fprintln(tty)
+	if err != nil && line == "" {
+		return "", ErrCancelled
+	}
+
+	password := strings.TrimRight(line, "\r\n")
+	if password == "" {
+		return "", ErrCancelled
+	}
+	return password, nil
 }
 
 // ============================================================================
@@ -318,6 +565,42 @@ func IsTerminal() bool {
 	return true
 }
 
+// TerminalWidth returns the width of the controlling terminal in columns
+// (as the tty reports it, else $COLUMNS), or 0 when it cannot be determined
+func TerminalWidth() int {
+	return terminalWidth(ttyWidth, os.Getenv("COLUMNS"))
+}
+
+// terminalWidth prefers the width query reports over columns, the value of
+// $COLUMNS, which shells rarely export
+func terminalWidth(query func() (int, error), columns string) int {
+	if cols, err := query(); err == nil && cols > 0 {
+		return cols
+	}
+
+	if cols, err := strconv.Atoi(columns); err == nil && cols > 0 {
+		return cols
+	}
+
+	return 0
+}
+
+// ttyWidth asks the controlling terminal for its size, as term.GetSize does
+func ttyWidth() (int, error) {
+	tty, err := os.Open("/dev/tty")
+	if err != nil {
+		return 0, err
+	}
+	defer tty.Close()
+
+	var size struct{ Rows, Cols, XPixel, YPixel uint16 }
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, tty.Fd(), syscall.TIOCGWINSZ, uintptr(unsafe.Pointer(&size))); errno != 0 {
+		return 0, errno
+	}
+
+	return int(size.Cols), nil
+}
+
 // DetectTerminal detects available terminal emulator
 func DetectTerminal() string {
 	terminals := []string{